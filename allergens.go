@@ -0,0 +1,133 @@
+package structs
+
+import "strings"
+
+// levelOfContainmentRank orders the level of containment codes by precedence.
+// When agencies disagree on an allergen, the code with the highest rank wins.
+// DERIVED_FROM means the allergen is present, so it ranks with CONTAINS.
+func levelOfContainmentRank(code string) int {
+	switch code {
+	case LevelOfContainmentContains, LevelOfContainmentDerivedFrom:
+		return 4
+	case LevelOfContainmentMayContain:
+		return 3
+	case LevelOfContainmentFreeFrom:
+		return 2
+	case LevelOfContainmentUndeclared:
+		return 1
+	}
+	return 0
+}
+
+// AllergenPresence is the merged level of containment of one allergen across
+// all specification agencies.
+type AllergenPresence struct {
+	// Code indicating the type of allergen. Uses code list allergenTypeCode.
+	AllergenTypeCode string
+	// Level of containment code with the highest precedence.
+	LevelOfContainmentCode string
+	// Agencies which declared the level of containment code.
+	Agencies []string
+}
+
+// IsPresent reports whether the allergen is contained, derived from or may be contained.
+func (p AllergenPresence) IsPresent() bool {
+	return levelOfContainmentRank(p.LevelOfContainmentCode) >= levelOfContainmentRank(LevelOfContainmentMayContain)
+}
+
+// Allergens returns the allergens of all specification agencies merged by
+// allergen type code, in order of first appearance. The level of containment
+// is the one with the highest precedence: CONTAINS and DERIVED_FROM beat
+// MAY_CONTAIN, which beats FREE_FROM, which beats UNDECLARED. Codes are
+// compared case insensitively and returned in upper case.
+func (m AllergenInformationModule) Allergens() []AllergenPresence {
+	var allergens []AllergenPresence
+	index := map[string]int{}
+	for _, infos := range m.AllergenRelatedInformations {
+		for _, info := range infos {
+			for _, a := range info.Allergens {
+				typeCode := strings.ToUpper(strings.TrimSpace(a.AllergenTypeCode))
+				if typeCode == "" {
+					continue
+				}
+				level := strings.ToUpper(strings.TrimSpace(a.LevelOfContainmentCode))
+				i, ok := index[typeCode]
+				if !ok {
+					i = len(allergens)
+					index[typeCode] = i
+					allergens = append(allergens, AllergenPresence{AllergenTypeCode: typeCode})
+				}
+				p := &allergens[i]
+				switch rank, prev := levelOfContainmentRank(level), levelOfContainmentRank(p.LevelOfContainmentCode); {
+				case !ok || rank > prev:
+					p.LevelOfContainmentCode = level
+					p.Agencies = nil
+				case rank < prev:
+					continue
+				}
+				if !containsString(p.Agencies, info.AllergenSpecificationAgency) {
+					p.Agencies = append(p.Agencies, info.AllergenSpecificationAgency)
+				}
+			}
+		}
+	}
+	return allergens
+}
+
+func containsString(list []string, s string) bool {
+	for _, l := range list {
+		if l == s {
+			return true
+		}
+	}
+	return false
+}
+
+// Allergen returns the merged level of containment of the allergen type code.
+// False is returned when no agency declares the allergen.
+func (m AllergenInformationModule) Allergen(typeCode string) (AllergenPresence, bool) {
+	typeCode = strings.ToUpper(strings.TrimSpace(typeCode))
+	for _, p := range m.Allergens() {
+		if p.AllergenTypeCode == typeCode {
+			return p, true
+		}
+	}
+	return AllergenPresence{}, false
+}
+
+// level returns the merged level of containment code of the allergen type
+// code, or an empty string when the allergen is not declared.
+func (m AllergenInformationModule) level(typeCode string) string {
+	p, _ := m.Allergen(typeCode)
+	return p.LevelOfContainmentCode
+}
+
+// Contains reports whether the product contains the allergen or is derived
+// from it according to any agency.
+func (m AllergenInformationModule) Contains(typeCode string) bool {
+	return levelOfContainmentRank(m.level(typeCode)) == levelOfContainmentRank(LevelOfContainmentContains)
+}
+
+// MayContain reports whether the product may contain the allergen and no
+// agency declares that it contains it.
+func (m AllergenInformationModule) MayContain(typeCode string) bool {
+	return m.level(typeCode) == LevelOfContainmentMayContain
+}
+
+// FreeFrom reports whether the product is declared free from the allergen and
+// no agency declares that it contains or may contain it.
+func (m AllergenInformationModule) FreeFrom(typeCode string) bool {
+	return m.level(typeCode) == LevelOfContainmentFreeFrom
+}
+
+// Present returns the type codes of the allergens which the product contains,
+// is derived from or may contain, in order of first appearance.
+func (m AllergenInformationModule) Present() []string {
+	var codes []string
+	for _, p := range m.Allergens() {
+		if p.IsPresent() {
+			codes = append(codes, p.AllergenTypeCode)
+		}
+	}
+	return codes
+}
@@ -0,0 +1,72 @@
+package structs
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func allergenModule(t *testing.T, data string) AllergenInformationModule {
+	t.Helper()
+	var m AllergenInformationModule
+	if err := json.Unmarshal([]byte(data), &m); err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+func TestAllergens(t *testing.T) {
+	m := allergenModule(t, `{"allergenRelatedInformation": [[
+		{"allergenSpecificationAgency": "EU", "allergen": [
+			{"allergenTypeCode": "am", "levelOfContainmentCode": "may_contain"},
+			{"allergenTypeCode": "AC", "levelOfContainmentCode": "FREE_FROM"},
+			{"allergenTypeCode": "AE", "levelOfContainmentCode": "UNDECLARED"},
+			{"allergenTypeCode": " ", "levelOfContainmentCode": "CONTAINS"}
+		]},
+		{"allergenSpecificationAgency": "FDA", "allergen": [
+			{"allergenTypeCode": "AM", "levelOfContainmentCode": "CONTAINS"},
+			{"allergenTypeCode": "AC", "levelOfContainmentCode": "FREE_FROM"},
+			{"allergenTypeCode": "AW", "levelOfContainmentCode": "DERIVED_FROM"}
+		]}
+	], [
+		{"allergenSpecificationAgency": "CODEX", "allergen": [
+			{"allergenTypeCode": "AM", "levelOfContainmentCode": "DERIVED_FROM"},
+			{"allergenTypeCode": "AC", "levelOfContainmentCode": "UNDECLARED"},
+			{"allergenTypeCode": "AE", "levelOfContainmentCode": "MAY_CONTAIN"}
+		]}
+	]]}`)
+	want := []AllergenPresence{
+		{AllergenTypeCode: "AM", LevelOfContainmentCode: "CONTAINS", Agencies: []string{"FDA", "CODEX"}},
+		{AllergenTypeCode: "AC", LevelOfContainmentCode: "FREE_FROM", Agencies: []string{"EU", "FDA"}},
+		{AllergenTypeCode: "AE", LevelOfContainmentCode: "MAY_CONTAIN", Agencies: []string{"CODEX"}},
+		{AllergenTypeCode: "AW", LevelOfContainmentCode: "DERIVED_FROM", Agencies: []string{"FDA"}},
+	}
+	if got := m.Allergens(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Allergens() = %+v, want %+v", got, want)
+	}
+
+	for _, tt := range []struct {
+		code                       string
+		contains, mayContain, free bool
+	}{
+		{"am", true, false, false},
+		{"AW", true, false, false},
+		{"AE", false, true, false},
+		{"AC", false, false, true},
+		{"AN", false, false, false},
+	} {
+		if m.Contains(tt.code) != tt.contains || m.MayContain(tt.code) != tt.mayContain || m.FreeFrom(tt.code) != tt.free {
+			t.Errorf("%s: Contains() = %v, MayContain() = %v, FreeFrom() = %v", tt.code,
+				m.Contains(tt.code), m.MayContain(tt.code), m.FreeFrom(tt.code))
+		}
+	}
+	if _, ok := m.Allergen("AN"); ok {
+		t.Error("Allergen(AN) found an undeclared allergen")
+	}
+	if got := m.Present(); !reflect.DeepEqual(got, []string{"AM", "AE", "AW"}) {
+		t.Errorf("Present() = %q", got)
+	}
+	if got := (AllergenInformationModule{}).Allergens(); got != nil {
+		t.Errorf("empty module Allergens() = %+v", got)
+	}
+}
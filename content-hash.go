@@ -0,0 +1,237 @@
+package structs
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+	"sort"
+)
+
+// ErrUnknownModule is returned for module names which are not JSON names of
+// TradeItemExtension fields.
+var ErrUnknownModule = errors.New("unknown module")
+
+// hashExcluded are the locations left out of canonical JSON because they do
+// not describe the product content.
+var hashExcluded = []string{
+	JoinPointer("", "tradeItem", "tradeItemSynchronisationDates", "lastChangeDateTime"),
+}
+
+// unorderedLists are the paths of the lists whose order does not carry
+// meaning: localized texts, which are told apart by language code, sets of
+// codes and lists keyed by a code. The token "*" stands for any list index.
+// The elements of lists of lists, such as allergenRelatedInformation, are
+// unordered too.
+var unorderedLists = map[string]bool{}
+
+func init() {
+	for _, p := range []string{
+		"/tradeItem/additionalTradeItemIdentification",
+		"/tradeItem/gdsnTradeItemClassification/additionalTradeItemClassification",
+		"/tradeItem/tradeItemContactInformation/*/contactDescription",
+		"/tradeItem/tradeItemInformation/extensions/allergenInformationModule/allergenRelatedInformation",
+		"/tradeItem/tradeItemInformation/extensions/allergenInformationModule/allergenRelatedInformation/*/*/allergen",
+		"/tradeItem/tradeItemInformation/extensions/allergenInformationModule/allergenRelatedInformation/*/*/allergenStatement",
+		"/tradeItem/tradeItemInformation/extensions/allergenInformationModule/allergenRelatedInformation/*/*/allergenStatement/*/x_emphasis",
+		"/tradeItem/tradeItemInformation/extensions/consumerInstructionsModule/consumerInstructions/consumerStorageInstructions",
+		"/tradeItem/tradeItemInformation/extensions/consumerInstructionsModule/consumerInstructions/consumerUsageInstructions",
+		"/tradeItem/tradeItemInformation/extensions/dgCodeListModule/codeList/*/codeListRecord/*/description",
+		"/tradeItem/tradeItemInformation/extensions/dgCodeListModule/codeList/*/codeListRecord/*/label",
+		"/tradeItem/tradeItemInformation/extensions/dgCodeListModule/codeList/*/codeListRecord/*/name",
+		"/tradeItem/tradeItemInformation/extensions/dgMediaModule/media/*/mediaLanguageCode",
+		"/tradeItem/tradeItemInformation/extensions/dgMediaModule/media/*/mediaName",
+		"/tradeItem/tradeItemInformation/extensions/dgMediaModule/media/*/mediaStateDescription",
+		"/tradeItem/tradeItemInformation/extensions/dgProductAttributeModule/productAttributeGroup/*/productAttribute/*/productAttributeName",
+		"/tradeItem/tradeItemInformation/extensions/dgProductAttributeModule/productAttributeGroup/*/productAttribute/*/productAttributeValueString",
+		"/tradeItem/tradeItemInformation/extensions/dgProductAttributeModule/productAttributeGroup/*/productAttributeGroupName",
+		"/tradeItem/tradeItemInformation/extensions/dietInformationModule/dietInformation/dietTypeDescription",
+		"/tradeItem/tradeItemInformation/extensions/dietInformationModule/dietInformation/dietTypeInformation",
+		"/tradeItem/tradeItemInformation/extensions/farmingAndProcessingInformationModule/tradeItemFarmingAndProcessing/preservationTechniqueCode",
+		"/tradeItem/tradeItemInformation/extensions/farmingAndProcessingInformationModule/tradeItemOrganicInformation/organicClaim",
+		"/tradeItem/tradeItemInformation/extensions/farmingAndProcessingInformationModule/tradeItemOrganicInformation/organicClaim/*/organicClaimAgencyCode",
+		"/tradeItem/tradeItemInformation/extensions/foodAndBeverageIngredientModule/foodAndBeverageIngredient/*/ingredientFarmingProcessing/preservationTechniqueCode",
+		"/tradeItem/tradeItemInformation/extensions/foodAndBeverageIngredientModule/foodAndBeverageIngredient/*/ingredientName/*/*/x_emphasis",
+		"/tradeItem/tradeItemInformation/extensions/foodAndBeverageIngredientModule/foodAndBeverageIngredient/*/ingredientOrganicInformation/organicClaim",
+		"/tradeItem/tradeItemInformation/extensions/foodAndBeverageIngredientModule/foodAndBeverageIngredient/*/ingredientOrganicInformation/organicClaim/*/organicClaimAgencyCode",
+		"/tradeItem/tradeItemInformation/extensions/foodAndBeverageIngredientModule/foodAndBeverageIngredient/*/ingredientPlaceOfActivity/*/countryOfOriginStatement",
+		"/tradeItem/tradeItemInformation/extensions/foodAndBeverageIngredientModule/foodAndBeverageIngredient/*/ingredientPlaceOfActivity/*/productActivityDetails/*/x_statement",
+		"/tradeItem/tradeItemInformation/extensions/foodAndBeverageIngredientModule/foodAndBeverageIngredient/*/ingredientPlaceOfActivity/*/provenanceStatement",
+		"/tradeItem/tradeItemInformation/extensions/foodAndBeverageIngredientModule/ingredientStatement",
+		"/tradeItem/tradeItemInformation/extensions/foodAndBeverageIngredientModule/x_additionalIngredientStatement",
+		"/tradeItem/tradeItemInformation/extensions/foodAndBeveragePreparationServingModule/preparationServing/*/preparationInstructions",
+		"/tradeItem/tradeItemInformation/extensions/foodAndBeveragePreparationServingModule/preparationServing/*/servingSuggestion",
+		"/tradeItem/tradeItemInformation/extensions/marketingInformationModule/marketingInformation/tradeItemKeyWords",
+		"/tradeItem/tradeItemInformation/extensions/marketingInformationModule/marketingInformation/tradeItemMarketingMessage",
+		"/tradeItem/tradeItemInformation/extensions/nonfoodIngredientModule/nonfoodIngredient/*/x_emphasis",
+		"/tradeItem/tradeItemInformation/extensions/nonfoodIngredientModule/nonfoodIngredientOfConcernCode",
+		"/tradeItem/tradeItemInformation/extensions/nonfoodIngredientModule/nonfoodIngredientStatement",
+		"/tradeItem/tradeItemInformation/extensions/nutritionalInformationModule/nutrientHeader",
+		"/tradeItem/tradeItemInformation/extensions/nutritionalInformationModule/nutrientHeader/*/nutrientDetail",
+		"/tradeItem/tradeItemInformation/extensions/nutritionalInformationModule/nutrientHeader/*/servingSizeDescription",
+		"/tradeItem/tradeItemInformation/extensions/nutritionalInformationModule/nutritionalClaim",
+		"/tradeItem/tradeItemInformation/extensions/packagingInformationModule/packaging/*/packagingRecyclingProcessTypeCode",
+		"/tradeItem/tradeItemInformation/extensions/packagingMarkingModule/packagingMarking/packagingMarkedLabelAccreditationCode",
+		"/tradeItem/tradeItemInformation/extensions/placeOfItemActivityModule/placeOfProductActivity/countryOfOriginStatement",
+		"/tradeItem/tradeItemInformation/extensions/placeOfItemActivityModule/placeOfProductActivity/productActivityDetails/*/x_statement",
+		"/tradeItem/tradeItemInformation/extensions/placeOfItemActivityModule/placeOfProductActivity/provenanceStatement",
+		"/tradeItem/tradeItemInformation/extensions/productCharacteristicsModule/productCharacteristics/*/productCharacteristicValueDescription",
+		"/tradeItem/tradeItemInformation/extensions/safetyDataSheetModule/safetyDataSheetInformation/*/gHSDetail/gHSSymbolDescriptionCode",
+		"/tradeItem/tradeItemInformation/extensions/safetyDataSheetModule/safetyDataSheetInformation/*/gHSDetail/hazardStatement/*/hazardStatementsDescription",
+		"/tradeItem/tradeItemInformation/extensions/safetyDataSheetModule/safetyDataSheetInformation/*/gHSDetail/precautionaryStatement/*/precautionaryStatementsDescription",
+		"/tradeItem/tradeItemInformation/extensions/salesInformationModule/salesInformation/consumerSalesConditionCode",
+		"/tradeItem/tradeItemInformation/extensions/tradeItemDescriptionModule/tradeItemDescriptionInformation/additionalTradeItemDescription",
+		"/tradeItem/tradeItemInformation/extensions/tradeItemDescriptionModule/tradeItemDescriptionInformation/brandNameInformation/languageSpecificBrandName",
+		"/tradeItem/tradeItemInformation/extensions/tradeItemDescriptionModule/tradeItemDescriptionInformation/brandNameInformation/languageSpecificSubbrandName",
+		"/tradeItem/tradeItemInformation/extensions/tradeItemDescriptionModule/tradeItemDescriptionInformation/descriptionShort",
+		"/tradeItem/tradeItemInformation/extensions/tradeItemDescriptionModule/tradeItemDescriptionInformation/functionalName",
+		"/tradeItem/tradeItemInformation/extensions/tradeItemDescriptionModule/tradeItemDescriptionInformation/tradeItemDescription",
+		"/tradeItem/tradeItemInformation/extensions/tradeItemDescriptionModule/tradeItemDescriptionInformation/variantDescription",
+		"/tradeItem/tradeItemInformation/extensions/tradeItemTemperatureInformationModule/tradeItemTemperatureInformation",
+	} {
+		unorderedLists[p] = true
+	}
+}
+
+// CanonicalJSON returns the product as canonical JSON for comparing and
+// hashing content. Products with the same content have the same canonical
+// JSON regardless of how they were encoded:
+//
+//   - Object members are sorted by name.
+//   - Members having zero values are left out, as encoding can't tell them
+//     from absent ones. Valid Null values, such as NullBool false or
+//     NullFloat64 0, are given values and kept.
+//   - Numbers are written in shortest exact decimal form, so 1.50 is 1.5.
+//   - The elements of the lists in unorderedLists, for example localized
+//     texts and allergens, are sorted. Other lists keep their order, as it
+//     may carry meaning, for example which net content is stated first.
+//   - The last change date time is left out.
+//
+// When module names are given, for example "nutritionalInformationModule",
+// only those modules of tradeItem/tradeItemInformation/extensions are
+// included, each under its JSON name. Unknown module names are an error.
+func (d MasterProductData) CanonicalJSON(modules ...string) ([]byte, error) {
+	tree, err := jsonTree(d)
+	if err != nil {
+		return nil, err
+	}
+	given := givenValues{}
+	given.collect(reflect.ValueOf(d), "")
+	for _, p := range hashExcluded {
+		tokens, _ := SplitPointer(p)
+		if _, err := getJSON(tree, tokens); err == nil {
+			tree, _, _ = removeJSON(tree, tokens)
+		}
+	}
+	if len(modules) > 0 {
+		path := []string{"tradeItem", "tradeItemInformation", "extensions"}
+		extensions, err := getJSON(tree, path)
+		if err != nil {
+			return nil, err
+		}
+		selected := make(map[string]interface{}, len(modules))
+		for _, name := range modules {
+			m, err := getJSON(extensions, []string{name})
+			if err != nil {
+				return nil, fmt.Errorf("%q: %w", name, ErrUnknownModule)
+			}
+			selected[name] = given.prune(m, JoinPointer("", append(path, name)...))
+		}
+		tree = selected
+	} else {
+		tree = given.prune(tree, "")
+	}
+	root := ""
+	if len(modules) > 0 {
+		root = JoinPointer("", "tradeItem", "tradeItemInformation", "extensions")
+	}
+	var buf bytes.Buffer
+	writeCanonicalJSON(&buf, tree, root, false)
+	return buf.Bytes(), nil
+}
+
+// ContentHash returns the hex encoded SHA-256 hash of the canonical JSON of
+// the product or of the given modules. See CanonicalJSON.
+func (d MasterProductData) ContentHash(modules ...string) (string, error) {
+	data, err := d.CanonicalJSON(modules...)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// writeCanonicalJSON writes v, found at path, as canonical JSON. The list
+// indices of path are "*". Unordered tells whether a list v is in
+// unorderedLists, or an element of one.
+func writeCanonicalJSON(buf *bytes.Buffer, v interface{}, path string, unordered bool) {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		buf.WriteByte('{')
+		for i, k := range sortedKeys(v) {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			key, _ := json.Marshal(k)
+			buf.Write(key)
+			buf.WriteByte(':')
+			p := JoinPointer(path, k)
+			writeCanonicalJSON(buf, v[k], p, unorderedLists[p])
+		}
+		buf.WriteByte('}')
+	case []interface{}:
+		elems := make([]string, len(v))
+		for i, e := range v {
+			var b bytes.Buffer
+			writeCanonicalJSON(&b, e, JoinPointer(path, "*"), unordered)
+			elems[i] = b.String()
+		}
+		if unordered {
+			sort.Strings(elems)
+		}
+		buf.WriteByte('[')
+		for i, e := range elems {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			buf.WriteString(e)
+		}
+		buf.WriteByte(']')
+	case json.Number:
+		buf.WriteString(canonicalNumber(v))
+	default:
+		data, _ := json.Marshal(v)
+		buf.Write(data)
+	}
+}
+
+// canonicalNumber returns the number in shortest exact decimal form without
+// exponent.
+func canonicalNumber(n json.Number) string {
+	r, ok := new(big.Rat).SetString(string(n))
+	if !ok {
+		return string(n)
+	}
+	if r.IsInt() {
+		return r.Num().String()
+	}
+	// A decimal number has a denominator of the form 2^a * 5^b and needs
+	// max(a, b) fractional digits.
+	den := new(big.Int).Set(r.Denom())
+	digits := int(den.TrailingZeroBits())
+	five := big.NewInt(5)
+	for fives, m := 0, new(big.Int); ; fives++ {
+		if fives > digits {
+			digits = fives
+		}
+		if m.Mod(den, five).Sign() != 0 {
+			break
+		}
+		den.Quo(den, five)
+	}
+	return r.FloatString(digits)
+}
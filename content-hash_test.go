@@ -0,0 +1,137 @@
+package structs
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCanonicalJSON(t *testing.T) {
+	d := masterProduct(t, `{
+		"gtin": "4006381333931",
+		"name": "",
+		"tradeItem": {
+			"tradeItemSynchronisationDates": {"lastChangeDateTime": "2020-05-01T10:00:00Z"},
+			"tradeItemInformation": {"extensions": {
+				"foodAndBeverageIngredientModule": {"juiceContentPercent": 0, "x_isFoodOrBeverage": false},
+				"tradeItemDescriptionModule": {"tradeItemDescriptionInformation": {"tradeItemDescription": [
+					{"$": "Mjölk", "@languageCode": "sv"}, {"$": "Maito", "@languageCode": "fi"}
+				]}},
+				"tradeItemMeasurementsModule": {"tradeItemMeasurements": {"netContent": [
+					{"$": 6, "@measurementUnitCode": "H87"}, {"$": 1.50, "@measurementUnitCode": "LTR"}
+				]}}
+			}}
+		}
+	}`)
+	got, err := d.CanonicalJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"gtin":"4006381333931","tradeItem":{"tradeItemInformation":{"extensions":{` +
+		`"foodAndBeverageIngredientModule":{"juiceContentPercent":0,"x_isFoodOrBeverage":false},` +
+		`"tradeItemDescriptionModule":{"tradeItemDescriptionInformation":{"tradeItemDescription":[{"$":"Maito","@languageCode":"fi"},{"$":"Mjölk","@languageCode":"sv"}]}},` +
+		`"tradeItemMeasurementsModule":{"tradeItemMeasurements":{"netContent":[{"$":6,"@measurementUnitCode":"H87"},{"$":1.5,"@measurementUnitCode":"LTR"}]}}}}}}`
+	if string(got) != want {
+		t.Errorf("CanonicalJSON() = %s\nwant %s", got, want)
+	}
+
+	modules, err := d.CanonicalJSON("foodAndBeverageIngredientModule", "allergenInformationModule")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"allergenInformationModule":{},"foodAndBeverageIngredientModule":{"juiceContentPercent":0,"x_isFoodOrBeverage":false}}`; string(modules) != want {
+		t.Errorf("CanonicalJSON(modules) = %s, want %s", modules, want)
+	}
+	if _, err := d.CanonicalJSON("tradeItemDescription"); !errors.Is(err, ErrUnknownModule) {
+		t.Errorf("CanonicalJSON(tradeItemDescription) error = %v", err)
+	}
+}
+
+func TestContentHash(t *testing.T) {
+	hash := func(d MasterProductData, modules ...string) string {
+		t.Helper()
+		h, err := d.ContentHash(modules...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return h
+	}
+	a := masterProduct(t, `{"gtin": "4006381333931", "tradeItem": {
+		"tradeItemSynchronisationDates": {"lastChangeDateTime": "2020-05-01T10:00:00Z"},
+		"tradeItemInformation": {"extensions": {"allergenInformationModule": {"allergenRelatedInformation": [[
+			{"allergenSpecificationAgency": "EU", "allergen": [
+				{"allergenTypeCode": "AM", "levelOfContainmentCode": "CONTAINS"},
+				{"allergenTypeCode": "AW", "levelOfContainmentCode": "MAY_CONTAIN"}
+			]}
+		]]}}}
+	}}`)
+	b := masterProduct(t, `{"gtin": "4006381333931", "tradeItem": {
+		"tradeItemSynchronisationDates": {"lastChangeDateTime": "2021-01-01T00:00:00Z"},
+		"tradeItemInformation": {"extensions": {"allergenInformationModule": {"allergenRelatedInformation": [[
+			{"allergenSpecificationAgency": "EU", "allergen": [
+				{"allergenTypeCode": "AW", "levelOfContainmentCode": "MAY_CONTAIN"},
+				{"allergenTypeCode": "AM", "levelOfContainmentCode": "CONTAINS"}
+			]}
+		]]}}}
+	}}`)
+	if h := hash(a); len(h) != 64 || h != hash(b) {
+		t.Errorf("ContentHash() = %s and %s, want equal", h, hash(b))
+	}
+
+	c := a
+	c.TradeItem.TradeItemInformation.Extension.FoodAndBeverageIngredientModule.XIsFoodOrBeverage = ValidBool(false)
+	if hash(c) == hash(a) {
+		t.Error("a valid NullBool false does not change the hash")
+	}
+	if hash(c, "allergenInformationModule") != hash(a, "allergenInformationModule") {
+		t.Error("a change outside the module changes the module hash")
+	}
+
+	// The order of net contents carries meaning.
+	var x, y MasterProductData
+	x.TradeItem.TradeItemInformation.Extension.TradeItemMeasurementsModule.TradeItemMeasurements.NetContent = []GDSNNetContent{
+		{Measurement: mustDecimal("6"), MeasurementUnitCode: UnitPiece}, {Measurement: mustDecimal("330"), MeasurementUnitCode: UnitGram},
+	}
+	y.TradeItem.TradeItemInformation.Extension.TradeItemMeasurementsModule.TradeItemMeasurements.NetContent = []GDSNNetContent{
+		x.TradeItem.TradeItemInformation.Extension.TradeItemMeasurementsModule.TradeItemMeasurements.NetContent[1],
+		x.TradeItem.TradeItemInformation.Extension.TradeItemMeasurementsModule.TradeItemMeasurements.NetContent[0],
+	}
+	if hash(x) == hash(y) {
+		t.Error("reordered net contents have the same hash")
+	}
+}
+
+func TestUnorderedListsExist(t *testing.T) {
+	for path := range unorderedLists {
+		tokens, _ := SplitPointer(path)
+		typ, ok := reflect.TypeOf(MasterProductData{}), true
+		for _, token := range tokens {
+			if token == "*" {
+				if typ.Kind() != reflect.Slice {
+					ok = false
+					break
+				}
+				typ = typ.Elem()
+			} else if typ, ok = jsonField(typ, token); !ok {
+				break
+			}
+		}
+		if !ok || typ.Kind() != reflect.Slice {
+			t.Errorf("unordered list %s is not a list of MasterProductData", path)
+		}
+	}
+}
+
+// jsonField returns the type of the field of struct type t with the JSON name.
+func jsonField(t reflect.Type, name string) (reflect.Type, bool) {
+	if t.Kind() != reflect.Struct {
+		return nil, false
+	}
+	for i := 0; i < t.NumField(); i++ {
+		if f := t.Field(i); strings.Split(f.Tag.Get("json"), ",")[0] == name {
+			return f.Type, true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,131 @@
+package structs
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// UnsupportedVersionError is returned when a document has a data format
+// version which can't be decoded: the version is malformed, newer than
+// CurrentDataFormatVersion or has no registered upgrade.
+type UnsupportedVersionError struct {
+	// Value of x_dataFormatVersion.
+	Version string
+	// Reason, for example a parse error.
+	Err error
+}
+
+// Error implements error interface.
+func (e *UnsupportedVersionError) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("unsupported data format version %q: %v", e.Version, e.Err)
+	}
+	return fmt.Sprintf("unsupported data format version %q", e.Version)
+}
+
+// Unwrap returns the reason.
+func (e *UnsupportedVersionError) Unwrap() error {
+	return e.Err
+}
+
+// UpgradeFunc upgrades a decoded document from one major data format version
+// to the next. Numbers in the document are json.Number.
+type UpgradeFunc func(doc map[string]interface{}) (map[string]interface{}, error)
+
+var upgrades = struct {
+	sync.RWMutex
+	m map[int]UpgradeFunc
+}{m: map[int]UpgradeFunc{}}
+
+// RegisterUpgrade registers the upgrade of documents from major version
+// fromMajor to fromMajor + 1. Documents of older versions are upgraded step
+// by step to the current version by DecodeProduct. A later registration for
+// the same version replaces the earlier one.
+func RegisterUpgrade(fromMajor int, f UpgradeFunc) {
+	upgrades.Lock()
+	defer upgrades.Unlock()
+	upgrades.m[fromMajor] = f
+}
+
+// DataFormatMajor returns the major version of a data format version, for
+// example 1 for "1.2". A leading "v" is accepted. The empty version is
+// CurrentDataFormatVersion.
+func DataFormatMajor(version string) (int, error) {
+	v := strings.TrimPrefix(strings.TrimSpace(version), "v")
+	if v == "" {
+		v = CurrentDataFormatVersion
+	}
+	if i := strings.IndexByte(v, '.'); i >= 0 {
+		v = v[:i]
+	}
+	major, err := strconv.Atoi(v)
+	if err != nil || major < 0 {
+		return 0, &UnsupportedVersionError{Version: version, Err: errors.New("malformed version")}
+	}
+	return major, nil
+}
+
+// DecodeProduct decodes a product document of any supported data format
+// version. The header is read first. Documents of the current major version
+// are decoded as such. Documents of older major versions are upgraded with
+// the registered upgrades, and their x_dataFormatVersion is set to
+// CurrentDataFormatVersion. An *UnsupportedVersionError is returned for
+// malformed and unknown versions.
+func DecodeProduct(data []byte) (MasterProductData, error) {
+	var head struct {
+		Header MasterProductHeaders `json:"Header"`
+	}
+	if err := json.Unmarshal(data, &head); err != nil {
+		return MasterProductData{}, err
+	}
+	version := head.Header.XDataFormatVersion
+	major, err := DataFormatMajor(version)
+	if err != nil {
+		return MasterProductData{}, err
+	}
+	current, _ := DataFormatMajor(CurrentDataFormatVersion)
+	if major > current {
+		return MasterProductData{}, &UnsupportedVersionError{Version: version}
+	}
+	var d MasterProductData
+	if major == current {
+		err := json.Unmarshal(data, &d)
+		return d, err
+	}
+
+	tree, err := decodeJSONTree(data)
+	if err != nil {
+		return MasterProductData{}, err
+	}
+	doc, _ := tree.(map[string]interface{})
+	// The upgrades run without the lock, so that they may register upgrades.
+	chain := make([]UpgradeFunc, 0, current-major)
+	upgrades.RLock()
+	for v := major; v < current; v++ {
+		f, ok := upgrades.m[v]
+		if !ok {
+			upgrades.RUnlock()
+			return MasterProductData{}, &UnsupportedVersionError{Version: version, Err: fmt.Errorf("no upgrade from %d to %d", v, v+1)}
+		}
+		chain = append(chain, f)
+	}
+	upgrades.RUnlock()
+	for i, f := range chain {
+		v := major + i
+		if doc, err = f(doc); err != nil {
+			return MasterProductData{}, &UnsupportedVersionError{Version: version, Err: fmt.Errorf("upgrade from %d to %d: %w", v, v+1, err)}
+		}
+	}
+	if data, err = json.Marshal(doc); err != nil {
+		return MasterProductData{}, err
+	}
+	if err := json.Unmarshal(data, &d); err != nil {
+		return MasterProductData{}, err
+	}
+	d.Header.XDataFormatVersion = CurrentDataFormatVersion
+	return d, nil
+}
@@ -0,0 +1,86 @@
+package structs
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestDataFormatMajor(t *testing.T) {
+	for _, tt := range []struct {
+		version string
+		want    int
+	}{
+		{"", 1},
+		{"1.0", 1},
+		{" v2.3.1 ", 2},
+		{"0", 0},
+	} {
+		if got, err := DataFormatMajor(tt.version); err != nil || got != tt.want {
+			t.Errorf("DataFormatMajor(%q) = %d, %v, want %d", tt.version, got, err, tt.want)
+		}
+	}
+	for _, version := range []string{"x", "-1.0", ".5", "1,0"} {
+		var e *UnsupportedVersionError
+		if _, err := DataFormatMajor(version); !errors.As(err, &e) || e.Version != version {
+			t.Errorf("DataFormatMajor(%q) error = %v", version, err)
+		}
+	}
+}
+
+func TestDecodeProduct(t *testing.T) {
+	d, err := DecodeProduct([]byte(`{"gtin": "4006381333931", "Header": {"x_dataFormatVersion": "1.4"}}`))
+	if err != nil || d.Gtin != "4006381333931" || d.Header.XDataFormatVersion != "1.4" {
+		t.Errorf("DecodeProduct(1.4) = %+v, %v", d, err)
+	}
+	if d, err := DecodeProduct([]byte(`{"gtin": "96385074"}`)); err != nil || d.Gtin != "96385074" {
+		t.Errorf("DecodeProduct(no version) = %+v, %v", d, err)
+	}
+
+	var e *UnsupportedVersionError
+	if _, err := DecodeProduct([]byte(`{"Header": {"x_dataFormatVersion": "2.0"}}`)); !errors.As(err, &e) || e.Version != "2.0" || e.Err != nil {
+		t.Errorf("DecodeProduct(2.0) error = %v", err)
+	}
+	if _, err := DecodeProduct([]byte(`{"Header": {"x_dataFormatVersion": "0.9"}}`)); !errors.As(err, &e) || e.Err == nil {
+		t.Errorf("DecodeProduct(0.9) without upgrade error = %v", err)
+	}
+	if _, err := DecodeProduct([]byte(`{"Header": {"x_dataFormatVersion": 1}}`)); err == nil || errors.As(err, &e) {
+		t.Errorf("DecodeProduct(numeric version) error = %v", err)
+	}
+}
+
+func TestDecodeProductUpgrade(t *testing.T) {
+	defer func() {
+		upgrades.Lock()
+		delete(upgrades.m, 0)
+		delete(upgrades.m, -1)
+		upgrades.Unlock()
+	}()
+	// Version 0 had the GTIN under "ean".
+	RegisterUpgrade(0, func(doc map[string]interface{}) (map[string]interface{}, error) {
+		doc["gtin"] = doc["ean"]
+		delete(doc, "ean")
+		return doc, nil
+	})
+	d, err := DecodeProduct([]byte(`{"ean": "4006381333931", "Header": {"x_dataFormatVersion": "0.3"}}`))
+	if err != nil || d.Gtin != "4006381333931" || d.Header.XDataFormatVersion != CurrentDataFormatVersion {
+		t.Errorf("DecodeProduct(0.3) = %+v, %v", d, err)
+	}
+
+	// An upgrade may register upgrades while running.
+	RegisterUpgrade(0, func(doc map[string]interface{}) (map[string]interface{}, error) {
+		RegisterUpgrade(-1, nil)
+		return doc, nil
+	})
+	if _, err := DecodeProduct([]byte(`{"Header": {"x_dataFormatVersion": "0.3"}}`)); err != nil {
+		t.Errorf("DecodeProduct(0.3) with registering upgrade error = %v", err)
+	}
+
+	failure := errors.New("no GTIN")
+	RegisterUpgrade(0, func(doc map[string]interface{}) (map[string]interface{}, error) {
+		return nil, failure
+	})
+	var e *UnsupportedVersionError
+	if _, err := DecodeProduct([]byte(`{"Header": {"x_dataFormatVersion": "0.3"}}`)); !errors.As(err, &e) || !errors.Is(err, failure) {
+		t.Errorf("DecodeProduct(0.3) with failing upgrade error = %v", err)
+	}
+}
@@ -0,0 +1,272 @@
+package structs
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Decimal is a decimal number, for example a measurement value. It keeps the
+// number exactly as given in the data, so no precision is lost in decoding
+// and encoding. It decodes from JSON numbers as well as from numbers sent as
+// JSON strings, for example "12.5". A comma is accepted as decimal separator
+// in strings. The zero value is 0.
+type Decimal struct {
+	text string
+}
+
+// maxDecimalExponent is the largest exponent ParseDecimal accepts, positive
+// or negative. The exact value of a decimal with a larger exponent needs an
+// integer of millions of digits.
+const maxDecimalExponent = 1000
+
+// ParseDecimal parses a decimal number. Surrounding white space and a leading
+// plus sign are ignored. Exponents beyond ±1000 are rejected.
+func ParseDecimal(s string) (Decimal, error) {
+	s = strings.TrimSpace(s)
+	s = strings.TrimPrefix(s, "+")
+	if strings.Count(s, ",") == 1 && !strings.Contains(s, ".") {
+		s = strings.Replace(s, ",", ".", 1)
+	}
+	if !isDecimal(s) {
+		return Decimal{}, fmt.Errorf("structs: invalid decimal %q", s)
+	}
+	if i := strings.IndexAny(s, "eE"); i >= 0 {
+		if exp, err := strconv.Atoi(s[i+1:]); err != nil || exp > maxDecimalExponent || exp < -maxDecimalExponent {
+			return Decimal{}, fmt.Errorf("structs: exponent of decimal %q out of range", s)
+		}
+	}
+	return Decimal{text: canonicalDecimal(s)}, nil
+}
+
+// canonicalDecimal turns a number accepted by isDecimal into a valid JSON
+// number by removing leading zeros and completing a missing integer part.
+// Significant digits are kept as they are.
+func canonicalDecimal(s string) string {
+	sign := ""
+	if s[0] == '-' {
+		sign, s = "-", s[1:]
+	}
+	end := strings.IndexAny(s, ".eE")
+	if end < 0 {
+		end = len(s)
+	}
+	integer := strings.TrimLeft(s[:end], "0")
+	if integer == "" {
+		integer = "0"
+	}
+	rest := s[end:]
+	if strings.HasPrefix(rest, ".") && (len(rest) == 1 || rest[1] == 'e' || rest[1] == 'E') {
+		rest = rest[1:]
+	}
+	return sign + integer + rest
+}
+
+// isDecimal reports whether s is a number in JSON number syntax, except that
+// leading zeros and a missing integer part (.5) are accepted.
+func isDecimal(s string) bool {
+	i := 0
+	if i < len(s) && s[i] == '-' {
+		i++
+	}
+	digits := 0
+	for i < len(s) && s[i] >= '0' && s[i] <= '9' {
+		i++
+		digits++
+	}
+	if i < len(s) && s[i] == '.' {
+		i++
+		for i < len(s) && s[i] >= '0' && s[i] <= '9' {
+			i++
+			digits++
+		}
+	}
+	if digits == 0 {
+		return false
+	}
+	if i < len(s) && (s[i] == 'e' || s[i] == 'E') {
+		i++
+		if i < len(s) && (s[i] == '+' || s[i] == '-') {
+			i++
+		}
+		exp := 0
+		for i < len(s) && s[i] >= '0' && s[i] <= '9' {
+			i++
+			exp++
+		}
+		if exp == 0 {
+			return false
+		}
+	}
+	return i == len(s)
+}
+
+// DecimalFromInt returns the decimal value of i.
+func DecimalFromInt(i int) Decimal {
+	return Decimal{text: strconv.Itoa(i)}
+}
+
+// DecimalFromFloat returns the decimal value of f using the shortest
+// representation that reads back to f. NaN and infinities are returned as zero.
+func DecimalFromFloat(f float64) Decimal {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return Decimal{}
+	}
+	return Decimal{text: strconv.FormatFloat(f, 'f', -1, 64)}
+}
+
+// ratDigits is the number of fractional digits DecimalFromRat rounds to when
+// a rational number has no finite decimal representation.
+const ratDigits = 18
+
+// DecimalFromRat returns the decimal value of r. It is exact when r has a
+// finite decimal representation, which is the case when the denominator has
+// no other prime factors than 2 and 5. Otherwise, for example for 1/3, the
+// value is rounded to 18 fractional digits.
+func DecimalFromRat(r *big.Rat) Decimal {
+	if r.IsInt() {
+		return Decimal{text: r.Num().String()}
+	}
+	// A finite decimal needs as many fractional digits as the larger
+	// exponent of 2 and 5 in the denominator.
+	denom := new(big.Int).Set(r.Denom())
+	digits := 0
+	for _, p := range []*big.Int{big.NewInt(2), big.NewInt(5)} {
+		n := 0
+		for q, m := new(big.Int), new(big.Int); ; n++ {
+			if q.QuoRem(denom, p, m); m.Sign() != 0 {
+				break
+			}
+			denom.Set(q)
+		}
+		if n > digits {
+			digits = n
+		}
+	}
+	if denom.IsInt64() && denom.Int64() == 1 {
+		return Decimal{text: r.FloatString(digits)}
+	}
+	s := strings.TrimSuffix(strings.TrimRight(r.FloatString(ratDigits), "0"), ".")
+	if s == "0" || s == "-0" {
+		return Decimal{}
+	}
+	return Decimal{text: s}
+}
+
+// String returns the decimal as given in the data.
+func (d Decimal) String() string {
+	if d.text == "" {
+		return "0"
+	}
+	return d.text
+}
+
+// Float64 returns the nearest float64 value of the decimal, ±Inf for values
+// beyond the range of float64.
+func (d Decimal) Float64() float64 {
+	f, _ := strconv.ParseFloat(d.String(), 64)
+	return f
+}
+
+// Rat returns the exact value of the decimal.
+func (d Decimal) Rat() *big.Rat {
+	r, ok := new(big.Rat).SetString(d.String())
+	if !ok {
+		// Decimals are only made by ParseDecimal and the DecimalFrom
+		// functions, which return valid numbers.
+		panic(fmt.Sprintf("structs: invalid decimal %q", d.text))
+	}
+	return r
+}
+
+// Int returns the integer part of the decimal, truncated towards zero like
+// a conversion from float to int and clamped to the range of int. Use IsInt
+// to find out whether the decimal has a fractional part.
+func (d Decimal) Int() int {
+	const maxInt = int(^uint(0) >> 1)
+	r := d.Rat()
+	q := new(big.Int).Quo(r.Num(), r.Denom())
+	switch {
+	case q.Cmp(big.NewInt(int64(maxInt))) > 0:
+		return maxInt
+	case q.Cmp(big.NewInt(int64(-maxInt-1))) < 0:
+		return -maxInt - 1
+	}
+	return int(q.Int64())
+}
+
+// IsInt reports whether the decimal has no fractional part.
+func (d Decimal) IsInt() bool {
+	return d.Rat().IsInt()
+}
+
+// Sign returns -1, 0 or +1 depending on whether the decimal is negative, zero
+// or positive.
+func (d Decimal) Sign() int {
+	return d.Rat().Sign()
+}
+
+// IsZero reports whether the decimal equals zero.
+func (d Decimal) IsZero() bool {
+	return d.Rat().Sign() == 0
+}
+
+// Cmp compares the exact values of d and other and returns -1, 0 or +1.
+func (d Decimal) Cmp(other Decimal) int {
+	return d.Rat().Cmp(other.Rat())
+}
+
+// MarshalJSON implements json.Marshaler interface. The decimal is encoded as
+// JSON number.
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface. Numbers, strings and
+// null are accepted. An empty string and null decode to zero.
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if string(data) == "null" {
+		*d = Decimal{}
+		return nil
+	}
+	s := string(data)
+	if len(data) > 0 && data[0] == '"' {
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		if strings.TrimSpace(s) == "" {
+			*d = Decimal{}
+			return nil
+		}
+	}
+	v, err := ParseDecimal(s)
+	if err != nil {
+		return err
+	}
+	*d = v
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler interface.
+func (d Decimal) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler interface.
+func (d *Decimal) UnmarshalText(text []byte) error {
+	if len(bytes.TrimSpace(text)) == 0 {
+		*d = Decimal{}
+		return nil
+	}
+	v, err := ParseDecimal(string(text))
+	if err != nil {
+		return err
+	}
+	*d = v
+	return nil
+}
@@ -0,0 +1,165 @@
+package structs
+
+import (
+	"encoding/json"
+	"math"
+	"math/big"
+	"testing"
+)
+
+// mustDecimal parses s or panics, for test tables.
+func mustDecimal(s string) Decimal {
+	d, err := ParseDecimal(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+func TestParseDecimal(t *testing.T) {
+	for _, tt := range []struct {
+		in, want string
+	}{
+		{"12.5", "12.5"},
+		{"1.50", "1.50"},
+		{" +007 ", "7"},
+		{"-0.5", "-0.5"},
+		{".5", "0.5"},
+		{"5.", "5"},
+		{"12,5", "12.5"},
+		{"1e3", "1e3"},
+		{"1.e3", "1e3"},
+		{"0.1000000000000000000001", "0.1000000000000000000001"},
+		{"1e1000", "1e1000"},
+		{"1E+1000", "1E+1000"},
+		{"1e-1000", "1e-1000"},
+	} {
+		d, err := ParseDecimal(tt.in)
+		if err != nil {
+			t.Errorf("ParseDecimal(%q): %v", tt.in, err)
+			continue
+		}
+		if d.String() != tt.want {
+			t.Errorf("ParseDecimal(%q) = %s, want %s", tt.in, d, tt.want)
+		}
+	}
+	for _, in := range []string{"", "-", ".", "1.2.3", "1,000.5", "1,2,3", "e5", "1e", "0x10", "NaN", "1 2",
+		"1e1001", "1e-1001", "1e5000000", "1e99999999999999999999"} {
+		if d, err := ParseDecimal(in); err == nil {
+			t.Errorf("ParseDecimal(%q) = %s, expected error", in, d)
+		}
+	}
+}
+
+func TestDecimalValues(t *testing.T) {
+	if got := (Decimal{}).String(); got != "0" {
+		t.Errorf("zero Decimal = %s", got)
+	}
+	if got := DecimalFromFloat(0.1).String(); got != "0.1" {
+		t.Errorf("DecimalFromFloat(0.1) = %s", got)
+	}
+	if got := DecimalFromFloat(math.NaN()); !got.IsZero() {
+		t.Errorf("DecimalFromFloat(NaN) = %s", got)
+	}
+	if got := DecimalFromInt(-42).String(); got != "-42" {
+		t.Errorf("DecimalFromInt(-42) = %s", got)
+	}
+	d := mustDecimal("-2.75")
+	if d.Int() != -2 || d.IsInt() || d.Float64() != -2.75 {
+		t.Errorf("%s: Int() = %d, IsInt() = %v, Float64() = %v", d, d.Int(), d.IsInt(), d.Float64())
+	}
+	if got := mustDecimal("1e30").Int(); got != int(^uint(0)>>1) {
+		t.Errorf("1e30.Int() = %d, want max int", got)
+	}
+	if huge, tiny := mustDecimal("1e1000"), mustDecimal("-1e-1000"); huge.Sign() != 1 || tiny.Sign() != -1 || huge.IsZero() ||
+		!math.IsInf(huge.Float64(), 1) || huge.Cmp(tiny) != 1 {
+		t.Errorf("1e1000 and -1e-1000: Sign() = %d, %d, Float64() = %v", huge.Sign(), tiny.Sign(), huge.Float64())
+	}
+	if !mustDecimal("2.000").IsInt() || !mustDecimal("0.00").IsZero() {
+		t.Error("2.000 is not an integer or 0.00 is not zero")
+	}
+	for _, tt := range []struct {
+		a, b string
+		want int
+	}{
+		{"1.50", "1.5", 0},
+		{"0.1", "0.10000000000000001", -1},
+		{"-1", "-2", 1},
+		{"1e2", "100", 0},
+	} {
+		if got := mustDecimal(tt.a).Cmp(mustDecimal(tt.b)); got != tt.want {
+			t.Errorf("%s.Cmp(%s) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+	if got := mustDecimal("0.1").Rat().FloatString(1); got != "0.1" {
+		t.Errorf("0.1.Rat() = %s", got)
+	}
+}
+
+func TestDecimalJSON(t *testing.T) {
+	var v struct {
+		D Decimal `json:"d"`
+	}
+	for _, tt := range []struct {
+		in, want string
+	}{
+		{`{"d": 1.50}`, `{"d":1.50}`},
+		{`{"d": "12,5"}`, `{"d":12.5}`},
+		{`{"d": ""}`, `{"d":0}`},
+		{`{"d": null}`, `{"d":0}`},
+		{`{"d": 123456789012345678901234567890}`, `{"d":123456789012345678901234567890}`},
+	} {
+		v.D = DecimalFromInt(9)
+		if err := json.Unmarshal([]byte(tt.in), &v); err != nil {
+			t.Errorf("Unmarshal(%s): %v", tt.in, err)
+			continue
+		}
+		data, err := json.Marshal(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != tt.want {
+			t.Errorf("Unmarshal(%s) encodes to %s, want %s", tt.in, data, tt.want)
+		}
+	}
+	for _, in := range []string{`{"d": "abc"}`, `{"d": true}`, `{"d": [1]}`} {
+		if err := json.Unmarshal([]byte(in), &v); err == nil {
+			t.Errorf("Unmarshal(%s) accepted", in)
+		}
+	}
+}
+
+func TestDecimalText(t *testing.T) {
+	var d Decimal
+	if err := d.UnmarshalText([]byte(" 3,25 ")); err != nil || d.String() != "3.25" {
+		t.Errorf("UnmarshalText(3,25) = %s, %v", d, err)
+	}
+	if err := d.UnmarshalText([]byte("  ")); err != nil || !d.IsZero() {
+		t.Errorf("UnmarshalText(blank) = %s, %v", d, err)
+	}
+	if text, _ := mustDecimal("1.50").MarshalText(); string(text) != "1.50" {
+		t.Errorf("MarshalText() = %s", text)
+	}
+}
+
+func TestDecimalFromRat(t *testing.T) {
+	for _, tt := range []struct {
+		r    *big.Rat
+		want string
+	}{
+		{big.NewRat(0, 1), "0"},
+		{big.NewRat(-12, 1), "-12"},
+		{big.NewRat(3, 4), "0.75"},
+		{big.NewRat(1, 80), "0.0125"},
+		{big.NewRat(-7, 3), "-2.333333333333333333"},
+		{big.NewRat(2, 3), "0.666666666666666667"},
+		{big.NewRat(1, 3000000000000000000), "0"},
+	} {
+		if got := DecimalFromRat(tt.r).String(); got != tt.want {
+			t.Errorf("DecimalFromRat(%s) = %s, want %s", tt.r, got, tt.want)
+		}
+	}
+	if got := mustDecimal("-0.5").Sign(); got != -1 {
+		t.Errorf("-0.5.Sign() = %d", got)
+	}
+}
@@ -0,0 +1,293 @@
+package structs
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+)
+
+// ChangeKind tells how a value changed.
+type ChangeKind string
+
+// Change kinds.
+const (
+	ChangeAdded    ChangeKind = "added"
+	ChangeRemoved  ChangeKind = "removed"
+	ChangeModified ChangeKind = "modified"
+)
+
+// Change is a single difference between two JSON documents.
+type Change struct {
+	// Kind of the change.
+	Kind ChangeKind
+	// JSON pointer to the value. Removed values are addressed in the old
+	// document, added and modified values in the new document.
+	Path string
+	// Old value as decoded JSON: nil, bool, json.Number, string,
+	// []interface{} or map[string]interface{}. Nil for added values.
+	Old interface{}
+	// New value as decoded JSON. Nil for removed values.
+	New interface{}
+}
+
+// String returns the change in human readable form.
+func (c Change) String() string {
+	o, _ := json.Marshal(c.Old)
+	n, _ := json.Marshal(c.New)
+	switch c.Kind {
+	case ChangeAdded:
+		return fmt.Sprintf("%s: added %s", c.Path, n)
+	case ChangeRemoved:
+		return fmt.Sprintf("%s: removed %s", c.Path, o)
+	}
+	return fmt.Sprintf("%s: %s -> %s", c.Path, o, n)
+}
+
+// listKeys are the members identifying the elements of keyed lists, in order
+// of preference. A list is keyed by the first member which all elements of
+// both the old and the new list have with unique scalar values.
+var listKeys = []string{
+	"nutrientTypeCode",
+	"preparationStateCode",
+	"mediaSequence",
+	"productAttributeGroupExtId",
+	"productAttributeExtId",
+	"allergenTypeCode",
+	"ingredientSequence",
+	"temperatureQualifierCode",
+	"productCharacteristicCode",
+	"physiochemicalCharacteristicCode",
+	"codeListName",
+	"extId",
+	"@languageCode",
+	"@measurementUnitCode",
+}
+
+// Diff returns the differences between two products. The values are
+// typically MasterProductData, but any value encodable to JSON is accepted,
+// and []byte or json.RawMessage are taken as JSON documents. Paths use the
+// JSON names of the fields, for example /tradeItem/gtin. Lists which are
+// keyed, for example nutrients by nutrientTypeCode, media by mediaSequence
+// and localized texts by @languageCode, are matched by key, so reordering
+// them is not a change. Reordering the elements of other lists is not a
+// change either, but when their elements change they are compared by index:
+// removing the first of three elements is reported as two modified elements
+// and a removed last one. Numbers are compared by value.
+func Diff(old, new interface{}) ([]Change, error) {
+	a, err := jsonTree(old)
+	if err != nil {
+		return nil, err
+	}
+	b, err := jsonTree(new)
+	if err != nil {
+		return nil, err
+	}
+	var changes []Change
+	diffJSON("", "", a, b, &changes)
+	return changes, nil
+}
+
+// jsonTree returns v as decoded JSON. []byte and json.RawMessage are decoded,
+// other values are encoded first.
+func jsonTree(v interface{}) (interface{}, error) {
+	switch raw := v.(type) {
+	case []byte:
+		return decodeJSONTree(raw)
+	case json.RawMessage:
+		return decodeJSONTree(raw)
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return decodeJSONTree(data)
+}
+
+// diffJSON appends the changes between a at oldPath and b at newPath.
+func diffJSON(oldPath, newPath string, a, b interface{}, changes *[]Change) {
+	switch a := a.(type) {
+	case map[string]interface{}:
+		if b, ok := b.(map[string]interface{}); ok {
+			for _, k := range sortedKeys(a) {
+				if bv, ok := b[k]; ok {
+					diffJSON(JoinPointer(oldPath, k), JoinPointer(newPath, k), a[k], bv, changes)
+				} else {
+					*changes = append(*changes, Change{Kind: ChangeRemoved, Path: JoinPointer(oldPath, k), Old: a[k]})
+				}
+			}
+			for _, k := range sortedKeys(b) {
+				if _, ok := a[k]; !ok {
+					*changes = append(*changes, Change{Kind: ChangeAdded, Path: JoinPointer(newPath, k), New: b[k]})
+				}
+			}
+			return
+		}
+	case []interface{}:
+		if b, ok := b.([]interface{}); ok {
+			diffList(oldPath, newPath, a, b, changes)
+			return
+		}
+	}
+	if !jsonEqual(a, b) {
+		*changes = append(*changes, Change{Kind: ChangeModified, Path: newPath, Old: a, New: b})
+	}
+}
+
+// diffList adds the changes between lists to changes. Lists having the same
+// elements in any order are equal. Keyed lists are matched by key, see
+// listKeys, and other lists element by element by index.
+func diffList(oldPath, newPath string, a, b []interface{}, changes *[]Change) {
+	if isPermutation(a, b) {
+		return
+	}
+	if key, ok := listKey(a, b); ok {
+		index := make(map[string]int, len(b))
+		for j, e := range b {
+			index[keyValue(e, key)] = j
+		}
+		matched := make(map[string]bool, len(a))
+		for i, e := range a {
+			k := keyValue(e, key)
+			if j, ok := index[k]; ok {
+				matched[k] = true
+				diffJSON(JoinPointer(oldPath, strconv.Itoa(i)), JoinPointer(newPath, strconv.Itoa(j)), e, b[j], changes)
+			} else {
+				*changes = append(*changes, Change{Kind: ChangeRemoved, Path: JoinPointer(oldPath, strconv.Itoa(i)), Old: e})
+			}
+		}
+		for j, e := range b {
+			if !matched[keyValue(e, key)] {
+				*changes = append(*changes, Change{Kind: ChangeAdded, Path: JoinPointer(newPath, strconv.Itoa(j)), New: e})
+			}
+		}
+		return
+	}
+	for i := 0; i < len(a) || i < len(b); i++ {
+		switch {
+		case i >= len(b):
+			*changes = append(*changes, Change{Kind: ChangeRemoved, Path: JoinPointer(oldPath, strconv.Itoa(i)), Old: a[i]})
+		case i >= len(a):
+			*changes = append(*changes, Change{Kind: ChangeAdded, Path: JoinPointer(newPath, strconv.Itoa(i)), New: b[i]})
+		default:
+			diffJSON(JoinPointer(oldPath, strconv.Itoa(i)), JoinPointer(newPath, strconv.Itoa(i)), a[i], b[i], changes)
+		}
+	}
+}
+
+// listKey returns the member keying both lists. Empty lists are not keyed.
+func listKey(a, b []interface{}) (string, bool) {
+	if len(a) == 0 || len(b) == 0 {
+		return "", false
+	}
+	for _, key := range listKeys {
+		if keyedBy(a, key) && keyedBy(b, key) {
+			return key, true
+		}
+	}
+	return "", false
+}
+
+// keyedBy reports whether all elements are objects with a unique non-empty
+// scalar value for the key.
+func keyedBy(list []interface{}, key string) bool {
+	seen := make(map[string]bool, len(list))
+	for _, e := range list {
+		k := keyValue(e, key)
+		if k == "" || seen[k] {
+			return false
+		}
+		seen[k] = true
+	}
+	return true
+}
+
+// keyValue returns the key member of an object as string, or an empty string
+// when the element is not an object or the member is not a non-empty scalar.
+func keyValue(e interface{}, key string) string {
+	m, ok := e.(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	switch v := m[key].(type) {
+	case string:
+		return v
+	case json.Number:
+		if r, ok := new(big.Rat).SetString(string(v)); ok {
+			return r.RatString()
+		}
+		return string(v)
+	}
+	return ""
+}
+
+// isPermutation reports whether the lists have the same elements in any order.
+func isPermutation(a, b []interface{}) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	used := make([]bool, len(b))
+next:
+	for _, e := range a {
+		for j, f := range b {
+			if !used[j] && jsonEqual(e, f) {
+				used[j] = true
+				continue next
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// jsonEqual reports whether two decoded JSON values are equal. Numbers are
+// compared by value and the order of list elements matters.
+func jsonEqual(a, b interface{}) bool {
+	switch a := a.(type) {
+	case map[string]interface{}:
+		b, ok := b.(map[string]interface{})
+		if !ok || len(a) != len(b) {
+			return false
+		}
+		for k, v := range a {
+			w, ok := b[k]
+			if !ok || !jsonEqual(v, w) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		b, ok := b.([]interface{})
+		if !ok || len(a) != len(b) {
+			return false
+		}
+		for i := range a {
+			if !jsonEqual(a[i], b[i]) {
+				return false
+			}
+		}
+		return true
+	case json.Number:
+		b, ok := b.(json.Number)
+		if !ok {
+			return false
+		}
+		x, okx := new(big.Rat).SetString(string(a))
+		y, oky := new(big.Rat).SetString(string(b))
+		if !okx || !oky {
+			return a == b
+		}
+		return x.Cmp(y) == 0
+	}
+	return a == b
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,86 @@
+package structs
+
+import (
+	"reflect"
+	"testing"
+)
+
+// changeStrings returns the changes in human readable form.
+func changeStrings(changes []Change) []string {
+	var res []string
+	for _, c := range changes {
+		res = append(res, c.String())
+	}
+	return res
+}
+
+func TestDiff(t *testing.T) {
+	for _, tt := range []struct {
+		name, old, new string
+		want           []string
+	}{
+		{"equal numbers", `{"a": 1.50, "b": [1, 2]}`, `{"a": 1.5, "b": [1, 2]}`, nil},
+		{"members", `{"a": 1, "b": "x", "c": {"d": true}}`, `{"b": "y", "c": {"d": true, "e": null}, "f": [1]}`, []string{
+			`/a: removed 1`, `/b: "x" -> "y"`, `/c/e: added null`, `/f: added [1]`,
+		}},
+		{"reordered scalars", `{"a": [1, 2, 3]}`, `{"a": [3, 1, 2]}`, nil},
+		{"keyed list", `{"n": [
+			{"nutrientTypeCode": "FAT", "v": 1},
+			{"nutrientTypeCode": "PRO-", "v": 2},
+			{"nutrientTypeCode": "SALTEQ", "v": 3}
+		]}`, `{"n": [
+			{"nutrientTypeCode": "SUGAR-", "v": 4},
+			{"nutrientTypeCode": "PRO-", "v": 5},
+			{"nutrientTypeCode": "FAT", "v": 1}
+		]}`, []string{
+			`/n/1/v: 2 -> 5`, `/n/2: removed {"nutrientTypeCode":"SALTEQ","v":3}`, `/n/0: added {"nutrientTypeCode":"SUGAR-","v":4}`,
+		}},
+		{"localized texts", `{"d": [{"$": "Maito", "@languageCode": "fi"}, {"$": "Mjölk", "@languageCode": "sv"}]}`,
+			`{"d": [{"$": "Mjölk", "@languageCode": "sv"}, {"$": "Maitoa", "@languageCode": "fi"}]}`, []string{
+				`/d/1/$: "Maito" -> "Maitoa"`,
+			}},
+		{"numeric key", `{"m": [{"mediaSequence": 1, "u": "a"}, {"mediaSequence": 2, "u": "b"}]}`,
+			`{"m": [{"mediaSequence": 2.0, "u": "b"}, {"mediaSequence": 1, "u": "c"}]}`, []string{
+				`/m/1/u: "a" -> "c"`,
+			}},
+		{"duplicate keys by index", `{"a": [{"extId": "x", "v": 1}, {"extId": "x", "v": 2}]}`,
+			`{"a": [{"extId": "x", "v": 1}, {"extId": "x", "v": 3}, {"extId": "y"}]}`, []string{
+				`/a/1/v: 2 -> 3`, `/a/2: added {"extId":"y"}`,
+			}},
+		{"unkeyed list by index", `{"a": [{"v": 1}, {"v": 2}, {"v": 3}]}`, `{"a": [{"v": 2}, {"v": 3}]}`, []string{
+			`/a/0/v: 1 -> 2`, `/a/1/v: 2 -> 3`, `/a/2: removed {"v":3}`,
+		}},
+		{"type change", `{"a": [1]}`, `{"a": {"0": 1}}`, []string{`/a: [1] -> {"0":1}`}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			changes, err := Diff([]byte(tt.old), []byte(tt.new))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := changeStrings(changes); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Diff() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiffProducts(t *testing.T) {
+	var a, b MasterProductData
+	a.Gtin = "4006381333931"
+	b.Gtin = "96385074"
+	b.TradeItem.TradeItemInformation.Extension.FoodAndBeverageIngredientModule.JuiceContentPercent = ValidFloat64(0)
+	changes, err := Diff(a, b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		`/gtin: "4006381333931" -> "96385074"`,
+		`/tradeItem/tradeItemInformation/extensions/foodAndBeverageIngredientModule/juiceContentPercent: null -> 0`,
+	}
+	if got := changeStrings(changes); !reflect.DeepEqual(got, want) {
+		t.Errorf("Diff() = %q, want %q", got, want)
+	}
+	if _, err := Diff([]byte(`{`), a); err == nil {
+		t.Error("Diff accepted invalid JSON")
+	}
+}
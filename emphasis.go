@@ -0,0 +1,120 @@
+package structs
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"sort"
+	"strings"
+)
+
+// ErrEmphasisOutOfBounds is returned when an emphasis range is not within the text.
+var ErrEmphasisOutOfBounds = errors.New("emphasis out of bounds")
+
+// EmphasisMarkers defines how emphasised substrings are rendered.
+type EmphasisMarkers struct {
+	// Written before an emphasised substring.
+	Open string
+	// Written after an emphasised substring.
+	Close string
+	// Escape is applied to the text, not to the markers. Nil leaves the text as is.
+	Escape func(string) string
+}
+
+var (
+	// EmphasisHTML renders emphasis with strong elements and escapes the text for HTML.
+	EmphasisHTML = EmphasisMarkers{Open: "<strong>", Close: "</strong>", Escape: html.EscapeString}
+	// EmphasisMarkdown renders emphasis as Markdown strong emphasis and escapes
+	// Markdown syntax in the text.
+	EmphasisMarkdown = EmphasisMarkers{Open: "**", Close: "**", Escape: markdownEscaper.Replace}
+)
+
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`, "`", "\\`", `*`, `\*`, `_`, `\_`, `[`, `\[`, `]`, `\]`, `<`, `\<`, `>`, `\>`, `#`, `\#`, `|`, `\|`,
+)
+
+// EmphasisPlain renders emphasis in plain text with the given markers, for
+// example EmphasisPlain("*", "*").
+func EmphasisPlain(open, close string) EmphasisMarkers {
+	return EmphasisMarkers{Open: open, Close: close}
+}
+
+// MergeEmphases validates emphasis ranges against the text and merges
+// overlapping and adjacent ranges. Ranges are counted in characters (runes),
+// not bytes. Empty ranges are dropped. The result is sorted by StartAt.
+func MergeEmphases(text string, emphases []XEmphasis) ([]XEmphasis, error) {
+	n := len([]rune(text))
+	ranges := make([]XEmphasis, 0, len(emphases))
+	for _, e := range emphases {
+		if e.StartAt < 0 || e.Length < 0 || e.StartAt+e.Length > n {
+			return nil, fmt.Errorf("[%d, %d) in text of %d characters: %w",
+				e.StartAt, e.StartAt+e.Length, n, ErrEmphasisOutOfBounds)
+		}
+		if e.Length > 0 {
+			ranges = append(ranges, e)
+		}
+	}
+	sort.Slice(ranges, func(i, j int) bool { return ranges[i].StartAt < ranges[j].StartAt })
+	var merged []XEmphasis
+	for _, r := range ranges {
+		if l := len(merged) - 1; l >= 0 && r.StartAt <= merged[l].StartAt+merged[l].Length {
+			if end := r.StartAt + r.Length; end > merged[l].StartAt+merged[l].Length {
+				merged[l].Length = end - merged[l].StartAt
+			}
+			continue
+		}
+		merged = append(merged, r)
+	}
+	return merged, nil
+}
+
+// RenderEmphasis returns the text with emphasised ranges surrounded by the markers.
+func RenderEmphasis(text string, emphases []XEmphasis, m EmphasisMarkers) (string, error) {
+	merged, err := MergeEmphases(text, emphases)
+	if err != nil {
+		return "", err
+	}
+	escape := m.Escape
+	if escape == nil {
+		escape = func(s string) string { return s }
+	}
+	runes := []rune(text)
+	var b strings.Builder
+	pos := 0
+	for _, e := range merged {
+		b.WriteString(escape(string(runes[pos:e.StartAt])))
+		b.WriteString(m.Open)
+		b.WriteString(escape(string(runes[e.StartAt : e.StartAt+e.Length])))
+		b.WriteString(m.Close)
+		pos = e.StartAt + e.Length
+	}
+	b.WriteString(escape(string(runes[pos:])))
+	return b.String(), nil
+}
+
+// Render returns the allergen statement with emphasised allergens.
+func (s AllergenStatement) Render(m EmphasisMarkers) (string, error) {
+	return RenderEmphasis(s.Name, s.XEmphasis, m)
+}
+
+// Render returns the ingredient name with emphasised substrings.
+func (n NonfoodIngredient) Render(m EmphasisMarkers) (string, error) {
+	return RenderEmphasis(n.IngredientName, n.XEmphasis, m)
+}
+
+// Render returns the ingredient names in all languages with emphasised substrings.
+func (n IngredientName) Render(m EmphasisMarkers) ([]LocalizedText, error) {
+	texts := make([]LocalizedText, len(n))
+	for i, name := range n {
+		emphases := make([]XEmphasis, len(name.XEmphasis))
+		for j, e := range name.XEmphasis {
+			emphases[j] = XEmphasis(e)
+		}
+		s, err := RenderEmphasis(name.Name, emphases, m)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name.LanguageCode, err)
+		}
+		texts[i] = LocalizedText{Value: s, LanguageCode: name.LanguageCode}
+	}
+	return texts, nil
+}
@@ -0,0 +1,75 @@
+package structs
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestMergeEmphases(t *testing.T) {
+	got, err := MergeEmphases("vehnäjauho, maito", []XEmphasis{
+		{StartAt: 12, Length: 5},
+		{StartAt: 0, Length: 3},
+		{StartAt: 2, Length: 3},
+		{StartAt: 5, Length: 0},
+		{StartAt: 5, Length: 1},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []XEmphasis{{StartAt: 0, Length: 6}, {StartAt: 12, Length: 5}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("MergeEmphases() = %+v, want %+v", got, want)
+	}
+	for _, e := range []XEmphasis{{StartAt: -1, Length: 1}, {StartAt: 0, Length: -1}, {StartAt: 15, Length: 3}} {
+		if _, err := MergeEmphases("vehnäjauho, maito", []XEmphasis{e}); !errors.Is(err, ErrEmphasisOutOfBounds) {
+			t.Errorf("MergeEmphases(%+v) error = %v", e, err)
+		}
+	}
+}
+
+func TestRenderEmphasis(t *testing.T) {
+	const text = "vehnäjauho, maito <1%"
+	emphases := []XEmphasis{{StartAt: 0, Length: 5}, {StartAt: 12, Length: 5}}
+	for _, tt := range []struct {
+		name string
+		m    EmphasisMarkers
+		want string
+	}{
+		{"html", EmphasisHTML, "<strong>vehnä</strong>jauho, <strong>maito</strong> &lt;1%"},
+		{"markdown", EmphasisMarkdown, `**vehnä**jauho, **maito** \<1%`},
+		{"plain", EmphasisPlain("*", "*"), "*vehnä*jauho, *maito* <1%"},
+	} {
+		got, err := RenderEmphasis(text, emphases, tt.m)
+		if err != nil || got != tt.want {
+			t.Errorf("%s: RenderEmphasis() = %q, %v, want %q", tt.name, got, err, tt.want)
+		}
+	}
+	if got, err := RenderEmphasis("a_b", nil, EmphasisMarkdown); err != nil || got != `a\_b` {
+		t.Errorf("RenderEmphasis(a_b) = %q, %v", got, err)
+	}
+}
+
+func TestRenderStatements(t *testing.T) {
+	s := AllergenStatement{Name: "Sisältää maitoa.", XEmphasis: []XEmphasis{{StartAt: 9, Length: 6}}}
+	if got, err := s.Render(EmphasisPlain("[", "]")); err != nil || got != "Sisältää [maitoa]." {
+		t.Errorf("AllergenStatement.Render() = %q, %v", got, err)
+	}
+	n := NonfoodIngredient{IngredientName: "parfum", XEmphasis: []XEmphasis{{StartAt: 0, Length: 7}}}
+	if _, err := n.Render(EmphasisHTML); !errors.Is(err, ErrEmphasisOutOfBounds) {
+		t.Errorf("NonfoodIngredient.Render() error = %v", err)
+	}
+
+	names := IngredientName{
+		{Name: "maito", LanguageCode: "fi", XEmphasis: []IngredientXEmphasis{{StartAt: 0, Length: 5}}},
+		{Name: "mjölk", LanguageCode: "sv"},
+	}
+	got, err := names.Render(EmphasisHTML)
+	want := []LocalizedText{{Value: "<strong>maito</strong>", LanguageCode: "fi"}, {Value: "mjölk", LanguageCode: "sv"}}
+	if err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("IngredientName.Render() = %+v, %v", got, err)
+	}
+	names[1].XEmphasis = []IngredientXEmphasis{{StartAt: 4, Length: 2}}
+	if _, err := names.Render(EmphasisHTML); !errors.Is(err, ErrEmphasisOutOfBounds) {
+		t.Errorf("IngredientName.Render() error = %v", err)
+	}
+}
@@ -0,0 +1,426 @@
+package structs
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// EU 1169/2011 requirement IDs reported in EU1169Finding.Requirement.
+const (
+	// The name of the food is missing.
+	EU1169NameOfFood = "name-of-food"
+	// The list of ingredients is missing.
+	EU1169IngredientStatement = "ingredient-statement"
+	// Allergens are not emphasised in the list of ingredients.
+	EU1169AllergenEmphasis = "allergen-emphasis"
+	// A mandatory nutrient of the nutrition declaration is missing.
+	EU1169Nutrition = "nutrition"
+	// The net quantity is missing.
+	EU1169NetQuantity = "net-quantity"
+	// Storage instructions are missing.
+	EU1169StorageInstructions = "storage-instructions"
+)
+
+// EU1169MandatoryNutrients are the nutrient type codes of the mandatory
+// nutrition declaration: energy, fat, saturates, carbohydrate, sugars,
+// protein and salt. Uses code list nutrientTypeCode.
+var EU1169MandatoryNutrients = []string{NutrientTypeEnergy, "FAT", "FASAT", "CHOAVL", "SUGAR-", "PRO-", "SALTEQ"}
+
+// EU1169TargetMarketLanguages are the languages of the food information by
+// target market country code of the EU and EEA (ISO 3166-1 numeric). They are
+// checked by CheckEU1169 when no languages are given.
+var EU1169TargetMarketLanguages = map[string][]string{
+	"040": {"de"},             // Austria
+	"056": {"nl", "fr", "de"}, // Belgium
+	"100": {"bg"},             // Bulgaria
+	"191": {"hr"},             // Croatia
+	"196": {"el"},             // Cyprus
+	"203": {"cs"},             // Czechia
+	"208": {"da"},             // Denmark
+	"233": {"et"},             // Estonia
+	"246": {"fi", "sv"},       // Finland
+	"250": {"fr"},             // France
+	"276": {"de"},             // Germany
+	"300": {"el"},             // Greece
+	"348": {"hu"},             // Hungary
+	"352": {"is"},             // Iceland
+	"372": {"en"},             // Ireland
+	"380": {"it"},             // Italy
+	"428": {"lv"},             // Latvia
+	"438": {"de"},             // Liechtenstein
+	"440": {"lt"},             // Lithuania
+	"442": {"fr", "de"},       // Luxembourg
+	"470": {"en"},             // Malta
+	"528": {"nl"},             // Netherlands
+	"578": {"no"},             // Norway
+	"616": {"pl"},             // Poland
+	"620": {"pt"},             // Portugal
+	"642": {"ro"},             // Romania
+	"703": {"sk"},             // Slovakia
+	"705": {"sl"},             // Slovenia
+	"724": {"es"},             // Spain
+	"752": {"sv"},             // Sweden
+}
+
+// EU1169AllergenNames are lower case words, or beginnings of words, naming
+// the allergens of annex II by allergen type code and language. An allergen
+// counts as emphasised when one of its names is found inside an emphasised
+// range of the allergen statements or ingredient names of the language.
+var EU1169AllergenNames = map[string]map[string][]string{
+	"AW": { // cereals containing gluten
+		"en": {"wheat", "rye", "barley", "oat", "spelt", "kamut", "gluten"},
+		"fi": {"vehnä", "ruis", "rukii", "ohra", "kaura", "spelt", "gluteeni"},
+		"sv": {"vete", "råg", "korn", "havre", "dinkel", "spelt", "gluten"},
+	},
+	"AC": { // crustaceans
+		"en": {"crustacean", "shrimp", "prawn", "crab", "lobster"},
+		"fi": {"äyriäi", "katkarap", "rapu", "hummeri"},
+		"sv": {"kräftdjur", "räk", "krabb", "hummer"},
+	},
+	"AE": { // eggs
+		"en": {"egg"},
+		"fi": {"kananmun", "muna"},
+		"sv": {"ägg"},
+	},
+	"AF": { // fish
+		"en": {"fish", "salmon", "tuna", "cod"},
+		"fi": {"kala", "lohi", "lohta", "turska"},
+		"sv": {"fisk", "lax", "torsk"},
+	},
+	"AP": { // peanuts
+		"en": {"peanut"},
+		"fi": {"maapähkin"},
+		"sv": {"jordnöt"},
+	},
+	"AY": { // soybeans
+		"en": {"soy"},
+		"fi": {"soija"},
+		"sv": {"soja"},
+	},
+	"AM": { // milk
+		"en": {"milk", "cream", "butter", "cheese", "lactose", "whey"},
+		"fi": {"maito", "maido", "kerma", "juusto", "laktoos", "hera"},
+		"sv": {"mjölk", "grädde", "smör", "laktos", "vassle"},
+	},
+	"AN": { // nuts
+		"en": {"almond", "hazelnut", "walnut", "cashew", "pecan", "pistachio", "macadamia", "brazil nut"},
+		"fi": {"manteli", "hasselpähkin", "saksanpähkin", "cashew", "pekaani", "pistaasi", "macadamia", "parapähkin"},
+		"sv": {"mandel", "hasselnöt", "valnöt", "cashew", "pekannöt", "pistage", "macadamia", "paranöt"},
+	},
+	"BC": { // celery
+		"en": {"celery", "celeriac"},
+		"fi": {"selleri"},
+		"sv": {"selleri"},
+	},
+	"BM": { // mustard
+		"en": {"mustard"},
+		"fi": {"sinap"},
+		"sv": {"senap"},
+	},
+	"AS": { // sesame seeds
+		"en": {"sesame"},
+		"fi": {"seesam"},
+		"sv": {"sesam"},
+	},
+	"AU": { // sulphur dioxide and sulphites
+		"en": {"sulphite", "sulfite", "sulphur dioxide", "sulfur dioxide"},
+		"fi": {"sulfiit", "rikkidioksid"},
+		"sv": {"sulfit", "svaveldioxid"},
+	},
+	"NL": { // lupin
+		"en": {"lupin"},
+		"fi": {"lupiin"},
+		"sv": {"lupin"},
+	},
+	"UM": { // molluscs
+		"en": {"mollusc", "mussel", "oyster", "squid", "clam"},
+		"fi": {"nilviäi", "simpuk", "osteri", "kalmari"},
+		"sv": {"blötdjur", "mussl", "ostron", "bläckfisk"},
+	},
+}
+
+// EU1169Finding is a single missing or invalid item of the food information.
+type EU1169Finding struct {
+	// Requirement ID, for example EU1169NameOfFood.
+	Requirement string
+	// Language the finding applies to. Empty for language independent
+	// findings, such as missing nutrients.
+	LanguageCode string
+	// JSON pointer to the missing or invalid value, relative to MasterProductData.
+	Path string
+	// Severity of the finding. Storage instructions are only required for
+	// products needing special storage conditions, so they are reported as
+	// warnings.
+	Severity Severity
+	// Human readable description.
+	Message string
+}
+
+// EU1169Report is the result of CheckEU1169.
+type EU1169Report struct {
+	// Declared compliance code from salesInformation/x_eu1169Compliance.
+	ComplianceCode string
+	// False when the product is declared not to be a food or beverage, in
+	// which case the regulation is not checked.
+	Applicable bool
+	// Checked languages.
+	Languages []string
+	// Findings in the order they were found.
+	Findings []EU1169Finding
+}
+
+// OK reports whether the report has no findings with SeverityError.
+func (r EU1169Report) OK() bool {
+	for _, f := range r.Findings {
+		if f.Severity == SeverityError {
+			return false
+		}
+	}
+	return true
+}
+
+// ByLanguage returns the findings grouped by language code. Language
+// independent findings are under the empty language code.
+func (r EU1169Report) ByLanguage() map[string][]EU1169Finding {
+	res := map[string][]EU1169Finding{}
+	for _, f := range r.Findings {
+		res[f.LanguageCode] = append(res[f.LanguageCode], f)
+	}
+	return res
+}
+
+func (r *EU1169Report) add(requirement, lang, path string, severity Severity, format string, args ...interface{}) {
+	r.Findings = append(r.Findings, EU1169Finding{
+		Requirement:  requirement,
+		LanguageCode: lang,
+		Path:         path,
+		Severity:     severity,
+		Message:      fmt.Sprintf(format, args...),
+	})
+}
+
+// hasLanguage reports whether texts have a non-empty text matching the language.
+func hasLanguage(lang string, texts []LocalizedText) bool {
+	_, ok := LanguagePreference{lang}.Resolve(texts)
+	return ok
+}
+
+// CheckEU1169 checks the mandatory food information of EU regulation
+// 1169/2011 in the given languages. When no languages are given, the
+// languages of the target market in EU1169TargetMarketLanguages are checked,
+// and for other target markets the languages of the trade item descriptions.
+// A product without any of them is reported to miss the name of the food. The name of the food,
+// list of ingredients, allergen emphasis and storage instructions are checked
+// per language. The nutrition declaration per 100 g or 100 ml and the net
+// quantity are checked once. Products declared not to be food or beverage by
+// x_isFoodOrBeverage are not checked.
+func (d MasterProductData) CheckEU1169(languages ...string) EU1169Report {
+	ext := d.TradeItem.TradeItemInformation.Extension
+	base := JoinPointer("", "tradeItem", "tradeItemInformation", "extensions")
+	r := EU1169Report{
+		ComplianceCode: ext.SalesInformationModule.SalesInformation.XEu1169Compliance.XComplianceCode,
+		Applicable:     !ext.FoodAndBeverageIngredientModule.XIsFoodOrBeverage.IsFalse(),
+		Languages:      languages,
+	}
+	if !r.Applicable {
+		return r
+	}
+	desc := ext.TradeItemDescriptionModule.TradeItemDescriptionInformation
+	if len(r.Languages) == 0 {
+		r.Languages = EU1169TargetMarketLanguages[strings.TrimSpace(d.TradeItem.TargetMarkets.TargetMarketCountryCode)]
+	}
+	if len(r.Languages) == 0 {
+		r.Languages = Languages(TradeItemDescriptionTexts(desc.TradeItemDescriptions))
+	}
+
+	names := append(FunctionalNameTexts(desc.FunctionalNames), TradeItemDescriptionTexts(desc.TradeItemDescriptions)...)
+	namePath := JoinPointer(base, "tradeItemDescriptionModule", "tradeItemDescriptionInformation", "functionalName")
+	if len(r.Languages) == 0 {
+		r.add(EU1169NameOfFood, "", namePath, SeverityError, "name of the food is missing")
+	}
+	ingredients := ext.FoodAndBeverageIngredientModule
+	ingredientsPath := JoinPointer(base, "foodAndBeverageIngredientModule")
+	storage := ConsumerStorageInstructionTexts(ext.ConsumerInstructionsModule.ConsumerInstructions.ConsumerStorageInstructions)
+	allergens := ext.AllergenInformationModule
+	var contained []string
+	for _, p := range allergens.Allergens() {
+		if levelOfContainmentRank(p.LevelOfContainmentCode) == levelOfContainmentRank(LevelOfContainmentContains) {
+			contained = append(contained, p.AllergenTypeCode)
+		}
+	}
+
+	for _, lang := range r.Languages {
+		if !hasLanguage(lang, names) {
+			r.add(EU1169NameOfFood, lang, namePath, SeverityError, "name of the food is missing")
+		}
+		if !hasLanguage(lang, IngredientStatementTexts(ingredients.IngredientStatements)) {
+			r.add(EU1169IngredientStatement, lang, JoinPointer(ingredientsPath, "ingredientStatement"),
+				SeverityError, "list of ingredients is missing")
+		}
+		if len(contained) > 0 {
+			r.checkAllergenEmphasis(lang, base, allergens, ingredients, contained)
+		}
+		if !hasLanguage(lang, storage) {
+			r.add(EU1169StorageInstructions, lang, JoinPointer(base, "consumerInstructionsModule", "consumerInstructions", "consumerStorageInstructions"),
+				SeverityWarning, "storage instructions are missing")
+		}
+	}
+
+	r.checkNutrition(JoinPointer(base, "nutritionalInformationModule"), ext.NutritionalInformationModule)
+
+	netContentPath := JoinPointer(base, "tradeItemMeasurementsModule", "tradeItemMeasurements", "netContent")
+	found := false
+	for i, c := range ext.TradeItemMeasurementsModule.TradeItemMeasurements.NetContent {
+		if _, err := c.Quantity().Unit(); err != nil {
+			r.add(EU1169NetQuantity, "", JoinPointer(netContentPath, strconv.Itoa(i)), SeverityError, "%v", err)
+			continue
+		}
+		if c.Measurement.Cmp(Decimal{}) > 0 {
+			found = true
+		}
+	}
+	if !found {
+		r.add(EU1169NetQuantity, "", netContentPath, SeverityError, "net quantity is missing")
+	}
+	return r
+}
+
+// emphasisedText is a text and its merged emphasis ranges.
+type emphasisedText struct {
+	text     []rune
+	emphases []XEmphasis
+}
+
+// covers reports whether the lower case name occurs in the text within an
+// emphasised range.
+func (t emphasisedText) covers(name string) bool {
+	n := []rune(name)
+	for _, e := range t.emphases {
+		span := t.text[e.StartAt : e.StartAt+e.Length]
+		for i := 0; i+len(n) <= len(span); i++ {
+			match := true
+			for j, r := range n {
+				if unicode.ToLower(span[i+j]) != r {
+					match = false
+					break
+				}
+			}
+			if match {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// checkAllergenEmphasis checks that each contained allergen is emphasised in
+// the language, either in an allergen statement or in the ingredient names.
+// An allergen is emphasised when one of its EU1169AllergenNames is within an
+// emphasised range. Allergens without names in the language can't be checked
+// and are reported as warnings.
+func (r *EU1169Report) checkAllergenEmphasis(lang, base string, allergens AllergenInformationModule,
+	ingredients FoodAndBeverageIngredientModule, contained []string) {
+	// Same as hasLanguage for a single text: the base languages must match.
+	matches := func(code string) bool {
+		return baseLanguage(normalizeLanguageCode(code)) == baseLanguage(normalizeLanguageCode(lang))
+	}
+	var texts []emphasisedText
+	add := func(text string, emphases []XEmphasis, path string) {
+		merged, err := MergeEmphases(text, emphases)
+		if err != nil {
+			r.add(EU1169AllergenEmphasis, lang, path, SeverityError, "%v", err)
+			return
+		}
+		texts = append(texts, emphasisedText{text: []rune(text), emphases: merged})
+	}
+	for i, infos := range allergens.AllergenRelatedInformations {
+		for j, info := range infos {
+			for k, s := range info.AllergenStatements {
+				if matches(s.LanguageCode) {
+					add(s.Name, s.XEmphasis, JoinPointer(base, "allergenInformationModule", "allergenRelatedInformation",
+						strconv.Itoa(i), strconv.Itoa(j), "allergenStatement", strconv.Itoa(k), "x_emphasis"))
+				}
+			}
+		}
+	}
+	for i, ingredient := range ingredients.FoodAndBeverageIngredients {
+		for j, names := range ingredient.IngredientNames {
+			for k, name := range names {
+				if !matches(name.LanguageCode) {
+					continue
+				}
+				emphases := make([]XEmphasis, len(name.XEmphasis))
+				for l, e := range name.XEmphasis {
+					emphases[l] = XEmphasis(e)
+				}
+				if ingredient.IsIngredientEmphasised {
+					emphases = append(emphases, XEmphasis{Length: len([]rune(name.Name))})
+				}
+				add(name.Name, emphases, JoinPointer(base, "foodAndBeverageIngredientModule", "foodAndBeverageIngredient",
+					strconv.Itoa(i), "ingredientName", strconv.Itoa(j), strconv.Itoa(k), "x_emphasis"))
+			}
+		}
+	}
+
+	var missing, unknown []string
+	for _, code := range contained {
+		words := EU1169AllergenNames[code][baseLanguage(normalizeLanguageCode(lang))]
+		if len(words) == 0 {
+			unknown = append(unknown, code)
+			continue
+		}
+		found := false
+		for _, t := range texts {
+			for _, w := range words {
+				found = found || t.covers(w)
+			}
+		}
+		if !found {
+			missing = append(missing, code)
+		}
+	}
+	path := JoinPointer(base, "allergenInformationModule")
+	if len(missing) > 0 {
+		r.add(EU1169AllergenEmphasis, lang, path, SeverityError, "allergens %s are not emphasised", strings.Join(missing, ", "))
+	}
+	if len(unknown) > 0 {
+		r.add(EU1169AllergenEmphasis, lang, path, SeverityWarning, "emphasis of allergens %s can't be checked", strings.Join(unknown, ", "))
+	}
+}
+
+// checkNutrition checks that the mandatory nutrients are declared per 100 g
+// or 100 ml. Energy must be stated both in kJ and in kcal, values derived by
+// Per100 don't count. The unprepared state is used when it is given.
+func (r *EU1169Report) checkNutrition(path string, m NutritionalInformationModule) {
+	if len(m.NutrientHeaders) == 0 {
+		r.add(EU1169Nutrition, "", JoinPointer(path, "nutrientHeader"), SeverityError, "nutrition declaration is missing")
+		return
+	}
+	i := 0
+	for j, h := range m.NutrientHeaders {
+		if strings.EqualFold(h.PreparationStateCode, PreparationStateUnprepared) {
+			i = j
+			break
+		}
+	}
+	p := JoinPointer(path, "nutrientHeader", strconv.Itoa(i))
+	t, err := m.NutrientHeaders[i].Per100()
+	if err != nil {
+		r.add(EU1169Nutrition, "", JoinPointer(p, "nutrientBasisQuantity"), SeverityError, "%v", err)
+		return
+	}
+	for _, code := range EU1169MandatoryNutrients {
+		if _, ok := t.Nutrient(code); !ok {
+			r.add(EU1169Nutrition, "", JoinPointer(p, "nutrientDetail"), SeverityError, "nutrient %s is missing", code)
+		}
+	}
+	if _, ok := t.Nutrient(NutrientTypeEnergy); ok {
+		for _, unit := range []string{UnitKilojoule, UnitKilocalorie} {
+			if e, ok := t.Energy(unit); !ok || e.Derived {
+				r.add(EU1169Nutrition, "", JoinPointer(p, "nutrientDetail"), SeverityError, "energy in %s is missing", unit)
+			}
+		}
+	}
+}
@@ -0,0 +1,161 @@
+package structs
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+const eu1169Product = `{"tradeItem": {
+	"targetMarket": {"targetMarketCountryCode": "246"},
+	"tradeItemInformation": {"extensions": {
+		"tradeItemDescriptionModule": {"tradeItemDescriptionInformation": {
+			"functionalName": [{"$": "Maitosuklaa", "@languageCode": "fi"}],
+			"tradeItemDescription": [{"$": "Mjölkchoklad", "@languageCode": "sv"}]
+		}},
+		"foodAndBeverageIngredientModule": {
+			"ingredientStatement": [{"$": "sokeri, maitojauhe", "@languageCode": "fi"}, {"$": "socker, mjölkpulver", "@languageCode": "sv"}],
+			"foodAndBeverageIngredient": [
+				{"ingredientName": [[{"$": "sokeri", "@languageCode": "fi"}, {"$": "socker", "@languageCode": "sv"}]]},
+				{"ingredientName": [[
+					{"$": "täysmaitojauhe", "@languageCode": "fi", "x_emphasis": [{"startAt": 4, "length": 5}]},
+					{"$": "helmjölkspulver", "@languageCode": "sv", "x_emphasis": [{"startAt": 3, "length": 5}]}
+				]]}
+			]
+		},
+		"allergenInformationModule": {"allergenRelatedInformation": [[
+			{"allergenSpecificationAgency": "EU", "allergen": [{"allergenTypeCode": "AM", "levelOfContainmentCode": "CONTAINS"}]}
+		]]},
+		"consumerInstructionsModule": {"consumerInstructions": {"consumerStorageInstructions": [
+			{"$": "Säilytä viileässä.", "@languageCode": "fi"}, {"$": "Förvaras svalt.", "@languageCode": "sv"}
+		]}},
+		"nutritionalInformationModule": {"nutrientHeader": [{
+			"preparationStateCode": "UNPREPARED",
+			"nutrientBasisQuantity": {"$": 100, "@measurementUnitCode": "GRM"},
+			"nutrientDetail": [
+				{"nutrientTypeCode": "ENER-", "quantityContained": [{"$": 2250, "@measurementUnitCode": "KJO"}, {"$": 540, "@measurementUnitCode": "E14"}]},
+				{"nutrientTypeCode": "FAT", "quantityContained": [{"$": 31, "@measurementUnitCode": "GRM"}]},
+				{"nutrientTypeCode": "FASAT", "quantityContained": [{"$": 19, "@measurementUnitCode": "GRM"}]},
+				{"nutrientTypeCode": "CHOAVL", "quantityContained": [{"$": 57, "@measurementUnitCode": "GRM"}]},
+				{"nutrientTypeCode": "SUGAR-", "quantityContained": [{"$": 56, "@measurementUnitCode": "GRM"}]},
+				{"nutrientTypeCode": "PRO-", "quantityContained": [{"$": 7.5, "@measurementUnitCode": "GRM"}]},
+				{"nutrientTypeCode": "SALTEQ", "quantityContained": [{"$": 0.2, "@measurementUnitCode": "GRM"}]}
+			]
+		}]},
+		"tradeItemMeasurementsModule": {"tradeItemMeasurements": {"netContent": [{"$": 200, "@measurementUnitCode": "GRM"}]}}
+	}}
+}}`
+
+func eu1169Document(t *testing.T) MasterProductData {
+	t.Helper()
+	var d MasterProductData
+	if err := json.Unmarshal([]byte(eu1169Product), &d); err != nil {
+		t.Fatal(err)
+	}
+	return d
+}
+
+// requirements returns the requirement, language and severity of the findings.
+func requirements(r EU1169Report) []string {
+	var res []string
+	for _, f := range r.Findings {
+		s := f.Requirement + " " + f.LanguageCode
+		if f.Severity == SeverityWarning {
+			s += " warning"
+		}
+		res = append(res, s)
+	}
+	return res
+}
+
+func TestCheckEU1169(t *testing.T) {
+	r := eu1169Document(t).CheckEU1169()
+	if !reflect.DeepEqual(r.Languages, []string{"fi", "sv"}) || len(r.Findings) != 0 || !r.OK() {
+		t.Errorf("CheckEU1169() = %+v", r)
+	}
+	if r := eu1169Document(t).CheckEU1169("en"); !reflect.DeepEqual(requirements(r),
+		[]string{"name-of-food en", "ingredient-statement en", "allergen-emphasis en", "storage-instructions en warning"}) {
+		t.Errorf("CheckEU1169(en) findings %q", requirements(r))
+	}
+
+	d := eu1169Document(t)
+	d.TradeItem.TradeItemInformation.Extension.FoodAndBeverageIngredientModule.XIsFoodOrBeverage = ValidBool(false)
+	if r := d.CheckEU1169(); r.Applicable || len(r.Findings) != 0 {
+		t.Errorf("non-food CheckEU1169() = %+v", r)
+	}
+}
+
+func TestCheckEU1169Languages(t *testing.T) {
+	d := eu1169Document(t)
+	d.TradeItem.TargetMarkets.TargetMarketCountryCode = "840"
+	if r := d.CheckEU1169(); !reflect.DeepEqual(r.Languages, []string{"sv"}) {
+		t.Errorf("languages outside the EU = %q", r.Languages)
+	}
+
+	d = eu1169Document(t)
+	desc := &d.TradeItem.TradeItemInformation.Extension.TradeItemDescriptionModule.TradeItemDescriptionInformation
+	desc.FunctionalNames, desc.TradeItemDescriptions = nil, nil
+	if r := d.CheckEU1169(); !reflect.DeepEqual(requirements(r), []string{"name-of-food fi", "name-of-food sv"}) {
+		t.Errorf("no descriptions in Finland findings %q", requirements(r))
+	}
+	d.TradeItem.TargetMarkets.TargetMarketCountryCode = ""
+	r := d.CheckEU1169()
+	if len(r.Languages) != 0 || !reflect.DeepEqual(requirements(r), []string{"name-of-food "}) {
+		t.Errorf("no descriptions and no target market findings %q", requirements(r))
+	}
+	if r.Findings[0].Path != "/tradeItem/tradeItemInformation/extensions/tradeItemDescriptionModule/tradeItemDescriptionInformation/functionalName" {
+		t.Errorf("path %s", r.Findings[0].Path)
+	}
+}
+
+func TestCheckEU1169AllergenEmphasis(t *testing.T) {
+	d := eu1169Document(t)
+	ingredients := d.TradeItem.TradeItemInformation.Extension.FoodAndBeverageIngredientModule.FoodAndBeverageIngredients
+	// Emphasise "jauhe" instead of "maito".
+	ingredients[1].IngredientNames[0][0].XEmphasis[0].StartAt = 9
+	r := d.CheckEU1169()
+	if !reflect.DeepEqual(requirements(r), []string{"allergen-emphasis fi"}) || r.Findings[0].Message != "allergens AM are not emphasised" {
+		t.Errorf("wrong emphasis findings %+v", r.Findings)
+	}
+
+	ingredients[1].IsIngredientEmphasised = true
+	if r := d.CheckEU1169(); len(r.Findings) != 0 {
+		t.Errorf("emphasised ingredient findings %q", requirements(r))
+	}
+
+	d = eu1169Document(t)
+	allergens := d.TradeItem.TradeItemInformation.Extension.AllergenInformationModule.AllergenRelatedInformations
+	allergens[0][0].Allergens = append(allergens[0][0].Allergens, Allergen{AllergenTypeCode: "AE", LevelOfContainmentCode: "CONTAINS"},
+		Allergen{AllergenTypeCode: "XX", LevelOfContainmentCode: "CONTAINS"})
+	allergens[0][0].AllergenStatements = []AllergenStatement{{Name: "Innehåller ÄGG.", LanguageCode: "sv-FI", XEmphasis: []XEmphasis{{StartAt: 11, Length: 3}}}}
+	if r := d.CheckEU1169(); !reflect.DeepEqual(requirements(r),
+		[]string{"allergen-emphasis fi", "allergen-emphasis fi warning", "allergen-emphasis sv warning"}) || r.Findings[0].Message != "allergens AE are not emphasised" {
+		t.Errorf("egg findings %+v", r.Findings)
+	}
+
+	allergens[0][0].AllergenStatements[0].XEmphasis[0].Length = 10
+	if r := d.CheckEU1169("sv"); len(r.Findings) != 3 || r.Findings[0].Path != "/tradeItem/tradeItemInformation/extensions/allergenInformationModule/allergenRelatedInformation/0/0/allergenStatement/0/x_emphasis" {
+		t.Errorf("out of bounds findings %+v", r.Findings)
+	}
+}
+
+func TestCheckEU1169Nutrition(t *testing.T) {
+	d := eu1169Document(t)
+	header := &d.TradeItem.TradeItemInformation.Extension.NutritionalInformationModule.NutrientHeaders[0]
+	header.NutrientDetails[0].QuantityContaineds = header.NutrientDetails[0].QuantityContaineds[:1]
+	header.NutrientDetails = append(header.NutrientDetails[:1], header.NutrientDetails[2:]...)
+	r := d.CheckEU1169()
+	var messages []string
+	for _, f := range r.Findings {
+		messages = append(messages, f.Message)
+	}
+	if want := []string{"nutrient FAT is missing", "energy in E14 is missing"}; !reflect.DeepEqual(messages, want) {
+		t.Errorf("nutrition findings %q, want %q", messages, want)
+	}
+
+	d.TradeItem.TradeItemInformation.Extension.NutritionalInformationModule.NutrientHeaders = nil
+	d.TradeItem.TradeItemInformation.Extension.TradeItemMeasurementsModule.TradeItemMeasurements.NetContent[0].MeasurementUnitCode = "XYZ"
+	if r := d.CheckEU1169(); !reflect.DeepEqual(requirements(r), []string{"nutrition ", "net-quantity ", "net-quantity "}) {
+		t.Errorf("findings %q", requirements(r))
+	}
+}
@@ -0,0 +1,675 @@
+package structs
+
+import (
+	"encoding"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// The GDSN XML mapping is derived from the JSON tags: a "$" field is the
+// character data of the element, an "@name" field is the attribute name and
+// any other field is a child element. Slices are repeated elements. The inner
+// slices of a nested slice are written one after another and consecutive
+// elements are read back into one inner slice. Zero values are omitted on
+// encode. GS1 extension modules are namespace qualified as in
+// the GDSN 3 schemas. Digital Goodie specific modules and the DG private use
+// module have no GDSN counterpart: dg modules are encoded without namespace
+// and dgPrivateUseModule is not encoded at all.
+
+// gdsnXMLNames maps JSON names to GDSN XML element names where they differ.
+var gdsnXMLNames = map[string]string{
+	"extensions": "extension",
+}
+
+var (
+	rawMessageType = reflect.TypeOf(json.RawMessage{})
+	timeType       = reflect.TypeOf(time.Time{})
+)
+
+// gdsnTimeLayouts are the date time formats accepted when decoding GDSN XML.
+// GDSN date times are often given without time zone, they are read as UTC.
+var gdsnTimeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+	"2006-01-02",
+}
+
+// gdsnModuleNamespace returns the namespace prefix and URI of a GS1 extension
+// module, for example alcohol_information and urn:gs1:gdsn:alcohol_information:xsd:3
+// for alcoholInformationModule.
+func gdsnModuleNamespace(name string) (prefix, uri string, ok bool) {
+	if !strings.HasSuffix(name, "Module") || strings.HasPrefix(name, "dg") {
+		return "", "", false
+	}
+	var b strings.Builder
+	for i, r := range strings.TrimSuffix(name, "Module") {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	prefix = b.String()
+	return prefix, "urn:gs1:gdsn:" + prefix + ":xsd:3", true
+}
+
+type xmlField struct {
+	index int
+	name  string
+}
+
+// xmlFields splits struct fields into attributes, character data and child elements.
+func xmlFields(t reflect.Type) (attrs []xmlField, chardata int, elems []xmlField) {
+	chardata = -1
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name := strings.Split(f.Tag.Get("json"), ",")[0]
+		switch {
+		case f.PkgPath != "" || name == "-" || name == "":
+		case name == "$":
+			chardata = i
+		case name[0] == '@':
+			attrs = append(attrs, xmlField{i, name[1:]})
+		default:
+			if n, ok := gdsnXMLNames[name]; ok {
+				name = n
+			}
+			elems = append(elems, xmlField{i, name})
+		}
+	}
+	return attrs, chardata, elems
+}
+
+// isZeroValue reports whether v is omitted on encode.
+func isZeroValue(v reflect.Value) bool {
+	if z, ok := v.Interface().(interface{ IsZero() bool }); ok {
+		return z.IsZero()
+	}
+	switch v.Kind() {
+	case reflect.Slice, reflect.Map:
+		return v.Len() == 0
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).PkgPath == "" && !isZeroValue(v.Field(i)) {
+				return false
+			}
+		}
+		return true
+	}
+	return v.IsZero()
+}
+
+// isXMLLeaf reports whether values of type t are encoded as text.
+func isXMLLeaf(t reflect.Type) bool {
+	if t == timeType || reflect.PtrTo(t).Implements(reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()) {
+		return true
+	}
+	switch t.Kind() {
+	case reflect.String, reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+func xmlText(v reflect.Value) (string, error) {
+	if m, ok := v.Interface().(encoding.TextMarshaler); ok {
+		b, err := m.MarshalText()
+		return string(b), err
+	}
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), nil
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', -1, v.Type().Bits()), nil
+	}
+	return "", fmt.Errorf("structs: cannot encode %s as XML text", v.Type())
+}
+
+func setXMLText(v reflect.Value, s string) error {
+	s = strings.TrimSpace(s)
+	if v.Type() == timeType {
+		for _, layout := range gdsnTimeLayouts {
+			if t, err := time.Parse(layout, s); err == nil {
+				v.Set(reflect.ValueOf(t))
+				return nil
+			}
+		}
+		return fmt.Errorf("structs: invalid date time %q", s)
+	}
+	if u, ok := v.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		return u.UnmarshalText([]byte(s))
+	}
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		i, err := strconv.ParseUint(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(i)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	default:
+		return fmt.Errorf("structs: cannot decode XML text into %s", v.Type())
+	}
+	return nil
+}
+
+func encodeGDSNStruct(e *xml.Encoder, v reflect.Value, start xml.StartElement) error {
+	attrs, chardata, elems := xmlFields(v.Type())
+	for _, f := range attrs {
+		fv := v.Field(f.index)
+		if isZeroValue(fv) {
+			continue
+		}
+		s, err := xmlText(fv)
+		if err != nil {
+			return err
+		}
+		start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: f.name}, Value: s})
+	}
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+	if chardata >= 0 {
+		s, err := xmlText(v.Field(chardata))
+		if err != nil {
+			return err
+		}
+		if err := e.EncodeToken(xml.CharData(s)); err != nil {
+			return err
+		}
+	}
+	for _, f := range elems {
+		if err := encodeGDSNElement(e, v.Field(f.index), f.name); err != nil {
+			return err
+		}
+	}
+	return e.EncodeToken(start.End())
+}
+
+func encodeGDSNElement(e *xml.Encoder, v reflect.Value, name string) error {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() || v.Type().Elem() == rawMessageType {
+			return nil
+		}
+		v = v.Elem()
+	}
+	if isZeroValue(v) {
+		return nil
+	}
+	start := xml.StartElement{Name: xml.Name{Local: name}}
+	if prefix, uri, ok := gdsnModuleNamespace(name); ok {
+		start.Name.Local = prefix + ":" + name
+		start.Attr = []xml.Attr{{Name: xml.Name{Local: "xmlns:" + prefix}, Value: uri}}
+	}
+	switch {
+	case isXMLLeaf(v.Type()):
+		s, err := xmlText(v)
+		if err != nil {
+			return err
+		}
+		return e.EncodeElement(s, start)
+	case v.Kind() == reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			if err := encodeGDSNElement(e, v.Index(i), name); err != nil {
+				return err
+			}
+		}
+		return nil
+	case v.Kind() == reflect.Struct:
+		return encodeGDSNStruct(e, v, start)
+	}
+	return fmt.Errorf("structs: cannot encode %s as GDSN XML", v.Type())
+}
+
+func decodeGDSNStruct(d *xml.Decoder, v reflect.Value, start xml.StartElement) error {
+	attrs, chardata, elems := xmlFields(v.Type())
+	for _, a := range start.Attr {
+		for _, f := range attrs {
+			if a.Name.Local == f.name {
+				if err := setXMLText(v.Field(f.index), a.Value); err != nil {
+					return fmt.Errorf("structs: attribute %s of %s: %w", f.name, start.Name.Local, err)
+				}
+			}
+		}
+	}
+	var text strings.Builder
+	prev := -1
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			return err
+		}
+		switch tok := tok.(type) {
+		case xml.StartElement:
+			field := -1
+			for _, f := range elems {
+				if tok.Name.Local == f.name {
+					field = f.index
+					break
+				}
+			}
+			if field < 0 {
+				if err := d.Skip(); err != nil {
+					return err
+				}
+				continue
+			}
+			if err := decodeGDSNElement(d, v.Field(field), tok, field == prev); err != nil {
+				return err
+			}
+			prev = field
+		case xml.CharData:
+			if chardata >= 0 {
+				text.Write(tok)
+			}
+		case xml.EndElement:
+			if chardata >= 0 {
+				if err := setXMLText(v.Field(chardata), text.String()); err != nil {
+					return fmt.Errorf("structs: element %s: %w", start.Name.Local, err)
+				}
+			}
+			return nil
+		}
+	}
+}
+
+// decodeGDSNElement decodes the element into v. Repeated elements are slice
+// items. The items of a nested slice, such as allergenRelatedInformation, are
+// consecutive sibling elements, repeated is set for all but the first of them.
+func decodeGDSNElement(d *xml.Decoder, v reflect.Value, start xml.StartElement, repeated bool) error {
+	if v.Kind() == reflect.Ptr {
+		if v.Type().Elem() == rawMessageType {
+			return d.Skip()
+		}
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		v = v.Elem()
+	}
+	switch {
+	case isXMLLeaf(v.Type()):
+		var s string
+		if err := d.DecodeElement(&s, &start); err != nil {
+			return err
+		}
+		if err := setXMLText(v, s); err != nil {
+			return fmt.Errorf("structs: element %s: %w", start.Name.Local, err)
+		}
+		return nil
+	case v.Kind() == reflect.Slice:
+		if repeated && v.Len() > 0 && v.Type().Elem().Kind() == reflect.Slice {
+			return decodeGDSNElement(d, v.Index(v.Len()-1), start, false)
+		}
+		item := reflect.New(v.Type().Elem()).Elem()
+		if err := decodeGDSNElement(d, item, start, false); err != nil {
+			return err
+		}
+		v.Set(reflect.Append(v, item))
+		return nil
+	case v.Kind() == reflect.Struct:
+		return decodeGDSNStruct(d, v, start)
+	}
+	return fmt.Errorf("structs: cannot decode GDSN XML into %s", v.Type())
+}
+
+// gdsnStart returns the start element for a value encoded with xml.Marshal.
+// The default element name, which is the Go type name, is replaced with the
+// GDSN element name.
+func gdsnStart(start xml.StartElement, v interface{}, name string) xml.StartElement {
+	if start.Name.Local == "" || start.Name.Local == reflect.TypeOf(v).Name() {
+		start.Name = xml.Name{Local: name}
+		if prefix, uri, ok := gdsnModuleNamespace(name); ok {
+			start.Name.Local = prefix + ":" + name
+			start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "xmlns:" + prefix}, Value: uri})
+		}
+	}
+	return start
+}
+
+func marshalGDSN(e *xml.Encoder, start xml.StartElement, v interface{}, name string) error {
+	return encodeGDSNStruct(e, reflect.ValueOf(v), gdsnStart(start, v, name))
+}
+
+func unmarshalGDSN(d *xml.Decoder, start xml.StartElement, v interface{}) error {
+	return decodeGDSNStruct(d, reflect.ValueOf(v).Elem(), start)
+}
+
+// DecodeCatalogueItemNotification reads a GDSN Catalogue Item Notification (CIN)
+// message and returns all trade items found in it, including trade items of
+// child catalogue items. Elements outside of tradeItem elements are ignored, so
+// the function accepts bare tradeItem documents as well.
+func DecodeCatalogueItemNotification(r io.Reader) ([]MasterProductTradeItem, error) {
+	d := xml.NewDecoder(r)
+	var items []MasterProductTradeItem
+	for {
+		tok, err := d.Token()
+		if err == io.EOF {
+			return items, nil
+		}
+		if err != nil {
+			return items, err
+		}
+		if se, ok := tok.(xml.StartElement); ok && se.Name.Local == "tradeItem" {
+			var item MasterProductTradeItem
+			if err := d.DecodeElement(&item, &se); err != nil {
+				return items, err
+			}
+			items = append(items, item)
+		}
+	}
+}
+
+// MarshalXML encodes the trade item as GDSN tradeItem element.
+func (t MasterProductTradeItem) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return marshalGDSN(e, start, t, "tradeItem")
+}
+
+// UnmarshalXML decodes a GDSN tradeItem element.
+func (t *MasterProductTradeItem) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return unmarshalGDSN(d, start, t)
+}
+
+// MarshalXML encodes the module as GDSN XML.
+func (m AlcoholInformationModule) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return marshalGDSN(e, start, m, "alcoholInformationModule")
+}
+
+// UnmarshalXML decodes the module from GDSN XML.
+func (m *AlcoholInformationModule) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return unmarshalGDSN(d, start, m)
+}
+
+// MarshalXML encodes the module as GDSN XML.
+func (m AllergenInformationModule) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return marshalGDSN(e, start, m, "allergenInformationModule")
+}
+
+// UnmarshalXML decodes the module from GDSN XML.
+func (m *AllergenInformationModule) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return unmarshalGDSN(d, start, m)
+}
+
+// MarshalXML encodes the module as GDSN XML.
+func (m ConsumerInstructionsModule) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return marshalGDSN(e, start, m, "consumerInstructionsModule")
+}
+
+// UnmarshalXML decodes the module from GDSN XML.
+func (m *ConsumerInstructionsModule) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return unmarshalGDSN(d, start, m)
+}
+
+// MarshalXML encodes the module as GDSN XML.
+func (m DangerousSubstanceInformationModule) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return marshalGDSN(e, start, m, "dangerousSubstanceInformationModule")
+}
+
+// UnmarshalXML decodes the module from GDSN XML.
+func (m *DangerousSubstanceInformationModule) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return unmarshalGDSN(d, start, m)
+}
+
+// MarshalXML encodes the module as GDSN XML.
+func (m DietInformationModule) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return marshalGDSN(e, start, m, "dietInformationModule")
+}
+
+// UnmarshalXML decodes the module from GDSN XML.
+func (m *DietInformationModule) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return unmarshalGDSN(d, start, m)
+}
+
+// MarshalXML encodes the module as GDSN XML.
+func (m FarmingAndProcessingInformationModule) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return marshalGDSN(e, start, m, "farmingAndProcessingInformationModule")
+}
+
+// UnmarshalXML decodes the module from GDSN XML.
+func (m *FarmingAndProcessingInformationModule) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return unmarshalGDSN(d, start, m)
+}
+
+// MarshalXML encodes the module as GDSN XML.
+func (m FoodAndBeverageIngredientModule) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return marshalGDSN(e, start, m, "foodAndBeverageIngredientModule")
+}
+
+// UnmarshalXML decodes the module from GDSN XML.
+func (m *FoodAndBeverageIngredientModule) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return unmarshalGDSN(d, start, m)
+}
+
+// MarshalXML encodes the module as GDSN XML.
+func (m FoodAndBeveragePreparationServingModule) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return marshalGDSN(e, start, m, "foodAndBeveragePreparationServingModule")
+}
+
+// UnmarshalXML decodes the module from GDSN XML.
+func (m *FoodAndBeveragePreparationServingModule) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return unmarshalGDSN(d, start, m)
+}
+
+// MarshalXML encodes the module as GDSN XML.
+func (m FoodAndBeveragePropertiesInformationModule) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return marshalGDSN(e, start, m, "foodAndBeveragePropertiesInformationModule")
+}
+
+// UnmarshalXML decodes the module from GDSN XML.
+func (m *FoodAndBeveragePropertiesInformationModule) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return unmarshalGDSN(d, start, m)
+}
+
+// MarshalXML encodes the module as GDSN XML.
+func (m MarketingInformationModule) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return marshalGDSN(e, start, m, "marketingInformationModule")
+}
+
+// UnmarshalXML decodes the module from GDSN XML.
+func (m *MarketingInformationModule) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return unmarshalGDSN(d, start, m)
+}
+
+// MarshalXML encodes the module as GDSN XML.
+func (m NonfoodIngredientModule) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return marshalGDSN(e, start, m, "nonfoodIngredientModule")
+}
+
+// UnmarshalXML decodes the module from GDSN XML.
+func (m *NonfoodIngredientModule) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return unmarshalGDSN(d, start, m)
+}
+
+// MarshalXML encodes the module as GDSN XML.
+func (m NutritionalInformationModule) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return marshalGDSN(e, start, m, "nutritionalInformationModule")
+}
+
+// UnmarshalXML decodes the module from GDSN XML.
+func (m *NutritionalInformationModule) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return unmarshalGDSN(d, start, m)
+}
+
+// MarshalXML encodes the module as GDSN XML.
+func (m PackagingInformationModule) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return marshalGDSN(e, start, m, "packagingInformationModule")
+}
+
+// UnmarshalXML decodes the module from GDSN XML.
+func (m *PackagingInformationModule) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return unmarshalGDSN(d, start, m)
+}
+
+// MarshalXML encodes the module as GDSN XML.
+func (m PackagingMarkingModule) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return marshalGDSN(e, start, m, "packagingMarkingModule")
+}
+
+// UnmarshalXML decodes the module from GDSN XML.
+func (m *PackagingMarkingModule) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return unmarshalGDSN(d, start, m)
+}
+
+// MarshalXML encodes the module as GDSN XML.
+func (m PlaceOfItemActivityModule) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return marshalGDSN(e, start, m, "placeOfItemActivityModule")
+}
+
+// UnmarshalXML decodes the module from GDSN XML.
+func (m *PlaceOfItemActivityModule) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return unmarshalGDSN(d, start, m)
+}
+
+// MarshalXML encodes the module as GDSN XML.
+func (m ProductCharacteristicsModule) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return marshalGDSN(e, start, m, "productCharacteristicsModule")
+}
+
+// UnmarshalXML decodes the module from GDSN XML.
+func (m *ProductCharacteristicsModule) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return unmarshalGDSN(d, start, m)
+}
+
+// MarshalXML encodes the module as GDSN XML.
+func (m SafetyDataSheetModule) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return marshalGDSN(e, start, m, "safetyDataSheetModule")
+}
+
+// UnmarshalXML decodes the module from GDSN XML.
+func (m *SafetyDataSheetModule) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return unmarshalGDSN(d, start, m)
+}
+
+// MarshalXML encodes the module as GDSN XML.
+func (m SalesInformationModule) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return marshalGDSN(e, start, m, "salesInformationModule")
+}
+
+// UnmarshalXML decodes the module from GDSN XML.
+func (m *SalesInformationModule) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return unmarshalGDSN(d, start, m)
+}
+
+// MarshalXML encodes the module as GDSN XML.
+func (m TradeItemDescriptionModule) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return marshalGDSN(e, start, m, "tradeItemDescriptionModule")
+}
+
+// UnmarshalXML decodes the module from GDSN XML.
+func (m *TradeItemDescriptionModule) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return unmarshalGDSN(d, start, m)
+}
+
+// MarshalXML encodes the module as GDSN XML.
+func (m TradeItemLifespanModule) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return marshalGDSN(e, start, m, "tradeItemLifespanModule")
+}
+
+// UnmarshalXML decodes the module from GDSN XML.
+func (m *TradeItemLifespanModule) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return unmarshalGDSN(d, start, m)
+}
+
+// MarshalXML encodes the module as GDSN XML.
+func (m TradeItemMeasurementsModule) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return marshalGDSN(e, start, m, "tradeItemMeasurementsModule")
+}
+
+// UnmarshalXML decodes the module from GDSN XML.
+func (m *TradeItemMeasurementsModule) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return unmarshalGDSN(d, start, m)
+}
+
+// MarshalXML encodes the module as GDSN XML.
+func (m TradeItemTemperatureInformationModule) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return marshalGDSN(e, start, m, "tradeItemTemperatureInformationModule")
+}
+
+// UnmarshalXML decodes the module from GDSN XML.
+func (m *TradeItemTemperatureInformationModule) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return unmarshalGDSN(d, start, m)
+}
+
+// MarshalXML encodes the module as GDSN XML.
+func (m VariableTradeItemInformationModule) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return marshalGDSN(e, start, m, "variableTradeItemInformationModule")
+}
+
+// UnmarshalXML decodes the module from GDSN XML.
+func (m *VariableTradeItemInformationModule) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return unmarshalGDSN(d, start, m)
+}
+
+// MarshalXML encodes the module as XML.
+func (m DGCodeListModule) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return marshalGDSN(e, start, m, "dgCodeListModule")
+}
+
+// UnmarshalXML decodes the module from XML.
+func (m *DGCodeListModule) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return unmarshalGDSN(d, start, m)
+}
+
+// MarshalXML encodes the module as XML.
+func (m DGMediaModule) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return marshalGDSN(e, start, m, "dgMediaModule")
+}
+
+// UnmarshalXML decodes the module from XML.
+func (m *DGMediaModule) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return unmarshalGDSN(d, start, m)
+}
+
+// MarshalXML encodes the module as XML.
+func (m DGPresentationModule) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return marshalGDSN(e, start, m, "dgPresentationModule")
+}
+
+// UnmarshalXML decodes the module from XML.
+func (m *DGPresentationModule) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return unmarshalGDSN(d, start, m)
+}
+
+// MarshalXML encodes the module as XML.
+func (m DGProductAttributeModule) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return marshalGDSN(e, start, m, "dgProductAttributeModule")
+}
+
+// UnmarshalXML decodes the module from XML.
+func (m *DGProductAttributeModule) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return unmarshalGDSN(d, start, m)
+}
@@ -0,0 +1,122 @@
+package structs
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"flag"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "update golden files in testdata")
+
+func TestGDSNXMLGolden(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join("testdata", "gdsn-*.xml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) == 0 {
+		t.Fatal("no golden files")
+	}
+	for _, file := range files {
+		t.Run(filepath.Base(file), func(t *testing.T) {
+			want, err := ioutil.ReadFile(file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var item MasterProductTradeItem
+			if err := xml.Unmarshal(want, &item); err != nil {
+				t.Fatal(err)
+			}
+			got, err := xml.MarshalIndent(item, "", "  ")
+			if err != nil {
+				t.Fatal(err)
+			}
+			got = append(got, '\n')
+			if *update {
+				if err := ioutil.WriteFile(file, got, 0644); err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+			if !bytes.Equal(got, want) {
+				t.Errorf("XML differs after decode and encode:\n%s", got)
+			}
+		})
+	}
+}
+
+func TestGDSNXMLNestedSlices(t *testing.T) {
+	data, err := ioutil.ReadFile(filepath.Join("testdata", "gdsn-trade-item.xml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	items, err := DecodeCatalogueItemNotification(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 1 {
+		t.Fatalf("got %d trade items, want 1", len(items))
+	}
+	ext := items[0].TradeItemInformation.Extension
+
+	allergens := ext.AllergenInformationModule.AllergenRelatedInformations
+	if len(allergens) != 1 || len(allergens[0]) != 2 {
+		t.Fatalf("allergenRelatedInformation = %+v, want one list of two", allergens)
+	}
+	if n := len(allergens[0][0].Allergens); n != 2 {
+		t.Errorf("got %d allergens in the first allergenRelatedInformation, want 2", n)
+	}
+
+	ingredients := ext.FoodAndBeverageIngredientModule.FoodAndBeverageIngredients
+	if len(ingredients) != 2 {
+		t.Fatalf("got %d ingredients, want 2", len(ingredients))
+	}
+	names := ingredients[0].IngredientNames
+	if len(names) != 1 || len(names[0]) != 2 || names[0][1].Name != "mjölk" {
+		t.Errorf("ingredientName = %+v, want one list of two", names)
+	}
+	if statements := ext.FoodAndBeverageIngredientModule.XAdditionalIngredientStatements; len(statements) != 1 || len(statements[0]) != 2 {
+		t.Errorf("x_additionalIngredientStatement = %+v, want one list of two", statements)
+	}
+}
+
+func TestGDSNXMLRoundTrip(t *testing.T) {
+	const doc = `{
+		"gtin": "04006381333931",
+		"tradeItemInformation": {"extensions": {
+			"allergenInformationModule": {"allergenRelatedInformation": [[
+				{"allergenSpecificationAgency": "EU", "allergen": [{"allergenTypeCode": "AM", "levelOfContainmentCode": "CONTAINS"}]},
+				{"allergenSpecificationAgency": "EU", "allergen": [{"allergenTypeCode": "AW", "levelOfContainmentCode": "MAY_CONTAIN"}]}
+			]]},
+			"productCharacteristicsModule": {"productCharacteristics": [{
+				"productCharacteristicCode": "COLOUR",
+				"productCharacteristicValueDescription": [[{"$": "valkoinen", "@languageCode": "fi"}, {"$": "white", "@languageCode": "en"}]]
+			}]}
+		}}
+	}`
+	var want MasterProductTradeItem
+	if err := json.Unmarshal([]byte(doc), &want); err != nil {
+		t.Fatal(err)
+	}
+	data, err := xml.Marshal(want)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(data), "<tradeItem>") {
+		t.Errorf("root element of %s is not tradeItem", data)
+	}
+	var got MasterProductTradeItem
+	if err := xml.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		g, _ := json.Marshal(got)
+		w, _ := json.Marshal(want)
+		t.Errorf("decoded %s, want %s", g, w)
+	}
+}
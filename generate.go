@@ -0,0 +1,13 @@
+package structs
+
+// master-product.go and master-product.proto, the Protocol Buffers messages
+// of the types, are generated from master-product.spec.json, and
+// master-product.schema.json, the JSON Schema of MasterProductData, from the
+// types. Edit the spec and run go generate, then check the generated files
+// are up to date with:
+//
+//	go run ./internal/structgen -check
+//	go run ./internal/schemagen -check
+
+//go:generate go run ./internal/structgen -spec master-product.spec.json -o master-product.go -proto master-product.proto
+//go:generate go run ./internal/schemagen -o master-product.schema.json
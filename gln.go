@@ -0,0 +1,124 @@
+package structs
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// GLNLength is the length of a Global Location Number.
+const GLNLength = 13
+
+var (
+	// ErrGLNEmpty is returned when a GLN is empty.
+	ErrGLNEmpty = errors.New("gln is empty")
+	// ErrGLNLength is returned when a GLN is not exactly 13 digits long.
+	ErrGLNLength = errors.New("gln has invalid length")
+	// ErrGLNCharacters is returned when a GLN contains other characters than digits.
+	ErrGLNCharacters = errors.New("gln contains non-digit characters")
+	// ErrGLNCheckDigit is returned when the GS1 mod-10 check digit does not match.
+	ErrGLNCheckDigit = errors.New("gln check digit mismatch")
+)
+
+// GLN is a Global Location Number identifying a party or a location.
+type GLN string
+
+// Validate returns an error if the GLN is not a 13 digit number with a valid
+// GS1 check digit. Surrounding white space is ignored.
+func (g GLN) Validate() error {
+	s := strings.TrimSpace(string(g))
+	switch {
+	case s == "":
+		return ErrGLNEmpty
+	case len(s) != GLNLength:
+		return fmt.Errorf("%q: %w", s, ErrGLNLength)
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return fmt.Errorf("%q: %w", s, ErrGLNCharacters)
+		}
+	}
+	check, err := GS1CheckDigit(s[:GLNLength-1])
+	if err != nil {
+		return err
+	}
+	if check != s[GLNLength-1] {
+		return fmt.Errorf("%q: expected check digit %c: %w", s, check, ErrGLNCheckDigit)
+	}
+	return nil
+}
+
+// IsValid reports whether the GLN is valid.
+func (g GLN) IsValid() bool {
+	return g.Validate() == nil
+}
+
+// GLNField is a single GLN value found in a product.
+type GLNField struct {
+	// JSON pointer to the field, for example /tradeItem/informationProviderOfTradeItem/gln.
+	Path string
+	// Value as given in the product.
+	Value string
+	// Name of the party identified by the GLN, when given.
+	PartyName string
+	// Validation error, nil when the value is valid.
+	Err error
+}
+
+// Valid reports whether the field holds a valid GLN.
+func (f GLNField) Valid() bool {
+	return f.Err == nil
+}
+
+// GLNReport contains the result of MasterProductData.CheckGLNs.
+type GLNReport struct {
+	// All GLN fields of the product.
+	Fields []GLNField
+}
+
+// Invalid returns the GLN fields that failed validation.
+func (r GLNReport) Invalid() []GLNField {
+	var invalid []GLNField
+	for _, f := range r.Fields {
+		if f.Err != nil {
+			invalid = append(invalid, f)
+		}
+	}
+	return invalid
+}
+
+// OK reports whether all GLN fields of the product are valid.
+func (r GLNReport) OK() bool {
+	return len(r.Invalid()) == 0
+}
+
+// CheckGLNs lists every GLN of the product: the information provider, the
+// manufacturers and the media providers. The information provider GLN is
+// always listed, manufacturer and media provider GLNs are listed when the
+// party is given. Empty values are reported as invalid.
+func (p MasterProductData) CheckGLNs() GLNReport {
+	var r GLNReport
+	check := func(path, value, partyName string) {
+		r.Fields = append(r.Fields, GLNField{
+			Path:      path,
+			Value:     value,
+			PartyName: partyName,
+			Err:       GLN(value).Validate(),
+		})
+	}
+
+	provider := p.TradeItem.InformationProviderOfTradeItem
+	check("/tradeItem/informationProviderOfTradeItem/gln", provider.GLN, provider.PartyName)
+	for i, m := range p.TradeItem.ManufacturerOfTradeItems {
+		check("/tradeItem/manufacturerOfTradeItem/"+strconv.Itoa(i)+"/gln", m.GLN, m.PartyName)
+	}
+	for i, m := range p.TradeItem.TradeItemInformation.Extension.DGMediaModule.Media {
+		if m.MediaProvider == (MediaProvider{}) {
+			continue
+		}
+		check("/tradeItem/tradeItemInformation/extensions/dgMediaModule/media/"+strconv.Itoa(i)+"/mediaProvider/gln",
+			m.MediaProvider.Gln, m.MediaProvider.PartyName)
+	}
+	return r
+}
@@ -0,0 +1,81 @@
+package structs
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestGLNValidate(t *testing.T) {
+	for _, tt := range []struct {
+		gln GLN
+		err error
+	}{
+		{gln: "4012345000009"},
+		{gln: "7612345678900"},
+		{gln: " 4012345000009 "},
+		{gln: "", err: ErrGLNEmpty},
+		{gln: "  ", err: ErrGLNEmpty},
+		{gln: "401234500000", err: ErrGLNLength},
+		{gln: "04012345000009", err: ErrGLNLength},
+		{gln: "40123450000a9", err: ErrGLNCharacters},
+		{gln: "4012345000008", err: ErrGLNCheckDigit},
+	} {
+		err := tt.gln.Validate()
+		if tt.err == nil {
+			if err != nil {
+				t.Errorf("GLN(%q).Validate(): %v", tt.gln, err)
+			}
+		} else if !errors.Is(err, tt.err) {
+			t.Errorf("GLN(%q).Validate() error = %v, want %v", tt.gln, err, tt.err)
+		}
+		if got := tt.gln.IsValid(); got != (tt.err == nil) {
+			t.Errorf("GLN(%q).IsValid() = %v", tt.gln, got)
+		}
+	}
+}
+
+func TestCheckGLNs(t *testing.T) {
+	var p MasterProductData
+	p.TradeItem.InformationProviderOfTradeItem.GLN = "4012345000009"
+	p.TradeItem.InformationProviderOfTradeItem.PartyName = "Provider"
+	p.TradeItem.ManufacturerOfTradeItems = []ManufacturerOfTradeItem{
+		{GLN: "7612345678900", PartyName: "Manufacturer"},
+		{GLN: "7612345678901"},
+	}
+	media := []GDSNMedia{{}, {}}
+	media[1].MediaProvider.Gln = ""
+	media[1].MediaProvider.PartyName = "Studio"
+	p.TradeItem.TradeItemInformation.Extension.DGMediaModule.Media = media
+
+	r := p.CheckGLNs()
+	paths := []string{
+		"/tradeItem/informationProviderOfTradeItem/gln",
+		"/tradeItem/manufacturerOfTradeItem/0/gln",
+		"/tradeItem/manufacturerOfTradeItem/1/gln",
+		"/tradeItem/tradeItemInformation/extensions/dgMediaModule/media/1/mediaProvider/gln",
+	}
+	if len(r.Fields) != len(paths) {
+		t.Fatalf("Fields = %+v, want %d fields", r.Fields, len(paths))
+	}
+	for i, path := range paths {
+		if r.Fields[i].Path != path {
+			t.Errorf("Fields[%d].Path = %q, want %q", i, r.Fields[i].Path, path)
+		}
+	}
+	if r.Fields[0].PartyName != "Provider" || !r.Fields[0].Valid() {
+		t.Errorf("Fields[0] = %+v", r.Fields[0])
+	}
+	if r.OK() {
+		t.Fatal("OK() = true, want invalid fields")
+	}
+	invalid := r.Invalid()
+	if len(invalid) != 2 {
+		t.Fatalf("Invalid() = %+v, want 2 fields", invalid)
+	}
+	if !errors.Is(invalid[0].Err, ErrGLNCheckDigit) {
+		t.Errorf("Invalid()[0].Err = %v, want %v", invalid[0].Err, ErrGLNCheckDigit)
+	}
+	if !errors.Is(invalid[1].Err, ErrGLNEmpty) || invalid[1].PartyName != "Studio" {
+		t.Errorf("Invalid()[1] = %+v", invalid[1])
+	}
+}
@@ -0,0 +1,189 @@
+package structs
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// GTINLength is the length of a normalized GTIN. All GTIN formats (GTIN-8,
+// GTIN-12, GTIN-13 and GTIN-14) are normalized to GTIN-14 by left padding
+// with zeros.
+const GTINLength = 14
+
+var (
+	// ErrGTINEmpty is returned when a GTIN is empty or contains only zeros.
+	ErrGTINEmpty = errors.New("gtin is empty")
+	// ErrGTINLength is returned when a GTIN does not have 8, 12, 13 or 14
+	// digits.
+	ErrGTINLength = errors.New("gtin has invalid length")
+	// ErrGTINCharacters is returned when a GTIN contains other characters than digits.
+	ErrGTINCharacters = errors.New("gtin contains non-digit characters")
+	// ErrGTINCheckDigit is returned when the GS1 mod-10 check digit does not match.
+	ErrGTINCheckDigit = errors.New("gtin check digit mismatch")
+)
+
+// GTIN is a Global Trade Item Number in GTIN-8, GTIN-12, GTIN-13 or GTIN-14
+// format. Normalize pads the shorter formats with leading zeros to GTIN-14.
+type GTIN string
+
+// GS1CheckDigit calculates the GS1 mod-10 check digit for the given digits.
+// The digits must not contain the check digit itself. The same algorithm is
+// used for all GS1 keys, for example GTIN and GLN.
+func GS1CheckDigit(digits string) (byte, error) {
+	sum := 0
+	weight := 3
+	for i := len(digits) - 1; i >= 0; i-- {
+		c := digits[i]
+		if c < '0' || c > '9' {
+			return 0, fmt.Errorf("%q contains non-digit characters", digits)
+		}
+		sum += int(c-'0') * weight
+		weight = 4 - weight
+	}
+	return byte('0' + (10-sum%10)%10), nil
+}
+
+// Normalize validates the GTIN and returns it in GTIN-14 format.
+// Surrounding white space is ignored.
+func (g GTIN) Normalize() (GTIN, error) {
+	s := strings.TrimSpace(string(g))
+	if s == "" {
+		return "", ErrGTINEmpty
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return "", fmt.Errorf("%q: %w", s, ErrGTINCharacters)
+		}
+	}
+	switch len(s) {
+	case 8, 12, 13, GTINLength:
+	default:
+		return "", fmt.Errorf("%q: %d digits: %w", s, len(s), ErrGTINLength)
+	}
+	padded := strings.Repeat("0", GTINLength-len(s)) + s
+	if strings.TrimLeft(padded, "0") == "" {
+		return "", ErrGTINEmpty
+	}
+	check, err := GS1CheckDigit(padded[:GTINLength-1])
+	if err != nil {
+		return "", err
+	}
+	if check != padded[GTINLength-1] {
+		return "", fmt.Errorf("%q: expected check digit %c: %w", s, check, ErrGTINCheckDigit)
+	}
+	return GTIN(padded), nil
+}
+
+// Validate returns an error if the GTIN is not valid.
+func (g GTIN) Validate() error {
+	_, err := g.Normalize()
+	return err
+}
+
+// IsValid reports whether the GTIN is valid.
+func (g GTIN) IsValid() bool {
+	return g.Validate() == nil
+}
+
+// Equal reports whether both GTINs are valid and identify the same trade item,
+// regardless of the format they are given in.
+func (g GTIN) Equal(other GTIN) bool {
+	a, err := g.Normalize()
+	if err != nil {
+		return false
+	}
+	b, err := other.Normalize()
+	if err != nil {
+		return false
+	}
+	return a == b
+}
+
+// Format returns the GTIN in the shortest standard format (GTIN-8, GTIN-12,
+// GTIN-13 or GTIN-14) able to represent it.
+func (g GTIN) Format() (GTIN, error) {
+	n, err := g.Normalize()
+	if err != nil {
+		return "", err
+	}
+	for _, l := range []int{8, 12, 13} {
+		if strings.TrimLeft(string(n[:GTINLength-l]), "0") == "" {
+			return n[GTINLength-l:], nil
+		}
+	}
+	return n, nil
+}
+
+// GTINField is a single GTIN value found in a product.
+type GTINField struct {
+	// JSON pointer to the field, for example /tradeItem/gtin.
+	Path string
+	// Value as given in the product.
+	Value string
+	// Normalized GTIN-14 value. Empty when the value is invalid.
+	Normalized GTIN
+	// Validation error, nil when the value is valid.
+	Err error
+}
+
+// GTINReport contains the result of MasterProductData.CheckGTINs.
+type GTINReport struct {
+	// All GTIN fields of the product.
+	Fields []GTINField
+	// Normalized GTIN of the product. Empty when no valid product GTIN was found.
+	GTIN GTIN
+	// Product GTIN fields disagreeing with GTIN after normalization.
+	Mismatches []GTINField
+}
+
+// Invalid returns the GTIN fields that failed validation.
+func (r GTINReport) Invalid() []GTINField {
+	var invalid []GTINField
+	for _, f := range r.Fields {
+		if f.Err != nil {
+			invalid = append(invalid, f)
+		}
+	}
+	return invalid
+}
+
+// OK reports whether all GTIN fields are valid and the product GTIN fields agree.
+func (r GTINReport) OK() bool {
+	return len(r.Invalid()) == 0 && len(r.Mismatches) == 0
+}
+
+// CheckGTINs validates all GTIN fields of the product and reports whether the
+// product's own GTIN fields (gtin and tradeItem.gtin) identify the same trade item.
+// Empty fields are reported as invalid.
+func (p MasterProductData) CheckGTINs() GTINReport {
+	var r GTINReport
+	check := func(path, value string) GTINField {
+		f := GTINField{Path: path, Value: value}
+		f.Normalized, f.Err = GTIN(value).Normalize()
+		r.Fields = append(r.Fields, f)
+		return f
+	}
+
+	own := []GTINField{
+		check("/gtin", p.Gtin),
+		check("/tradeItem/gtin", p.TradeItem.GTIN),
+	}
+	for i, ref := range p.TradeItem.ReferencedTradeItems {
+		check("/tradeItem/referencedTradeItem/"+strconv.Itoa(i)+"/gtin", ref.GTIN)
+	}
+
+	for _, f := range own {
+		if f.Err == nil {
+			r.GTIN = f.Normalized
+			break
+		}
+	}
+	for _, f := range own {
+		if f.Err == nil && f.Normalized != r.GTIN {
+			r.Mismatches = append(r.Mismatches, f)
+		}
+	}
+	return r
+}
@@ -0,0 +1,151 @@
+package structs
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestGS1CheckDigit(t *testing.T) {
+	for _, tt := range []struct {
+		digits string
+		want   byte
+	}{
+		{"400638133393", '1'},
+		{"9638507", '4'},
+		{"03600029145", '2'},
+		{"0000000000000", '0'},
+	} {
+		got, err := GS1CheckDigit(tt.digits)
+		if err != nil {
+			t.Errorf("GS1CheckDigit(%q): %v", tt.digits, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("GS1CheckDigit(%q) = %c, want %c", tt.digits, got, tt.want)
+		}
+	}
+	if _, err := GS1CheckDigit("12a"); err == nil {
+		t.Error("GS1CheckDigit(\"12a\"): expected error")
+	}
+}
+
+func TestGTINNormalize(t *testing.T) {
+	for _, tt := range []struct {
+		gtin GTIN
+		want GTIN
+		err  error
+	}{
+		{gtin: "96385074", want: "00000096385074"},
+		{gtin: "036000291452", want: "00036000291452"},
+		{gtin: "4006381333931", want: "04006381333931"},
+		{gtin: "04006381333931", want: "04006381333931"},
+		{gtin: " 4006381333931\n", want: "04006381333931"},
+		{gtin: "", err: ErrGTINEmpty},
+		{gtin: "   ", err: ErrGTINEmpty},
+		{gtin: "00000000", err: ErrGTINEmpty},
+		{gtin: "0", err: ErrGTINLength},
+		{gtin: "6385074", err: ErrGTINLength},
+		{gtin: "0096385074", err: ErrGTINLength},
+		{gtin: "40063813339", err: ErrGTINLength},
+		{gtin: "004006381333931", err: ErrGTINLength},
+		{gtin: "4006381333932", err: ErrGTINCheckDigit},
+		{gtin: "40063813339a1", err: ErrGTINCharacters},
+		{gtin: "4006-381333931", err: ErrGTINCharacters},
+	} {
+		got, err := tt.gtin.Normalize()
+		if tt.err != nil {
+			if !errors.Is(err, tt.err) {
+				t.Errorf("GTIN(%q).Normalize() error = %v, want %v", tt.gtin, err, tt.err)
+			}
+			if tt.gtin.IsValid() {
+				t.Errorf("GTIN(%q).IsValid() = true", tt.gtin)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("GTIN(%q).Normalize(): %v", tt.gtin, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("GTIN(%q).Normalize() = %q, want %q", tt.gtin, got, tt.want)
+		}
+		if !tt.gtin.IsValid() {
+			t.Errorf("GTIN(%q).IsValid() = false", tt.gtin)
+		}
+	}
+}
+
+func TestGTINFormat(t *testing.T) {
+	for _, tt := range []struct {
+		gtin, want GTIN
+	}{
+		{"00000096385074", "96385074"},
+		{"000036000291452", ""},
+		{"00036000291452", "036000291452"},
+		{"04006381333931", "4006381333931"},
+		{"14006381333938", "14006381333938"},
+	} {
+		got, err := tt.gtin.Format()
+		if tt.want == "" {
+			if err == nil {
+				t.Errorf("GTIN(%q).Format() = %q, expected error", tt.gtin, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("GTIN(%q).Format(): %v", tt.gtin, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("GTIN(%q).Format() = %q, want %q", tt.gtin, got, tt.want)
+		}
+	}
+}
+
+func TestGTINEqual(t *testing.T) {
+	for _, tt := range []struct {
+		a, b GTIN
+		want bool
+	}{
+		{"4006381333931", "04006381333931", true},
+		{"96385074", "00000096385074", true},
+		{"4006381333931", "96385074", false},
+		{"4006381333932", "4006381333932", false},
+		{"", "", false},
+	} {
+		if got := tt.a.Equal(tt.b); got != tt.want {
+			t.Errorf("GTIN(%q).Equal(%q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestCheckGTINs(t *testing.T) {
+	var p MasterProductData
+	p.Gtin = "4006381333931"
+	p.TradeItem.GTIN = "04006381333931"
+	p.TradeItem.ReferencedTradeItems = []ReferencedTradeItem{{GTIN: "96385074"}}
+	r := p.CheckGTINs()
+	if !r.OK() {
+		t.Fatalf("CheckGTINs() = %+v, want OK", r)
+	}
+	if r.GTIN != "04006381333931" {
+		t.Errorf("GTIN = %q, want 04006381333931", r.GTIN)
+	}
+	if len(r.Fields) != 3 || r.Fields[2].Path != "/tradeItem/referencedTradeItem/0/gtin" {
+		t.Errorf("Fields = %+v", r.Fields)
+	}
+
+	p.TradeItem.GTIN = "96385074"
+	p.TradeItem.ReferencedTradeItems[0].GTIN = "6385074"
+	r = p.CheckGTINs()
+	if r.OK() {
+		t.Fatal("CheckGTINs() = OK, want mismatch and invalid field")
+	}
+	if len(r.Mismatches) != 1 || r.Mismatches[0].Path != "/tradeItem/gtin" {
+		t.Errorf("Mismatches = %+v", r.Mismatches)
+	}
+	invalid := r.Invalid()
+	if len(invalid) != 1 || !errors.Is(invalid[0].Err, ErrGTINLength) {
+		t.Errorf("Invalid() = %+v", invalid)
+	}
+}
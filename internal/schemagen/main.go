@@ -0,0 +1,345 @@
+// Command schemagen generates the JSON Schema of MasterProductData from the
+// Go sources of package structs. Property names come from the JSON tags and
+// descriptions from the doc comments.
+//
+// Usage:
+//
+//	go run ./internal/schemagen [-dir .] [-o master-product.schema.json] [-check]
+//
+// With -check the schema is not written. Instead, the command fails when the
+// existing schema file differs from the generated one.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+const (
+	schemaDialect = "https://json-schema.org/draft/2020-12/schema"
+	rootType      = "MasterProductData"
+)
+
+// decimalPattern matches the numbers Decimal accepts as JSON strings.
+const decimalPattern = `^\s*[+-]?([0-9]+([.,][0-9]*)?|[.,][0-9]+)([eE][+-]?[0-9]+)?\s*$`
+
+// typeSchemas are the schemas of types having their own JSON encoding.
+var typeSchemas = map[string]map[string]interface{}{
+	"Decimal":          {"type": []string{"number", "string", "null"}, "pattern": decimalPattern},
+	"NullFloat64":      {"type": []string{"number", "null"}},
+	"NullInt":          {"type": []string{"integer", "null"}},
+	"NullBool":         {"type": []string{"boolean", "null"}},
+	"time.Time":        {"type": "string", "format": "date-time"},
+	"json.RawMessage":  {},
+	"json.Number":      {"type": "number"},
+	"GTIN":             {"type": "string"},
+	"GLN":              {"type": "string"},
+	"TemperatureClass": {"type": "string"},
+}
+
+var basicSchemas = map[string]string{
+	"string":  "string",
+	"bool":    "boolean",
+	"int":     "integer",
+	"int8":    "integer",
+	"int16":   "integer",
+	"int32":   "integer",
+	"int64":   "integer",
+	"uint":    "integer",
+	"uint8":   "integer",
+	"uint16":  "integer",
+	"uint32":  "integer",
+	"uint64":  "integer",
+	"float32": "number",
+	"float64": "number",
+}
+
+type generator struct {
+	specs map[string]*ast.TypeSpec
+	docs  map[string]string
+	defs  map[string]interface{}
+}
+
+func main() {
+	dir := flag.String("dir", ".", "directory of package structs")
+	out := flag.String("o", "master-product.schema.json", "schema file")
+	check := flag.Bool("check", false, "fail if the schema file is not up to date")
+	flag.Parse()
+
+	schema, err := generate(*dir)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "schemagen:", err)
+		os.Exit(1)
+	}
+	if *check {
+		old, err := ioutil.ReadFile(*out)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "schemagen:", err)
+			os.Exit(1)
+		}
+		if !bytes.Equal(old, schema) {
+			fmt.Fprintf(os.Stderr, "schemagen: %s is not up to date, run go generate\n", *out)
+			os.Exit(1)
+		}
+		return
+	}
+	if err := ioutil.WriteFile(*out, schema, 0644); err != nil {
+		fmt.Fprintln(os.Stderr, "schemagen:", err)
+		os.Exit(1)
+	}
+}
+
+// generate returns the schema of the package in dir.
+func generate(dir string) ([]byte, error) {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, dir, func(fi os.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go")
+	}, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+	g := &generator{
+		specs: map[string]*ast.TypeSpec{},
+		docs:  map[string]string{},
+		defs:  map[string]interface{}{},
+	}
+	for _, pkg := range pkgs {
+		for _, f := range pkg.Files {
+			for _, decl := range f.Decls {
+				gen, ok := decl.(*ast.GenDecl)
+				if !ok || gen.Tok != token.TYPE {
+					continue
+				}
+				for _, spec := range gen.Specs {
+					ts := spec.(*ast.TypeSpec)
+					g.specs[ts.Name.Name] = ts
+					doc := ts.Doc
+					if doc == nil && len(gen.Specs) == 1 {
+						doc = gen.Doc
+					}
+					g.docs[ts.Name.Name] = description(doc)
+				}
+			}
+		}
+	}
+	if _, ok := g.specs[rootType]; !ok {
+		return nil, fmt.Errorf("type %s not found in %s", rootType, dir)
+	}
+	root := map[string]interface{}{
+		"$schema": schemaDialect,
+		"title":   rootType,
+		"$ref":    "#/$defs/" + rootType,
+		"$defs":   g.defs,
+	}
+	g.named(rootType)
+	data, err := json.MarshalIndent(root, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+// description returns the comment as one line.
+func description(c *ast.CommentGroup) string {
+	if c == nil {
+		return ""
+	}
+	return strings.Join(strings.Fields(c.Text()), " ")
+}
+
+// named returns a reference to the definition of a named type, generating
+// the definition on first use.
+func (g *generator) named(name string) map[string]interface{} {
+	if s, ok := typeSchemas[name]; ok {
+		return copySchema(s)
+	}
+	ref := map[string]interface{}{"$ref": "#/$defs/" + name}
+	if _, ok := g.defs[name]; ok {
+		return ref
+	}
+	spec, ok := g.specs[name]
+	if !ok {
+		panic("schemagen: unknown type " + name)
+	}
+	// Reserve the name first for recursive types.
+	g.defs[name] = nil
+	def := g.schema(spec.Type, true)
+	if doc := g.docs[name]; doc != "" {
+		def["description"] = doc
+	}
+	g.defs[name] = def
+	return ref
+}
+
+// schema returns the schema of a type expression. Lists, maps and pointers
+// encode nil as null unless omitted.
+func (g *generator) schema(expr ast.Expr, nullable bool) map[string]interface{} {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		if typ, ok := basicSchemas[t.Name]; ok {
+			return map[string]interface{}{"type": typ}
+		}
+		return g.named(t.Name)
+	case *ast.SelectorExpr:
+		name := t.X.(*ast.Ident).Name + "." + t.Sel.Name
+		s, ok := typeSchemas[name]
+		if !ok {
+			panic("schemagen: unsupported type " + name)
+		}
+		return copySchema(s)
+	case *ast.StarExpr:
+		s := g.schema(t.X, nullable)
+		if nullable {
+			return orNull(s)
+		}
+		return s
+	case *ast.ArrayType:
+		s := map[string]interface{}{"type": "array", "items": g.schema(t.Elt, true)}
+		if ident, ok := t.Elt.(*ast.Ident); ok && ident.Name == "byte" {
+			s = map[string]interface{}{"type": "string", "contentEncoding": "base64"}
+		}
+		if nullable && t.Len == nil {
+			return orNull(s)
+		}
+		return s
+	case *ast.MapType:
+		s := map[string]interface{}{"type": "object", "additionalProperties": g.schema(t.Value, true)}
+		if nullable {
+			return orNull(s)
+		}
+		return s
+	case *ast.StructType:
+		return g.object(t)
+	case *ast.InterfaceType:
+		return map[string]interface{}{}
+	}
+	panic(fmt.Sprintf("schemagen: unsupported type expression %T", expr))
+}
+
+// object returns the schema of a struct. Fields are named by their JSON tags
+// the way encoding/json does. Fields without omitempty are always encoded and
+// therefore required. Unknown members are allowed, as they are when decoding.
+func (g *generator) object(t *ast.StructType) map[string]interface{} {
+	props := map[string]interface{}{}
+	required := map[string]bool{}
+	for _, f := range t.Fields.List {
+		var tag reflect.StructTag
+		if f.Tag != nil {
+			tag = reflect.StructTag(strings.Trim(f.Tag.Value, "`"))
+		}
+		opts := strings.Split(tag.Get("json"), ",")
+		if opts[0] == "-" && len(opts) == 1 {
+			continue
+		}
+		omitempty := false
+		for _, o := range opts[1:] {
+			omitempty = omitempty || o == "omitempty"
+		}
+		if len(f.Names) == 0 {
+			// Embedded struct without name in the tag: its fields are promoted.
+			if opts[0] == "" {
+				name := embeddedName(f.Type)
+				if spec, ok := g.specs[name]; ok {
+					if st, ok := spec.Type.(*ast.StructType); ok {
+						embedded := g.object(st)
+						for k, v := range embedded["properties"].(map[string]interface{}) {
+							props[k] = v
+						}
+						names, _ := embedded["required"].([]string)
+						for _, k := range names {
+							required[k] = true
+						}
+						continue
+					}
+				}
+			}
+			f.Names = []*ast.Ident{ast.NewIdent(embeddedName(f.Type))}
+		}
+		for _, n := range f.Names {
+			if !n.IsExported() {
+				continue
+			}
+			name := opts[0]
+			if name == "" {
+				name = n.Name
+			}
+			s := g.schema(f.Type, !omitempty)
+			doc := description(f.Doc)
+			if doc == "" {
+				doc = description(f.Comment)
+			}
+			if doc != "" {
+				if _, ref := s["$ref"]; ref {
+					// Keep the reference the only keyword next to the description.
+					s = map[string]interface{}{"$ref": s["$ref"]}
+				}
+				s["description"] = doc
+			}
+			props[name] = s
+			if !omitempty {
+				required[name] = true
+			}
+		}
+	}
+	s := map[string]interface{}{"type": "object", "properties": props}
+	if len(required) > 0 {
+		names := make([]string, 0, len(required))
+		for k := range required {
+			names = append(names, k)
+		}
+		sort.Strings(names)
+		s["required"] = names
+	}
+	return s
+}
+
+func embeddedName(expr ast.Expr) string {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		return t.Name
+	case *ast.StarExpr:
+		return embeddedName(t.X)
+	case *ast.SelectorExpr:
+		return t.Sel.Name
+	}
+	return ""
+}
+
+// orNull returns a schema also accepting null.
+func orNull(s map[string]interface{}) map[string]interface{} {
+	switch typ := s["type"].(type) {
+	case string:
+		s["type"] = []string{typ, "null"}
+		return s
+	case []string:
+		for _, t := range typ {
+			if t == "null" {
+				return s
+			}
+		}
+		s["type"] = append(append([]string(nil), typ...), "null")
+		return s
+	}
+	if len(s) == 0 {
+		return s
+	}
+	return map[string]interface{}{"anyOf": []interface{}{s, map[string]interface{}{"type": "null"}}}
+}
+
+func copySchema(s map[string]interface{}) map[string]interface{} {
+	res := make(map[string]interface{}, len(s))
+	for k, v := range s {
+		res[k] = v
+	}
+	return res
+}
@@ -0,0 +1,351 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	structs "github.com/foodiefm/go-structs"
+)
+
+const (
+	packageDir = "../.."
+	schemaFile = "../../master-product.schema.json"
+)
+
+func readSchema(t *testing.T) map[string]interface{} {
+	t.Helper()
+	data, err := ioutil.ReadFile(schemaFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var schema map[string]interface{}
+	if err := json.Unmarshal(data, &schema); err != nil {
+		t.Fatal(err)
+	}
+	return schema
+}
+
+func TestGenerate(t *testing.T) {
+	got, err := generate(packageDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := ioutil.ReadFile(schemaFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("%s is not up to date, run go generate", schemaFile)
+	}
+}
+
+// validator checks documents against the subset of JSON Schema generate
+// produces.
+type validator struct {
+	defs map[string]interface{}
+	errs []string
+}
+
+func (v *validator) errorf(path, format string, args ...interface{}) {
+	v.errs = append(v.errs, path+": "+fmt.Sprintf(format, args...))
+}
+
+func (v *validator) validate(path string, schema map[string]interface{}, doc interface{}) {
+	if ref, ok := schema["$ref"].(string); ok {
+		v.validate(path, v.defs[strings.TrimPrefix(ref, "#/$defs/")].(map[string]interface{}), doc)
+	}
+	if anyOf, ok := schema["anyOf"].([]interface{}); ok {
+		var errs []string
+		for _, s := range anyOf {
+			sub := &validator{defs: v.defs}
+			sub.validate(path, s.(map[string]interface{}), doc)
+			if len(sub.errs) == 0 {
+				errs = nil
+				break
+			}
+			errs = append(errs, sub.errs...)
+		}
+		v.errs = append(v.errs, errs...)
+	}
+	if typ, ok := schema["type"]; ok && !hasType(typ, doc) {
+		v.errorf(path, "%v is not of type %v", doc, typ)
+		return
+	}
+	switch doc := doc.(type) {
+	case string:
+		if pattern, ok := schema["pattern"].(string); ok && !regexp.MustCompile(pattern).MatchString(doc) {
+			v.errorf(path, "%q does not match %s", doc, pattern)
+		}
+		if schema["format"] == "date-time" {
+			if _, err := time.Parse(time.RFC3339Nano, doc); err != nil {
+				v.errorf(path, "%v", err)
+			}
+		}
+	case []interface{}:
+		if items, ok := schema["items"].(map[string]interface{}); ok {
+			for i, e := range doc {
+				v.validate(fmt.Sprintf("%s/%d", path, i), items, e)
+			}
+		}
+	case map[string]interface{}:
+		required, _ := schema["required"].([]interface{})
+		for _, name := range required {
+			if _, ok := doc[name.(string)]; !ok {
+				v.errorf(path, "required member %s is missing", name)
+			}
+		}
+		props, _ := schema["properties"].(map[string]interface{})
+		additional, _ := schema["additionalProperties"].(map[string]interface{})
+		names := make([]string, 0, len(doc))
+		for name := range doc {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			e := doc[name]
+			if s, ok := props[name].(map[string]interface{}); ok {
+				v.validate(path+"/"+name, s, e)
+			} else if additional != nil {
+				v.validate(path+"/"+name, additional, e)
+			}
+		}
+	}
+}
+
+func hasType(typ interface{}, doc interface{}) bool {
+	if types, ok := typ.([]interface{}); ok {
+		for _, t := range types {
+			if hasType(t, doc) {
+				return true
+			}
+		}
+		return false
+	}
+	switch doc := doc.(type) {
+	case nil:
+		return typ == "null"
+	case bool:
+		return typ == "boolean"
+	case string:
+		return typ == "string"
+	case json.Number:
+		_, err := doc.Int64()
+		return typ == "number" || typ == "integer" && err == nil
+	case []interface{}:
+		return typ == "array"
+	case map[string]interface{}:
+		return typ == "object"
+	}
+	return false
+}
+
+// validateDocument returns the errors of validating the JSON document.
+func validateDocument(t *testing.T, schema map[string]interface{}, data []byte) []string {
+	t.Helper()
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var doc interface{}
+	if err := dec.Decode(&doc); err != nil {
+		t.Fatal(err)
+	}
+	v := &validator{defs: schema["$defs"].(map[string]interface{})}
+	v.validate("", schema, doc)
+	return v.errs
+}
+
+func sampleDocuments(t *testing.T) map[string][]byte {
+	t.Helper()
+	var d structs.MasterProductData
+	if err := json.Unmarshal([]byte(`{
+		"gtin": "4006381333931",
+		"name": "Milk",
+		"Header": {"x_dataFormatVersion": "1.0"},
+		"tradeItem": {
+			"tradeItemSynchronisationDates": {"lastChangeDateTime": "2020-05-01T10:00:00Z"},
+			"tradeItemInformation": {"extensions": {
+				"foodAndBeverageIngredientModule": {"juiceContentPercent": 12.5, "x_isFoodOrBeverage": true},
+				"tradeItemDescriptionModule": {"tradeItemDescriptionInformation": {"tradeItemDescription": [
+					{"$": "Maito", "@languageCode": "fi"}
+				]}},
+				"tradeItemMeasurementsModule": {"tradeItemMeasurements": {"netContent": [
+					{"$": "1.5", "@measurementUnitCode": "LTR"}
+				]}}
+			}}
+		}
+	}`), &d); err != nil {
+		t.Fatal(err)
+	}
+	docs := map[string]structs.MasterProductData{"zero": {}, "json": d}
+	files, err := filepath.Glob(filepath.Join(packageDir, "testdata", "gdsn-*.xml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, file := range files {
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var d structs.MasterProductData
+		if err := xml.Unmarshal(data, &d.TradeItem); err != nil {
+			t.Fatal(err)
+		}
+		docs[filepath.Base(file)] = d
+	}
+	res := map[string][]byte{}
+	for name, d := range docs {
+		data, err := json.Marshal(d)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res[name] = data
+	}
+	return res
+}
+
+func TestSchemaValidatesDocuments(t *testing.T) {
+	schema := readSchema(t)
+	for name, data := range sampleDocuments(t) {
+		if errs := validateDocument(t, schema, data); len(errs) > 0 {
+			t.Errorf("%s: %s", name, strings.Join(errs, "\n"))
+		}
+	}
+
+	// Invalid documents are made from the zero document.
+	for _, tt := range []struct {
+		change func(doc map[string]interface{})
+		want   []string
+	}{
+		{func(doc map[string]interface{}) { doc["gtin"] = 4006381333931 }, []string{"/gtin: 4006381333931 is not of type string"}},
+		{func(doc map[string]interface{}) { delete(doc, "Header") }, []string{": required member Header is missing"}},
+		{func(doc map[string]interface{}) {
+			doc["tradeItem"].(map[string]interface{})["tradeItemSynchronisationDates"] = map[string]interface{}{"lastChangeDateTime": true}
+		}, []string{"/tradeItem/tradeItemSynchronisationDates/lastChangeDateTime: true is not of type string"}},
+	} {
+		var doc map[string]interface{}
+		if err := json.Unmarshal(sampleDocuments(t)["zero"], &doc); err != nil {
+			t.Fatal(err)
+		}
+		tt.change(doc)
+		data, err := json.Marshal(doc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if errs := validateDocument(t, schema, data); !reflect.DeepEqual(errs, tt.want) {
+			t.Errorf("errors %q, want %q", errs, tt.want)
+		}
+	}
+}
+
+// jsonFields returns the fields of the struct type by JSON member name, the
+// way encoding/json names them.
+func jsonFields(typ reflect.Type) map[string]reflect.StructField {
+	fields := map[string]reflect.StructField{}
+	for i := 0; i < typ.NumField(); i++ {
+		f := typ.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name := strings.Split(tag, ",")[0]
+		if f.Anonymous && name == "" {
+			ft := f.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				for k, v := range jsonFields(ft) {
+					fields[k] = v
+				}
+				continue
+			}
+		}
+		if f.PkgPath != "" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		fields[name] = f
+	}
+	return fields
+}
+
+func TestSchemaMatchesTypes(t *testing.T) {
+	schema := readSchema(t)
+	defs := schema["$defs"].(map[string]interface{})
+	seen := map[reflect.Type]bool{}
+	var compare func(path string, typ reflect.Type, s map[string]interface{})
+	compare = func(path string, typ reflect.Type, s map[string]interface{}) {
+		if ref, ok := s["$ref"].(string); ok {
+			name := strings.TrimPrefix(ref, "#/$defs/")
+			if name != typ.Name() {
+				t.Errorf("%s: schema %s for type %s", path, name, typ)
+			}
+			s = defs[name].(map[string]interface{})
+		}
+		if _, ok := typeSchemas[typ.Name()]; ok && typ.Name() != "" || typ.PkgPath() == "time" {
+			return
+		}
+		switch typ.Kind() {
+		case reflect.Ptr:
+			compare(path, typ.Elem(), s)
+		case reflect.Slice, reflect.Array:
+			if typ.Elem().Kind() == reflect.Uint8 {
+				return
+			}
+			items, ok := s["items"].(map[string]interface{})
+			if !ok {
+				t.Errorf("%s: no items for %s", path, typ)
+				return
+			}
+			compare(path+"/*", typ.Elem(), items)
+		case reflect.Struct:
+			if seen[typ] {
+				return
+			}
+			seen[typ] = true
+			props, _ := s["properties"].(map[string]interface{})
+			required := map[string]bool{}
+			names, _ := s["required"].([]interface{})
+			for _, name := range names {
+				required[name.(string)] = true
+			}
+			fields := jsonFields(typ)
+			var missing []string
+			for name := range props {
+				if _, ok := fields[name]; !ok {
+					missing = append(missing, name)
+				}
+			}
+			sort.Strings(missing)
+			if len(missing) > 0 {
+				t.Errorf("%s: %s has no fields %v of the schema", path, typ, missing)
+			}
+			for name, f := range fields {
+				p, ok := props[name].(map[string]interface{})
+				if !ok {
+					t.Errorf("%s: member %s of %s missing in the schema", path, name, typ)
+					continue
+				}
+				if omit := strings.Contains(f.Tag.Get("json"), ",omitempty"); required[name] == omit {
+					t.Errorf("%s/%s: required %v with omitempty %v", path, name, required[name], omit)
+				}
+				compare(path+"/"+name, f.Type, p)
+			}
+		}
+	}
+	compare("", reflect.TypeOf(structs.MasterProductData{}), schema)
+	if len(seen) < 10 {
+		t.Errorf("compared only %d types", len(seen))
+	}
+}
@@ -0,0 +1,344 @@
+// Command structgen generates the Go types of the master product model and
+// their Protocol Buffers messages from master-product.spec.json. The spec
+// has the data format version of the model and lists the types in output
+// order:
+//
+//	{
+//	  "dataFormatVersion": "1.0",
+//	  "types": [
+//	    {
+//	      "name": "GDSNNetWeight",
+//	      "doc": [" GDSNNetWeight is ..."],
+//	      "slice": false,
+//	      "fields": [
+//	        {"name": "Measurement", "type": "Decimal", "json": "$", "proto": 1, "doc": [" ..."], "comment": " ..."}
+//	      ]
+//	    }
+//	  ]
+//	}
+//
+// The data format version is generated as CurrentDataFormatVersion. Its major
+// version is incremented for changes which old documents do not decode with,
+// such as removed or retyped fields, and its minor version for other changes.
+//
+// Doc and comment lines are written after "//" as they are. A type with
+// "slice" set is a slice of an anonymous struct. Field types are Go type
+// expressions; types defined outside of the spec, such as Decimal, must exist
+// in package structs. Proto is the field number in the message of the type,
+// which must not change once published.
+//
+// A type with a string "$" field and a string "@languageCode" field is a
+// localized text. For a struct type X a LocalizedText method is generated, for
+// a slice type X a LocalizedTexts method, and for both a function XTexts that
+// returns the texts of a []X in order.
+//
+// Usage:
+//
+//	go run ./internal/structgen [-spec master-product.spec.json] [-o master-product.go] [-proto master-product.proto] [-check]
+//
+// With -check the files are not written. Instead, the command fails when the
+// existing files differ from the generated ones.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/format"
+	"go/parser"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+)
+
+// Spec is the master product model.
+type Spec struct {
+	DataFormatVersion string `json:"dataFormatVersion"`
+	Types             []Type `json:"types"`
+}
+
+// Type is a named struct type.
+type Type struct {
+	Name   string   `json:"name"`
+	Doc    []string `json:"doc"`
+	Slice  bool     `json:"slice"`
+	Fields []Field  `json:"fields"`
+}
+
+// Field is a struct field.
+type Field struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+	// JSON tag value, for example "@languageCode" or "name,omitempty".
+	JSON string `json:"json"`
+	// Protocol Buffers field number.
+	Proto   int      `json:"proto"`
+	Doc     []string `json:"doc"`
+	Comment string   `json:"comment"`
+}
+
+// imports are the packages field types may refer to by qualifier.
+var imports = map[string]string{
+	"json": "encoding/json",
+	"time": "time",
+}
+
+func main() {
+	specFile := flag.String("spec", "master-product.spec.json", "spec file")
+	out := flag.String("o", "master-product.go", "Go file")
+	protoOut := flag.String("proto", "master-product.proto", "Protocol Buffers file")
+	check := flag.Bool("check", false, "fail if the generated files are not up to date")
+	flag.Parse()
+
+	spec, err := readSpec(*specFile)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "structgen:", err)
+		os.Exit(1)
+	}
+	src, err := spec.golang(*specFile)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "structgen:", err)
+		os.Exit(1)
+	}
+	proto, err := spec.proto(*specFile)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "structgen:", err)
+		os.Exit(1)
+	}
+	for _, f := range []struct {
+		name string
+		data []byte
+	}{{*out, src}, {*protoOut, proto}} {
+		if *check {
+			old, err := ioutil.ReadFile(f.name)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, "structgen:", err)
+				os.Exit(1)
+			}
+			if !bytes.Equal(old, f.data) {
+				fmt.Fprintf(os.Stderr, "structgen: %s is not up to date, run go generate\n", f.name)
+				os.Exit(1)
+			}
+			continue
+		}
+		if err := ioutil.WriteFile(f.name, f.data, 0644); err != nil {
+			fmt.Fprintln(os.Stderr, "structgen:", err)
+			os.Exit(1)
+		}
+	}
+}
+
+// readSpec reads and checks the spec file.
+func readSpec(specFile string) (Spec, error) {
+	data, err := ioutil.ReadFile(specFile)
+	if err != nil {
+		return Spec{}, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	var spec Spec
+	if err := dec.Decode(&spec); err != nil {
+		return Spec{}, fmt.Errorf("%s: %w", specFile, err)
+	}
+	if err := spec.check(); err != nil {
+		return Spec{}, fmt.Errorf("%s: %w", specFile, err)
+	}
+	return spec, nil
+}
+
+// golang returns the formatted Go source of the spec.
+func (spec Spec) golang(specFile string) ([]byte, error) {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// Code generated by structgen from %s. DO NOT EDIT.\n\n", specFile)
+	b.WriteString("package structs\n\n")
+	if pkgs := spec.imports(); len(pkgs) > 0 {
+		b.WriteString("import (\n")
+		for _, p := range pkgs {
+			fmt.Fprintf(&b, "%q\n", p)
+		}
+		b.WriteString(")\n")
+	}
+	if spec.DataFormatVersion != "" {
+		b.WriteString("\n// CurrentDataFormatVersion is the data format version MasterProductData\n")
+		b.WriteString("// implements. Documents without x_dataFormatVersion are taken to be of this\n")
+		b.WriteString("// version.\n")
+		fmt.Fprintf(&b, "const CurrentDataFormatVersion = %q\n", spec.DataFormatVersion)
+	}
+	for _, t := range spec.Types {
+		b.WriteByte('\n')
+		writeComment(&b, t.Doc)
+		kind := "struct"
+		if t.Slice {
+			kind = "[]struct"
+		}
+		fmt.Fprintf(&b, "type %s %s {\n", t.Name, kind)
+		for _, f := range t.Fields {
+			writeComment(&b, f.Doc)
+			fmt.Fprintf(&b, "%s %s `json:%q proto:\"%d\"`", f.Name, f.Type, f.JSON, f.Proto)
+			if f.Comment != "" {
+				fmt.Fprintf(&b, " //%s", f.Comment)
+			}
+			b.WriteByte('\n')
+		}
+		b.WriteString("}\n")
+		writeLocalized(&b, t)
+	}
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("formatting generated code: %w", err)
+	}
+	return src, nil
+}
+
+// localizedFields returns the names of the text and language code fields of
+// a localized text type, or false when t is not one.
+func (t Type) localizedFields() (value, lang string, ok bool) {
+	for _, f := range t.Fields {
+		if f.Type != "string" {
+			continue
+		}
+		switch strings.Split(f.JSON, ",")[0] {
+		case "$":
+			value = f.Name
+		case "@languageCode":
+			lang = f.Name
+		}
+	}
+	return value, lang, value != "" && lang != ""
+}
+
+// writeLocalized writes the LocalizedText accessors of a localized text type.
+func writeLocalized(b *bytes.Buffer, t Type) {
+	value, lang, ok := t.localizedFields()
+	if !ok {
+		return
+	}
+	if t.Slice {
+		fmt.Fprintf(b, "\n// LocalizedTexts returns the texts of n in order.\n")
+		fmt.Fprintf(b, "func (n %s) LocalizedTexts() []LocalizedText {\n", t.Name)
+		b.WriteString("texts := make([]LocalizedText, len(n))\n")
+		fmt.Fprintf(b, "for i, t := range n {\ntexts[i] = LocalizedText{Value: t.%s, LanguageCode: t.%s}\n}\n", value, lang)
+		b.WriteString("return texts\n}\n")
+		fmt.Fprintf(b, "\n// %sTexts returns the texts of s in order.\n", t.Name)
+		fmt.Fprintf(b, "func %sTexts(s []%s) []LocalizedText {\n", t.Name, t.Name)
+		b.WriteString("var texts []LocalizedText\n")
+		b.WriteString("for _, n := range s {\ntexts = append(texts, n.LocalizedTexts()...)\n}\n")
+		b.WriteString("return texts\n}\n")
+		return
+	}
+	fmt.Fprintf(b, "\n// LocalizedText returns t as a LocalizedText.\n")
+	fmt.Fprintf(b, "func (t %s) LocalizedText() LocalizedText {\n", t.Name)
+	fmt.Fprintf(b, "return LocalizedText{Value: t.%s, LanguageCode: t.%s}\n}\n", value, lang)
+	fmt.Fprintf(b, "\n// %sTexts returns the texts of s in order.\n", t.Name)
+	fmt.Fprintf(b, "func %sTexts(s []%s) []LocalizedText {\n", t.Name, t.Name)
+	b.WriteString("texts := make([]LocalizedText, len(s))\n")
+	b.WriteString("for i, t := range s {\ntexts[i] = t.LocalizedText()\n}\n")
+	b.WriteString("return texts\n}\n")
+}
+
+func writeComment(b *bytes.Buffer, lines []string) {
+	for _, l := range lines {
+		fmt.Fprintf(b, "//%s\n", l)
+	}
+}
+
+// check validates the version, names, types and tags of the spec.
+func (s Spec) check() error {
+	if s.DataFormatVersion != "" && !isVersion(s.DataFormatVersion) {
+		return fmt.Errorf("data format version %q: must be major.minor", s.DataFormatVersion)
+	}
+	types := map[string]bool{}
+	for _, t := range s.Types {
+		if !isExported(t.Name) {
+			return fmt.Errorf("type %q: name must be an exported identifier", t.Name)
+		}
+		if types[t.Name] {
+			return fmt.Errorf("type %s: defined twice", t.Name)
+		}
+		types[t.Name] = true
+		fields := map[string]bool{}
+		tags := map[string]bool{}
+		numbers := map[int]bool{}
+		for _, f := range t.Fields {
+			if !isExported(f.Name) {
+				return fmt.Errorf("%s.%s: name must be an exported identifier", t.Name, f.Name)
+			}
+			if fields[f.Name] {
+				return fmt.Errorf("%s.%s: defined twice", t.Name, f.Name)
+			}
+			fields[f.Name] = true
+			if _, err := parser.ParseExpr(f.Type); err != nil {
+				return fmt.Errorf("%s.%s: type %q: %w", t.Name, f.Name, f.Type, err)
+			}
+			name := strings.Split(f.JSON, ",")[0]
+			if name == "" || strings.ContainsAny(f.JSON, "\"`") {
+				return fmt.Errorf("%s.%s: invalid json tag %q", t.Name, f.Name, f.JSON)
+			}
+			if tags[name] {
+				return fmt.Errorf("%s.%s: json name %q used twice", t.Name, f.Name, name)
+			}
+			tags[name] = true
+			if f.Proto < 1 || f.Proto > maxFieldNumber || f.Proto >= 19000 && f.Proto <= 19999 {
+				return fmt.Errorf("%s.%s: invalid field number %d", t.Name, f.Name, f.Proto)
+			}
+			if numbers[f.Proto] {
+				return fmt.Errorf("%s.%s: field number %d used twice", t.Name, f.Name, f.Proto)
+			}
+			numbers[f.Proto] = true
+			for _, l := range append(f.Doc, f.Comment) {
+				if strings.Contains(l, "\n") {
+					return fmt.Errorf("%s.%s: comment lines must not contain line breaks", t.Name, f.Name)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// isVersion reports whether v is a version such as "1.0".
+func isVersion(v string) bool {
+	parts := strings.Split(v, ".")
+	if len(parts) != 2 {
+		return false
+	}
+	for _, p := range parts {
+		if p == "" || strings.Trim(p, "0123456789") != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// imports returns the packages used by field types.
+func (s Spec) imports() []string {
+	used := map[string]bool{}
+	for _, t := range s.Types {
+		for _, f := range t.Fields {
+			for q, p := range imports {
+				if strings.Contains(f.Type, q+".") {
+					used[p] = true
+				}
+			}
+		}
+	}
+	var pkgs []string
+	for p := range used {
+		pkgs = append(pkgs, p)
+	}
+	sort.Strings(pkgs)
+	return pkgs
+}
+
+func isExported(name string) bool {
+	if name == "" || name[0] < 'A' || name[0] > 'Z' {
+		return false
+	}
+	for _, r := range name {
+		if !(r == '_' || r >= '0' && r <= '9' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z') {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+const (
+	specFile = "master-product.spec.json"
+	rootDir  = "../../"
+)
+
+func TestGenerated(t *testing.T) {
+	spec, err := readSpec(rootDir + specFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	src, err := spec.golang(specFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	proto, err := spec.proto(specFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range []struct {
+		name string
+		data []byte
+	}{{"master-product.go", src}, {"master-product.proto", proto}} {
+		old, err := ioutil.ReadFile(rootDir + f.name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(old, f.data) {
+			t.Errorf("%s is not up to date, run go generate", f.name)
+		}
+	}
+}
+
+func TestSpecCheck(t *testing.T) {
+	field := func(name, typ, json string, proto int) Field {
+		return Field{Name: name, Type: typ, JSON: json, Proto: proto}
+	}
+	for _, tt := range []struct {
+		spec Spec
+		want string
+	}{
+		{Spec{DataFormatVersion: "1"}, `data format version "1": must be major.minor`},
+		{Spec{DataFormatVersion: "v1.0"}, `data format version "v1.0": must be major.minor`},
+		{Spec{Types: []Type{{Name: "x"}}}, `type "x": name must be an exported identifier`},
+		{Spec{Types: []Type{{Name: "X"}, {Name: "X"}}}, "type X: defined twice"},
+		{Spec{Types: []Type{{Name: "X", Fields: []Field{field("a", "string", "a", 1)}}}}, "X.a: name must be an exported identifier"},
+		{Spec{Types: []Type{{Name: "X", Fields: []Field{field("A", "string", "a", 1), field("A", "string", "b", 2)}}}}, "X.A: defined twice"},
+		{Spec{Types: []Type{{Name: "X", Fields: []Field{field("A", "[]", "a", 1)}}}}, `X.A: type "[]"`},
+		{Spec{Types: []Type{{Name: "X", Fields: []Field{field("A", "string", ",omitempty", 1)}}}}, `X.A: invalid json tag ",omitempty"`},
+		{Spec{Types: []Type{{Name: "X", Fields: []Field{field("A", "string", "a", 1), field("B", "string", "a,omitempty", 2)}}}}, `X.B: json name "a" used twice`},
+		{Spec{Types: []Type{{Name: "X", Fields: []Field{field("A", "string", "a", 19000)}}}}, "X.A: invalid field number 19000"},
+		{Spec{Types: []Type{{Name: "X", Fields: []Field{field("A", "string", "a", 1), field("B", "string", "b", 1)}}}}, "X.B: field number 1 used twice"},
+		{Spec{Types: []Type{{Name: "X", Fields: []Field{{Name: "A", Type: "string", JSON: "a", Proto: 1, Comment: "a\nb"}}}}}, "X.A: comment lines must not contain line breaks"},
+	} {
+		if err := tt.spec.check(); err == nil || !strings.HasPrefix(err.Error(), tt.want) {
+			t.Errorf("check() = %v, want %s", err, tt.want)
+		}
+	}
+}
+
+func TestGolangLocalized(t *testing.T) {
+	spec := Spec{Types: []Type{
+		{Name: "Description", Fields: []Field{
+			{Name: "Value", Type: "string", JSON: "$", Proto: 1},
+			{Name: "LanguageCode", Type: "string", JSON: "@languageCode,omitempty", Proto: 2},
+		}},
+		{Name: "Names", Slice: true, Fields: []Field{
+			{Name: "Name", Type: "string", JSON: "$", Proto: 1},
+			{Name: "Language", Type: "string", JSON: "@languageCode", Proto: 2},
+		}},
+		{Name: "Code", Fields: []Field{
+			{Name: "Value", Type: "string", JSON: "$", Proto: 1},
+			{Name: "CodeListVersion", Type: "string", JSON: "@codeListVersion", Proto: 2},
+		}},
+	}}
+	src, err := spec.golang("test.spec.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"func (t Description) LocalizedText() LocalizedText {\n\treturn LocalizedText{Value: t.Value, LanguageCode: t.LanguageCode}\n}",
+		"func DescriptionTexts(s []Description) []LocalizedText {",
+		"func (n Names) LocalizedTexts() []LocalizedText {",
+		"texts[i] = LocalizedText{Value: t.Name, LanguageCode: t.Language}",
+		"func NamesTexts(s []Names) []LocalizedText {",
+	} {
+		if !bytes.Contains(src, []byte(want)) {
+			t.Errorf("generated code has no %q:\n%s", want, src)
+		}
+	}
+	if bytes.Contains(src, []byte("Code) LocalizedText")) || bytes.Contains(src, []byte("CodeTexts")) {
+		t.Errorf("accessors generated for a type without language code:\n%s", src)
+	}
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// protoPackage is the package of the generated messages.
+const protoPackage = "foodiefm.structs"
+
+// maxFieldNumber is the largest Protocol Buffers field number.
+const maxFieldNumber = 1<<29 - 1
+
+// protoTypes are the Protocol Buffers types of the Go types which are not
+// defined by the spec. Optional fields keep the presence of Null types and
+// pointers. Decimals and times are kept as text, so that they read back
+// exactly as given.
+var protoTypes = map[string]string{
+	"string":           "string",
+	"bool":             "bool",
+	"int":              "int64",
+	"float64":          "double",
+	"Decimal":          "string",
+	"NullFloat64":      "optional double",
+	"NullInt":          "optional int64",
+	"NullBool":         "optional bool",
+	"time.Time":        "string",
+	"*json.RawMessage": "optional bytes",
+}
+
+// protoListTypes are the Go types which may be list elements in addition to
+// the struct types of the spec.
+var protoListTypes = map[string]bool{
+	"string": true,
+}
+
+// proto returns the Protocol Buffers messages of the spec. Every type is a
+// message with the same name. A type which is a list is a message with the
+// repeated field elements of the nested message Element, so that lists of
+// the type can be repeated. Fields of the type itself are repeated Element.
+func (spec Spec) proto(specFile string) ([]byte, error) {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// Code generated by structgen from %s. DO NOT EDIT.\n\n", specFile)
+	b.WriteString("syntax = \"proto3\";\n\n")
+	fmt.Fprintf(&b, "package %s;\n", protoPackage)
+	for _, t := range spec.Types {
+		b.WriteByte('\n')
+		writeComment(&b, t.Doc)
+		fmt.Fprintf(&b, "message %s {\n", t.Name)
+		indent := "  "
+		if t.Slice {
+			b.WriteString("  repeated Element elements = 1;\n\n  message Element {\n")
+			indent = "    "
+		}
+		names := map[string]bool{}
+		for _, f := range t.Fields {
+			typ, err := spec.protoType(f.Type)
+			if err != nil {
+				return nil, fmt.Errorf("%s.%s: %w", t.Name, f.Name, err)
+			}
+			name := snakeCase(f.Name)
+			if names[name] {
+				return nil, fmt.Errorf("%s.%s: field name %s used twice", t.Name, f.Name, name)
+			}
+			names[name] = true
+			for _, l := range f.Doc {
+				fmt.Fprintf(&b, "%s//%s\n", indent, l)
+			}
+			fmt.Fprintf(&b, "%s%s %s = %d;", indent, typ, name, f.Proto)
+			if f.Comment != "" {
+				fmt.Fprintf(&b, " //%s", f.Comment)
+			}
+			b.WriteByte('\n')
+		}
+		if t.Slice {
+			b.WriteString("  }\n")
+		}
+		b.WriteString("}\n")
+	}
+	return b.Bytes(), nil
+}
+
+// protoType returns the type of a field with the Go type typ.
+func (spec Spec) protoType(typ string) (string, error) {
+	if t, ok := protoTypes[typ]; ok {
+		return t, nil
+	}
+	if t, ok := spec.lookup(typ); ok {
+		if t.Slice {
+			return "repeated " + typ + ".Element", nil
+		}
+		return typ, nil
+	}
+	if elem := strings.TrimPrefix(typ, "[]"); elem != typ {
+		if protoListTypes[elem] {
+			return "repeated " + protoTypes[elem], nil
+		}
+		if _, ok := spec.lookup(elem); ok {
+			return "repeated " + elem, nil
+		}
+	}
+	return "", fmt.Errorf("no Protocol Buffers type for %s", typ)
+}
+
+func (spec Spec) lookup(name string) (Type, bool) {
+	for _, t := range spec.Types {
+		if t.Name == name {
+			return t, true
+		}
+	}
+	return Type{}, false
+}
+
+// snakeCase returns the field name of a Go name, for example ext_id of ExtID
+// and x_data_format_version of XDataFormatVersion.
+func snakeCase(name string) string {
+	r := []rune(name)
+	var b strings.Builder
+	for i, c := range r {
+		if i > 0 && unicode.IsUpper(c) {
+			prev := r[i-1]
+			next := i+1 < len(r) && unicode.IsLower(r[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || unicode.IsUpper(prev) && next {
+				b.WriteByte('_')
+			}
+		}
+		b.WriteRune(unicode.ToLower(c))
+	}
+	return b.String()
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+func TestProtoCompiles(t *testing.T) {
+	protoc, err := exec.LookPath("protoc")
+	if err != nil {
+		t.Skip("protoc not found")
+	}
+	spec, err := readSpec(rootDir + specFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	proto, err := spec.proto(specFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir, err := ioutil.TempDir("", "structgen")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	if err := ioutil.WriteFile(filepath.Join(dir, "master-product.proto"), proto, 0644); err != nil {
+		t.Fatal(err)
+	}
+	// Optional fields need protoc 3.15 or later.
+	cmd := exec.Command(protoc, "--proto_path="+dir,
+		"--descriptor_set_out="+filepath.Join(dir, "master-product.pb"), "master-product.proto")
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("protoc: %v\n%s", err, out)
+	}
+}
+
+func TestProtoType(t *testing.T) {
+	spec := Spec{Types: []Type{{Name: "Names", Slice: true}, {Name: "Code"}}}
+	for _, tt := range []struct {
+		typ, want string
+	}{
+		{"string", "string"},
+		{"Decimal", "string"},
+		{"NullInt", "optional int64"},
+		{"Code", "Code"},
+		{"*json.RawMessage", "optional bytes"},
+		{"[]Code", "repeated Code"},
+		{"[]string", "repeated string"},
+		{"Names", "repeated Names.Element"},
+		{"[]Names", "repeated Names"},
+	} {
+		if got, err := spec.protoType(tt.typ); err != nil || got != tt.want {
+			t.Errorf("protoType(%s) = %s, %v, want %s", tt.typ, got, err, tt.want)
+		}
+	}
+	for _, typ := range []string{"GTIN", "[]int", "map[string]string", "[][]Code"} {
+		if got, err := spec.protoType(typ); err == nil {
+			t.Errorf("protoType(%s) = %s, want error", typ, got)
+		}
+	}
+}
+
+func TestSnakeCase(t *testing.T) {
+	for name, want := range map[string]string{
+		"Gtin":               "gtin",
+		"GTIN":               "gtin",
+		"ExtID":              "ext_id",
+		"XDataFormatVersion": "x_data_format_version",
+		"TradeItemGTIN":      "trade_item_gtin",
+		"GTINList":           "gtin_list",
+		"Per100G":            "per100_g",
+	} {
+		if got := snakeCase(name); got != want {
+			t.Errorf("snakeCase(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
@@ -0,0 +1,427 @@
+package structs
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// JSON Patch operations.
+const (
+	PatchAdd     = "add"
+	PatchRemove  = "remove"
+	PatchReplace = "replace"
+	PatchMove    = "move"
+	PatchCopy    = "copy"
+	PatchTest    = "test"
+)
+
+var (
+	// ErrPatchOperation is returned for malformed patch operations.
+	ErrPatchOperation = errors.New("invalid patch operation")
+	// ErrPatchPath is returned when a patch operation refers to a location
+	// which does not exist.
+	ErrPatchPath = errors.New("patch path not found")
+	// ErrPatchTest is returned when a test operation fails.
+	ErrPatchTest = errors.New("patch test failed")
+)
+
+// PatchOperation is a RFC 6902 JSON Patch operation.
+type PatchOperation struct {
+	// Operation, for example PatchReplace.
+	Op string
+	// JSON pointer to the target location.
+	Path string
+	// JSON pointer to the source location of move and copy operations.
+	From string
+	// Value of add, replace and test operations as decoded JSON.
+	Value interface{}
+}
+
+// MarshalJSON implements json.Marshaler interface. The value member is
+// written for add, replace and test operations even when it is null.
+func (o PatchOperation) MarshalJSON() ([]byte, error) {
+	m := map[string]interface{}{"op": o.Op, "path": o.Path}
+	switch o.Op {
+	case PatchAdd, PatchReplace, PatchTest:
+		m["value"] = o.Value
+	case PatchMove, PatchCopy:
+		m["from"] = o.From
+	}
+	return json.Marshal(m)
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface. Numbers in values are
+// decoded as json.Number.
+func (o *PatchOperation) UnmarshalJSON(data []byte) error {
+	var m map[string]json.RawMessage
+	if err := json.Unmarshal(data, &m); err != nil {
+		return err
+	}
+	var op PatchOperation
+	for _, f := range []struct {
+		name string
+		dst  *string
+	}{{"op", &op.Op}, {"path", &op.Path}, {"from", &op.From}} {
+		if raw, ok := m[f.name]; ok {
+			if err := json.Unmarshal(raw, f.dst); err != nil {
+				return fmt.Errorf("%s: %w", f.name, err)
+			}
+		}
+	}
+	if raw, ok := m["value"]; ok {
+		v, err := decodeJSONTree(raw)
+		if err != nil {
+			return fmt.Errorf("value: %w", err)
+		}
+		op.Value = v
+	} else {
+		switch op.Op {
+		case PatchAdd, PatchReplace, PatchTest:
+			return fmt.Errorf("%s without value: %w", op.Op, ErrPatchOperation)
+		}
+	}
+	*o = op
+	return nil
+}
+
+// Patch is a RFC 6902 JSON Patch document.
+type Patch []PatchOperation
+
+// CreatePatch returns a patch which transforms old into new. The values are
+// typically MasterProductData, but any value encodable to JSON is accepted,
+// and []byte or json.RawMessage are taken as JSON documents. Paths use the
+// JSON names of the fields, for example /tradeItem/gtin or
+// /tradeItem/tradeItemInformation/extensions/tradeItemDescriptionModule/tradeItemDescriptionInformation/tradeItemDescription/0/$.
+// Keyed lists, as described in Diff, are patched element by element, moving
+// elements when their order changes. Numbers equal by value are not replaced.
+func CreatePatch(old, new interface{}) (Patch, error) {
+	a, err := jsonTree(old)
+	if err != nil {
+		return nil, err
+	}
+	b, err := jsonTree(new)
+	if err != nil {
+		return nil, err
+	}
+	var p Patch
+	patchJSON("", a, b, &p)
+	return p, nil
+}
+
+func patchJSON(path string, a, b interface{}, p *Patch) {
+	switch a := a.(type) {
+	case map[string]interface{}:
+		if b, ok := b.(map[string]interface{}); ok {
+			for _, k := range sortedKeys(a) {
+				if bv, ok := b[k]; ok {
+					patchJSON(JoinPointer(path, k), a[k], bv, p)
+				} else {
+					*p = append(*p, PatchOperation{Op: PatchRemove, Path: JoinPointer(path, k)})
+				}
+			}
+			for _, k := range sortedKeys(b) {
+				if _, ok := a[k]; !ok {
+					*p = append(*p, PatchOperation{Op: PatchAdd, Path: JoinPointer(path, k), Value: b[k]})
+				}
+			}
+			return
+		}
+	case []interface{}:
+		if b, ok := b.([]interface{}); ok {
+			patchList(path, a, b, p)
+			return
+		}
+	}
+	if !jsonEqual(a, b) {
+		*p = append(*p, PatchOperation{Op: PatchReplace, Path: path, Value: b})
+	}
+}
+
+func patchList(path string, a, b []interface{}, p *Patch) {
+	if jsonEqual(a, b) {
+		return
+	}
+	key, ok := listKey(a, b)
+	if !ok {
+		for i := len(a) - 1; i >= len(b); i-- {
+			*p = append(*p, PatchOperation{Op: PatchRemove, Path: JoinPointer(path, strconv.Itoa(i))})
+		}
+		for i, e := range b {
+			if i < len(a) {
+				patchJSON(JoinPointer(path, strconv.Itoa(i)), a[i], e, p)
+			} else {
+				*p = append(*p, PatchOperation{Op: PatchAdd, Path: JoinPointer(path, strconv.Itoa(i)), Value: e})
+			}
+		}
+		return
+	}
+	wanted := make(map[string]bool, len(b))
+	for _, e := range b {
+		wanted[keyValue(e, key)] = true
+	}
+	var current []interface{}
+	for i := len(a) - 1; i >= 0; i-- {
+		if !wanted[keyValue(a[i], key)] {
+			*p = append(*p, PatchOperation{Op: PatchRemove, Path: JoinPointer(path, strconv.Itoa(i))})
+		}
+	}
+	for _, e := range a {
+		if wanted[keyValue(e, key)] {
+			current = append(current, e)
+		}
+	}
+	for i, e := range b {
+		k := keyValue(e, key)
+		j := i
+		for j < len(current) && keyValue(current[j], key) != k {
+			j++
+		}
+		if j == len(current) {
+			*p = append(*p, PatchOperation{Op: PatchAdd, Path: JoinPointer(path, strconv.Itoa(i)), Value: e})
+			current = append(current[:i], append([]interface{}{e}, current[i:]...)...)
+			continue
+		}
+		if j != i {
+			*p = append(*p, PatchOperation{Op: PatchMove, From: JoinPointer(path, strconv.Itoa(j)), Path: JoinPointer(path, strconv.Itoa(i))})
+			moved := current[j]
+			current = append(current[:j], current[j+1:]...)
+			current = append(current[:i], append([]interface{}{moved}, current[i:]...)...)
+		}
+		patchJSON(JoinPointer(path, strconv.Itoa(i)), current[i], e, p)
+	}
+}
+
+// Apply applies the patch to a JSON document. The operations are applied in
+// order and the first failing operation stops the patching.
+func (p Patch) Apply(doc []byte) ([]byte, error) {
+	tree, err := decodeJSONTree(doc)
+	if err != nil {
+		return nil, err
+	}
+	if tree, err = p.applyTree(tree); err != nil {
+		return nil, err
+	}
+	return json.Marshal(tree)
+}
+
+// ApplyTo applies the patch to a product. The patched document must decode
+// to MasterProductData without unknown fields, so values of wrong type, for
+// example a string in place of a number, are rejected. The product is not
+// modified when an error is returned.
+func (p Patch) ApplyTo(d *MasterProductData) error {
+	tree, err := jsonTree(d)
+	if err != nil {
+		return err
+	}
+	if tree, err = p.applyTree(tree); err != nil {
+		return err
+	}
+	data, err := json.Marshal(tree)
+	if err != nil {
+		return err
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	var patched MasterProductData
+	if err := dec.Decode(&patched); err != nil {
+		return err
+	}
+	*d = patched
+	return nil
+}
+
+func (p Patch) applyTree(tree interface{}) (interface{}, error) {
+	for i, op := range p {
+		var err error
+		if tree, err = op.apply(tree); err != nil {
+			return nil, fmt.Errorf("operation %d: %s %s: %w", i, op.Op, op.Path, err)
+		}
+	}
+	return tree, nil
+}
+
+func (o PatchOperation) apply(doc interface{}) (interface{}, error) {
+	path, ok := SplitPointer(o.Path)
+	if !ok {
+		return nil, fmt.Errorf("path %q: %w", o.Path, ErrPatchOperation)
+	}
+	switch o.Op {
+	case PatchAdd:
+		return addJSON(doc, path, copyJSON(o.Value))
+	case PatchRemove:
+		doc, _, err := removeJSON(doc, path)
+		return doc, err
+	case PatchReplace:
+		if _, err := getJSON(doc, path); err != nil {
+			return nil, err
+		}
+		if len(path) == 0 {
+			return copyJSON(o.Value), nil
+		}
+		doc, _, err := removeJSON(doc, path)
+		if err != nil {
+			return nil, err
+		}
+		return addJSON(doc, path, copyJSON(o.Value))
+	case PatchMove, PatchCopy:
+		from, ok := SplitPointer(o.From)
+		if !ok {
+			return nil, fmt.Errorf("from %q: %w", o.From, ErrPatchOperation)
+		}
+		v, err := getJSON(doc, from)
+		if err != nil {
+			return nil, err
+		}
+		if o.Op == PatchCopy {
+			return addJSON(doc, path, copyJSON(v))
+		}
+		if len(from) < len(path) && o.From == JoinPointer("", path[:len(from)]...) {
+			return nil, fmt.Errorf("moving %s into itself: %w", o.From, ErrPatchOperation)
+		}
+		if doc, _, err = removeJSON(doc, from); err != nil {
+			return nil, err
+		}
+		return addJSON(doc, path, v)
+	case PatchTest:
+		v, err := getJSON(doc, path)
+		if err != nil {
+			return nil, err
+		}
+		if !jsonEqual(v, o.Value) {
+			return nil, ErrPatchTest
+		}
+		return doc, nil
+	}
+	return nil, fmt.Errorf("op %q: %w", o.Op, ErrPatchOperation)
+}
+
+// listIndex parses an array index token. The index may equal length only
+// when end is true, and "-" refers to the end of the list.
+func listIndex(token string, length int, end bool) (int, error) {
+	if token == "-" && end {
+		return length, nil
+	}
+	i, err := strconv.Atoi(token)
+	if err != nil || i < 0 || (len(token) > 1 && token[0] == '0') || i > length || (i == length && !end) {
+		return 0, fmt.Errorf("index %q: %w", token, ErrPatchPath)
+	}
+	return i, nil
+}
+
+func getJSON(doc interface{}, path []string) (interface{}, error) {
+	for _, t := range path {
+		switch node := doc.(type) {
+		case map[string]interface{}:
+			v, ok := node[t]
+			if !ok {
+				return nil, fmt.Errorf("member %q: %w", t, ErrPatchPath)
+			}
+			doc = v
+		case []interface{}:
+			i, err := listIndex(t, len(node), false)
+			if err != nil {
+				return nil, err
+			}
+			doc = node[i]
+		default:
+			return nil, fmt.Errorf("%q of a scalar: %w", t, ErrPatchPath)
+		}
+	}
+	return doc, nil
+}
+
+// updateJSON calls f with the parent of the location and the last token, and
+// stores the parent f returns in place of the old one.
+func updateJSON(doc interface{}, path []string, f func(parent interface{}, token string) (interface{}, error)) (interface{}, error) {
+	if len(path) == 1 {
+		return f(doc, path[0])
+	}
+	child, err := getJSON(doc, path[:1])
+	if err != nil {
+		return nil, err
+	}
+	if child, err = updateJSON(child, path[1:], f); err != nil {
+		return nil, err
+	}
+	switch node := doc.(type) {
+	case map[string]interface{}:
+		node[path[0]] = child
+	case []interface{}:
+		i, _ := listIndex(path[0], len(node), false)
+		node[i] = child
+	}
+	return doc, nil
+}
+
+func addJSON(doc interface{}, path []string, value interface{}) (interface{}, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+	return updateJSON(doc, path, func(parent interface{}, token string) (interface{}, error) {
+		switch node := parent.(type) {
+		case map[string]interface{}:
+			node[token] = value
+			return node, nil
+		case []interface{}:
+			i, err := listIndex(token, len(node), true)
+			if err != nil {
+				return nil, err
+			}
+			node = append(node, nil)
+			copy(node[i+1:], node[i:])
+			node[i] = value
+			return node, nil
+		}
+		return nil, fmt.Errorf("%q of a scalar: %w", token, ErrPatchPath)
+	})
+}
+
+func removeJSON(doc interface{}, path []string) (interface{}, interface{}, error) {
+	if len(path) == 0 {
+		return nil, nil, fmt.Errorf("removing the document: %w", ErrPatchOperation)
+	}
+	var removed interface{}
+	doc, err := updateJSON(doc, path, func(parent interface{}, token string) (interface{}, error) {
+		switch node := parent.(type) {
+		case map[string]interface{}:
+			v, ok := node[token]
+			if !ok {
+				return nil, fmt.Errorf("member %q: %w", token, ErrPatchPath)
+			}
+			removed = v
+			delete(node, token)
+			return node, nil
+		case []interface{}:
+			i, err := listIndex(token, len(node), false)
+			if err != nil {
+				return nil, err
+			}
+			removed = node[i]
+			return append(node[:i:i], node[i+1:]...), nil
+		}
+		return nil, fmt.Errorf("%q of a scalar: %w", token, ErrPatchPath)
+	})
+	return doc, removed, err
+}
+
+// copyJSON returns a deep copy of decoded JSON.
+func copyJSON(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		res := make(map[string]interface{}, len(v))
+		for k, m := range v {
+			res[k] = copyJSON(m)
+		}
+		return res
+	case []interface{}:
+		res := make([]interface{}, len(v))
+		for i, m := range v {
+			res[i] = copyJSON(m)
+		}
+		return res
+	}
+	return v
+}
@@ -0,0 +1,158 @@
+package structs
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func decodePatch(t *testing.T, data string) Patch {
+	t.Helper()
+	var p Patch
+	if err := json.Unmarshal([]byte(data), &p); err != nil {
+		t.Fatalf("Unmarshal(%s): %v", data, err)
+	}
+	return p
+}
+
+func TestPatchApply(t *testing.T) {
+	// Examples of RFC 6902 appendix A.
+	for _, tt := range []struct {
+		name, doc, patch, want string
+	}{
+		{"add member", `{"foo": "bar"}`, `[{"op": "add", "path": "/baz", "value": "qux"}]`, `{"baz": "qux", "foo": "bar"}`},
+		{"add element", `{"foo": ["bar", "baz"]}`, `[{"op": "add", "path": "/foo/1", "value": "qux"}]`, `{"foo": ["bar", "qux", "baz"]}`},
+		{"append", `{"foo": ["bar"]}`, `[{"op": "add", "path": "/foo/-", "value": ["abc", "def"]}]`, `{"foo": ["bar", ["abc", "def"]]}`},
+		{"remove element", `{"foo": ["bar", "qux", "baz"]}`, `[{"op": "remove", "path": "/foo/1"}]`, `{"foo": ["bar", "baz"]}`},
+		{"replace", `{"baz": "qux", "foo": "bar"}`, `[{"op": "replace", "path": "/baz", "value": "boo"}]`, `{"baz": "boo", "foo": "bar"}`},
+		{"move member", `{"foo": {"bar": "baz", "waldo": "fred"}, "qux": {"corge": "grault"}}`,
+			`[{"op": "move", "from": "/foo/waldo", "path": "/qux/thud"}]`,
+			`{"foo": {"bar": "baz"}, "qux": {"corge": "grault", "thud": "fred"}}`},
+		{"move element", `{"foo": ["all", "grass", "cows", "eat"]}`, `[{"op": "move", "from": "/foo/1", "path": "/foo/3"}]`,
+			`{"foo": ["all", "cows", "eat", "grass"]}`},
+		{"copy", `{"a": {"b": 1}}`, `[{"op": "copy", "from": "/a", "path": "/c"}, {"op": "replace", "path": "/c/b", "value": 2}]`,
+			`{"a": {"b": 1}, "c": {"b": 2}}`},
+		{"test", `{"baz": "qux", "foo": ["a", 2, "c"]}`,
+			`[{"op": "test", "path": "/baz", "value": "qux"}, {"op": "test", "path": "/foo/1", "value": 2.0}]`,
+			`{"baz": "qux", "foo": ["a", 2, "c"]}`},
+		{"escaped", `{"/": 9, "~1": 10}`, `[{"op": "test", "path": "/~01", "value": 10}, {"op": "remove", "path": "/~1"}]`, `{"~1": 10}`},
+		{"null value", `{"a": 1}`, `[{"op": "replace", "path": "/a", "value": null}]`, `{"a": null}`},
+		{"whole document", `{"a": 1}`, `[{"op": "replace", "path": "", "value": [1]}]`, `[1]`},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := decodePatch(t, tt.patch).Apply([]byte(tt.doc))
+			if err != nil {
+				t.Fatal(err)
+			}
+			assertJSON(t, got, tt.want)
+		})
+	}
+}
+
+func TestPatchApplyErrors(t *testing.T) {
+	for _, tt := range []struct {
+		doc, patch string
+		err        error
+	}{
+		{`{"foo": "bar"}`, `[{"op": "test", "path": "/foo", "value": "baz"}]`, ErrPatchTest},
+		{`{"foo": "bar"}`, `[{"op": "remove", "path": "/baz"}]`, ErrPatchPath},
+		{`{"foo": "bar"}`, `[{"op": "add", "path": "/baz/bat", "value": "qux"}]`, ErrPatchPath},
+		{`{"foo": [1]}`, `[{"op": "add", "path": "/foo/2", "value": 2}]`, ErrPatchPath},
+		{`{"foo": [1]}`, `[{"op": "replace", "path": "/foo/01", "value": 2}]`, ErrPatchPath},
+		{`{"foo": [1]}`, `[{"op": "replace", "path": "/foo/-", "value": 2}]`, ErrPatchPath},
+		{`{"foo": {"bar": 1}}`, `[{"op": "move", "from": "/foo", "path": "/foo/bar/baz"}]`, ErrPatchOperation},
+		{`{"foo": "bar"}`, `[{"op": "remove", "path": "foo"}]`, ErrPatchOperation},
+		{`{"foo": "bar"}`, `[{"op": "invert", "path": "/foo"}]`, ErrPatchOperation},
+	} {
+		if _, err := decodePatch(t, tt.patch).Apply([]byte(tt.doc)); !errors.Is(err, tt.err) {
+			t.Errorf("%s: Apply() error = %v, want %v", tt.patch, err, tt.err)
+		}
+	}
+	var p Patch
+	if err := json.Unmarshal([]byte(`[{"op": "add", "path": "/a"}]`), &p); !errors.Is(err, ErrPatchOperation) {
+		t.Errorf("add without value error = %v", err)
+	}
+}
+
+func TestPatchJSON(t *testing.T) {
+	p := Patch{
+		{Op: PatchAdd, Path: "/a", Value: nil},
+		{Op: PatchRemove, Path: "/b", Value: "ignored"},
+		{Op: PatchMove, From: "/c", Path: "/d"},
+	}
+	data, err := json.Marshal(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertJSON(t, data, `[{"op": "add", "path": "/a", "value": null}, {"op": "remove", "path": "/b"}, {"op": "move", "from": "/c", "path": "/d"}]`)
+}
+
+func TestCreatePatch(t *testing.T) {
+	for _, tt := range []struct {
+		name, old, new string
+		ops            int
+	}{
+		{"equal", `{"a": 1.0, "b": [1, 2]}`, `{"a": 1, "b": [1, 2]}`, 0},
+		{"members", `{"a": 1, "b": {"c": "x"}}`, `{"b": {"c": "y", "d": []}, "e": null}`, 4},
+		{"list", `{"a": [1, 2, 3, 4]}`, `{"a": [4, 2]}`, 3},
+		{"keyed list", `{"n": [
+			{"nutrientTypeCode": "FAT", "v": 1},
+			{"nutrientTypeCode": "PRO-", "v": 2},
+			{"nutrientTypeCode": "SALTEQ", "v": 3}
+		]}`, `{"n": [
+			{"nutrientTypeCode": "SUGAR-", "v": 4},
+			{"nutrientTypeCode": "PRO-", "v": 2},
+			{"nutrientTypeCode": "FAT", "v": 5}
+		]}`, 4},
+		{"type change", `{"a": [1]}`, `{"a": "1"}`, 1},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := CreatePatch([]byte(tt.old), []byte(tt.new))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(p) != tt.ops {
+				data, _ := json.Marshal(p)
+				t.Errorf("CreatePatch() = %s, want %d operations", data, tt.ops)
+			}
+			got, err := p.Apply([]byte(tt.old))
+			if err != nil {
+				t.Fatal(err)
+			}
+			// Numbers equal by value are not replaced, so compare by value.
+			g, _ := decodeJSONTree(got)
+			w, _ := decodeJSONTree([]byte(tt.new))
+			if !jsonEqual(g, w) {
+				t.Errorf("patched document %s, want %s", got, tt.new)
+			}
+		})
+	}
+}
+
+func TestPatchApplyTo(t *testing.T) {
+	var old, new MasterProductData
+	old.Gtin = "4006381333931"
+	new.Gtin = "96385074"
+	new.TradeItem.TradeItemInformation.Extension.FoodAndBeverageIngredientModule.JuiceContentPercent = ValidFloat64(12.5)
+	p, err := CreatePatch(old, new)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d := old
+	if err := p.ApplyTo(&d); err != nil {
+		t.Fatal(err)
+	}
+	if d.Gtin != new.Gtin || d.TradeItem.TradeItemInformation.Extension.FoodAndBeverageIngredientModule.JuiceContentPercent != ValidFloat64(12.5) {
+		t.Errorf("ApplyTo() = %+v", d)
+	}
+
+	for _, patch := range []string{
+		`[{"op": "replace", "path": "/gtin", "value": 1}]`,
+		`[{"op": "add", "path": "/x_unknown", "value": 1}]`,
+	} {
+		d := old
+		if err := decodePatch(t, patch).ApplyTo(&d); err == nil || d.Gtin != old.Gtin {
+			t.Errorf("%s: ApplyTo() = %v, gtin %s", patch, err, d.Gtin)
+		}
+	}
+}
@@ -0,0 +1,37 @@
+package structs
+
+import "strings"
+
+var (
+	pointerEscaper   = strings.NewReplacer("~", "~0", "/", "~1")
+	pointerUnescaper = strings.NewReplacer("~1", "/", "~0", "~")
+)
+
+// JoinPointer appends reference tokens to a RFC 6901 JSON pointer. Tokens
+// are object keys or array indexes formatted with strconv.Itoa, they are
+// escaped.
+func JoinPointer(pointer string, tokens ...string) string {
+	var b strings.Builder
+	b.WriteString(pointer)
+	for _, t := range tokens {
+		b.WriteByte('/')
+		b.WriteString(pointerEscaper.Replace(t))
+	}
+	return b.String()
+}
+
+// SplitPointer splits a RFC 6901 JSON pointer to unescaped reference tokens.
+// The empty pointer refers to the whole document and has no tokens.
+func SplitPointer(pointer string) ([]string, bool) {
+	if pointer == "" {
+		return nil, true
+	}
+	if pointer[0] != '/' {
+		return nil, false
+	}
+	tokens := strings.Split(pointer[1:], "/")
+	for i, t := range tokens {
+		tokens[i] = pointerUnescaper.Replace(t)
+	}
+	return tokens, true
+}
@@ -0,0 +1,45 @@
+package structs
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestJoinPointer(t *testing.T) {
+	for _, tt := range []struct {
+		pointer string
+		tokens  []string
+		want    string
+	}{
+		{"", nil, ""},
+		{"", []string{"tradeItem", "gtin"}, "/tradeItem/gtin"},
+		{"/tradeItem", []string{"referencedTradeItem", "0", "gtin"}, "/tradeItem/referencedTradeItem/0/gtin"},
+		{"/a", []string{"b/c", "d~e", ""}, "/a/b~1c/d~0e/"},
+	} {
+		if got := JoinPointer(tt.pointer, tt.tokens...); got != tt.want {
+			t.Errorf("JoinPointer(%q, %q) = %q, want %q", tt.pointer, tt.tokens, got, tt.want)
+		}
+	}
+}
+
+func TestSplitPointer(t *testing.T) {
+	for _, tt := range []struct {
+		pointer string
+		tokens  []string
+		ok      bool
+	}{
+		{"", nil, true},
+		{"/", []string{""}, true},
+		{"/tradeItem/gtin", []string{"tradeItem", "gtin"}, true},
+		{"/a/b~1c/d~0e/~01", []string{"a", "b/c", "d~e", "~1"}, true},
+		{"tradeItem", nil, false},
+	} {
+		tokens, ok := SplitPointer(tt.pointer)
+		if ok != tt.ok || !reflect.DeepEqual(tokens, tt.tokens) {
+			t.Errorf("SplitPointer(%q) = %q, %v, want %q, %v", tt.pointer, tokens, ok, tt.tokens, tt.ok)
+		}
+		if ok && JoinPointer("", tokens...) != tt.pointer {
+			t.Errorf("JoinPointer(SplitPointer(%q)) = %q", tt.pointer, JoinPointer("", tokens...))
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package structs
+
+import "strings"
+
+// LocalizedText is a text in a given language. It is the common form of all
+// the text and language code pairs of the product, such as TradeItemDescription,
+// FunctionalName, IngredientStatement or GDSNMediaName.
+type LocalizedText struct {
+	Value        string
+	LanguageCode string
+}
+
+// Localized is implemented by the text and language code pairs of the
+// product. Slices of them are converted with the generated functions named
+// after the element type, for example TradeItemDescriptionTexts or
+// IngredientStatementTexts.
+type Localized interface {
+	LocalizedText() LocalizedText
+}
+
+// LocalizedText returns t.
+func (t LocalizedText) LocalizedText() LocalizedText {
+	return t
+}
+
+// LanguagePreference is an ordered list of preferred language codes, for
+// example fi-FI, fi, sv, en. Language codes are compared case insensitively
+// and "_" is accepted in place of "-".
+type LanguagePreference []string
+
+// normalizeLanguageCode returns the code in lower case with "-" as separator.
+func normalizeLanguageCode(code string) string {
+	return strings.ToLower(strings.Replace(strings.TrimSpace(code), "_", "-", -1))
+}
+
+// baseLanguage returns the language without region, for example fi for fi-FI.
+func baseLanguage(code string) string {
+	if i := strings.IndexByte(code, '-'); i >= 0 {
+		return code[:i]
+	}
+	return code
+}
+
+// Resolve returns the best matching non-empty text. Preferences are tried in
+// order. A preference matches texts with the same language code and, when the
+// preference has no region, texts of the same language in any region: fi
+// matches fi-FI. When no preference matches this way, the base languages of
+// the preferences are tried in order: fi-FI matches fi and fi-AX. The returned
+// text carries the language code that was chosen. False is returned when
+// no text matches any preference.
+func (p LanguagePreference) Resolve(texts []LocalizedText) (LocalizedText, bool) {
+	find := func(match func(code string) bool) (LocalizedText, bool) {
+		for _, t := range texts {
+			if strings.TrimSpace(t.Value) != "" && match(normalizeLanguageCode(t.LanguageCode)) {
+				return t, true
+			}
+		}
+		return LocalizedText{}, false
+	}
+	for _, pref := range p {
+		pref = normalizeLanguageCode(pref)
+		if t, ok := find(func(code string) bool {
+			return code == pref || (baseLanguage(pref) == pref && baseLanguage(code) == pref)
+		}); ok {
+			return t, true
+		}
+	}
+	for _, pref := range p {
+		base := baseLanguage(normalizeLanguageCode(pref))
+		if t, ok := find(func(code string) bool { return baseLanguage(code) == base }); ok {
+			return t, true
+		}
+	}
+	return LocalizedText{}, false
+}
+
+// ResolveString returns the value of the best matching text, or an empty
+// string when no text matches, for example:
+//
+//	pref := structs.LanguagePreference{"fi-FI", "fi", "sv", "en"}
+//	desc := pref.ResolveString(structs.TradeItemDescriptionTexts(info.TradeItemDescriptions))
+func (p LanguagePreference) ResolveString(texts []LocalizedText) string {
+	t, _ := p.Resolve(texts)
+	return t.Value
+}
+
+// Languages returns the distinct language codes of the texts in order of appearance.
+func Languages(texts []LocalizedText) []string {
+	var langs []string
+	seen := map[string]bool{}
+	for _, t := range texts {
+		if !seen[t.LanguageCode] {
+			seen[t.LanguageCode] = true
+			langs = append(langs, t.LanguageCode)
+		}
+	}
+	return langs
+}
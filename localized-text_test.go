@@ -0,0 +1,71 @@
+package structs
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLocalizedTexts(t *testing.T) {
+	descs := []TradeItemDescription{{Description: "Maito", LanguageCode: "fi"}, {Description: "Mjölk", LanguageCode: "sv"}}
+	want := []LocalizedText{{Value: "Maito", LanguageCode: "fi"}, {Value: "Mjölk", LanguageCode: "sv"}}
+	if got := TradeItemDescriptionTexts(descs); !reflect.DeepEqual(got, want) {
+		t.Errorf("TradeItemDescriptionTexts() = %+v, want %+v", got, want)
+	}
+	if got := TradeItemDescriptionTexts(nil); len(got) != 0 {
+		t.Errorf("TradeItemDescriptionTexts(nil) = %+v", got)
+	}
+
+	names := []IngredientName{
+		{{Name: "vehnäjauho", LanguageCode: "fi"}},
+		{{Name: "vetemjöl", LanguageCode: "sv"}, {Name: "wheat flour", LanguageCode: "en"}},
+	}
+	if got := IngredientNameTexts(names); len(got) != 3 || got[2] != (LocalizedText{Value: "wheat flour", LanguageCode: "en"}) {
+		t.Errorf("IngredientNameTexts() = %+v", got)
+	}
+
+	for _, l := range []Localized{
+		FunctionalName{Name: "Maito", LanguageCode: "fi"},
+		LocalizedText{Value: "Maito", LanguageCode: "fi"},
+	} {
+		if got := l.LocalizedText(); got != (LocalizedText{Value: "Maito", LanguageCode: "fi"}) {
+			t.Errorf("%T.LocalizedText() = %+v", l, got)
+		}
+	}
+}
+
+func TestLanguagePreferenceResolve(t *testing.T) {
+	texts := []LocalizedText{
+		{Value: "Milk", LanguageCode: "en"},
+		{Value: " ", LanguageCode: "fi"},
+		{Value: "Maito", LanguageCode: "fi-FI"},
+		{Value: "Mjölk", LanguageCode: "sv_AX"},
+	}
+	for _, tt := range []struct {
+		pref LanguagePreference
+		want string
+		ok   bool
+	}{
+		{LanguagePreference{"fi"}, "Maito", true},
+		{LanguagePreference{"FI_fi"}, "Maito", true},
+		{LanguagePreference{"sv-FI", "en"}, "Milk", true},
+		{LanguagePreference{"sv-FI", "de"}, "Mjölk", true},
+		{LanguagePreference{"de", "en"}, "Milk", true},
+		{LanguagePreference{"de"}, "", false},
+		{nil, "", false},
+	} {
+		got, ok := tt.pref.Resolve(texts)
+		if got.Value != tt.want || ok != tt.ok {
+			t.Errorf("%v.Resolve() = %+v, %v, want %q, %v", tt.pref, got, ok, tt.want, tt.ok)
+		}
+		if s := tt.pref.ResolveString(texts); s != tt.want {
+			t.Errorf("%v.ResolveString() = %q, want %q", tt.pref, s, tt.want)
+		}
+	}
+}
+
+func TestLanguages(t *testing.T) {
+	texts := []LocalizedText{{LanguageCode: "fi"}, {LanguageCode: "sv"}, {LanguageCode: "fi"}, {LanguageCode: ""}}
+	if got := Languages(texts); !reflect.DeepEqual(got, []string{"fi", "sv", ""}) {
+		t.Errorf("Languages() = %q", got)
+	}
+}
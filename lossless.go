@@ -0,0 +1,277 @@
+package structs
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// ProductDocument is a MasterProductData which remembers the JSON document it
+// was decoded from. When encoded back to JSON, fields absent from the original
+// document stay absent unless they have been given a non-zero value or a valid
+// Null value, such as ValidBool(false), while fields present in the original
+// document are always written, including explicit zeros and nulls. Fields
+// unknown to MasterProductData are preserved as well. List elements are matched
+// with the original by index as long as the length of the list is unchanged.
+// When elements have been added or removed, the list is encoded as new.
+// This makes read-modify-write flows against the Digital Goodie API safe:
+//
+//	var doc structs.ProductDocument
+//	err := json.Unmarshal(data, &doc)
+//	doc.Name = "New name"
+//	data, err = json.Marshal(doc)
+//
+// A zero ProductDocument, which has not been decoded, is encoded without any
+// zero valued fields other than valid Null values.
+type ProductDocument struct {
+	MasterProductData
+	original interface{}
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface.
+func (d *ProductDocument) UnmarshalJSON(data []byte) error {
+	original, err := decodeJSONTree(data)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, &d.MasterProductData); err != nil {
+		return err
+	}
+	d.original = original
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler interface.
+func (d ProductDocument) MarshalJSON() ([]byte, error) {
+	return MarshalPreserving(d.original, d.MasterProductData)
+}
+
+// MarshalPreserving encodes v to JSON preserving the presence of fields in
+// original, which is either a decoded JSON document or raw JSON given as
+// []byte or json.RawMessage. See ProductDocument for the rules.
+func MarshalPreserving(original interface{}, v interface{}) ([]byte, error) {
+	switch raw := original.(type) {
+	case []byte:
+		tree, err := decodeJSONTree(raw)
+		if err != nil {
+			return nil, err
+		}
+		original = tree
+	case json.RawMessage:
+		tree, err := decodeJSONTree(raw)
+		if err != nil {
+			return nil, err
+		}
+		original = tree
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	encoded, err := decodeJSONTree(data)
+	if err != nil {
+		return nil, err
+	}
+	given := givenValues{}
+	given.collect(reflect.ValueOf(v), "")
+	if original == nil {
+		return json.Marshal(given.prune(encoded, ""))
+	}
+	return json.Marshal(given.merge(original, encoded, ""))
+}
+
+// decodeJSONTree decodes JSON to maps, slices and scalars. Numbers are kept as
+// json.Number to preserve their exact representation.
+func decodeJSONTree(data []byte) (interface{}, error) {
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.UseNumber()
+	var tree interface{}
+	if err := d.Decode(&tree); err != nil {
+		return nil, err
+	}
+	return tree, nil
+}
+
+// zeroTime is the encoding of zero time.Time.
+const zeroTime = "0001-01-01T00:00:00Z"
+
+// isZeroJSON reports whether the decoded JSON value is what Go zero values
+// encode to.
+func isZeroJSON(v interface{}) bool {
+	switch v := v.(type) {
+	case nil:
+		return true
+	case bool:
+		return !v
+	case string:
+		return v == "" || v == zeroTime
+	case json.Number:
+		f, err := v.Float64()
+		return err == nil && f == 0
+	case []interface{}:
+		return len(v) == 0
+	case map[string]interface{}:
+		for _, m := range v {
+			if !isZeroJSON(m) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// givenValues is the set of JSON pointers to the valid Null values of an
+// encoded value. They are present even when their value is zero.
+type givenValues map[string]bool
+
+var (
+	nullFloat64Type = reflect.TypeOf(NullFloat64{})
+	nullIntType     = reflect.TypeOf(NullInt{})
+	nullBoolType    = reflect.TypeOf(NullBool{})
+)
+
+// collect adds the pointers to the valid Null values of v at path.
+func (g givenValues) collect(v reflect.Value, path string) {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if !v.IsNil() {
+			g.collect(v.Elem(), path)
+		}
+	case reflect.Slice, reflect.Array:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			// Raw JSON and bytes hold no Null values.
+			return
+		}
+		for i := 0; i < v.Len(); i++ {
+			g.collect(v.Index(i), JoinPointer(path, strconv.Itoa(i)))
+		}
+	case reflect.Struct:
+		switch v.Type() {
+		case nullFloat64Type, nullIntType, nullBoolType:
+			if v.FieldByName("Valid").Bool() {
+				g[path] = true
+			}
+			return
+		}
+		for i := 0; i < v.NumField(); i++ {
+			f := v.Type().Field(i)
+			if f.PkgPath != "" {
+				continue
+			}
+			name := strings.Split(f.Tag.Get("json"), ",")[0]
+			switch {
+			case name == "-":
+			case name == "" && f.Anonymous:
+				g.collect(v.Field(i), path)
+			case name == "":
+				g.collect(v.Field(i), JoinPointer(path, f.Name))
+			default:
+				g.collect(v.Field(i), JoinPointer(path, name))
+			}
+		}
+	}
+}
+
+// omit reports whether the encoded value v at path is left out when it is not
+// in the original document: zero values without valid Null values.
+func (g givenValues) omit(v interface{}, path string) bool {
+	if !isZeroJSON(v) {
+		return false
+	}
+	for p := range g {
+		if p == path || strings.HasPrefix(p, path+"/") {
+			return false
+		}
+	}
+	return true
+}
+
+// prune removes the omitted object members of v at path recursively.
+func (g givenValues) prune(v interface{}, path string) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		res := make(map[string]interface{}, len(v))
+		for k, m := range v {
+			p := JoinPointer(path, k)
+			if !g.omit(m, p) {
+				res[k] = g.prune(m, p)
+			}
+		}
+		return res
+	case []interface{}:
+		res := make([]interface{}, len(v))
+		for i, m := range v {
+			res[i] = g.prune(m, JoinPointer(path, strconv.Itoa(i)))
+		}
+		return res
+	}
+	return v
+}
+
+// merge returns encoded restricted to the members present in original, plus
+// members which are not omitted from encoded. Members of original unknown to
+// encoded are kept.
+func (g givenValues) merge(original, encoded interface{}, path string) interface{} {
+	switch enc := encoded.(type) {
+	case map[string]interface{}:
+		orig, ok := original.(map[string]interface{})
+		if !ok {
+			if original == nil && g.omit(enc, path) {
+				return nil
+			}
+			return g.prune(enc, path)
+		}
+		res := make(map[string]interface{}, len(enc))
+		for k, o := range orig {
+			if e, ok := enc[k]; ok {
+				res[k] = g.merge(o, e, JoinPointer(path, k))
+			} else {
+				res[k] = o
+			}
+		}
+		for k, e := range enc {
+			p := JoinPointer(path, k)
+			if _, ok := orig[k]; !ok && !g.omit(e, p) {
+				res[k] = g.prune(e, p)
+			}
+		}
+		return res
+	case []interface{}:
+		orig, ok := original.([]interface{})
+		if !ok {
+			if original == nil && len(enc) == 0 {
+				return nil
+			}
+			return g.prune(enc, path)
+		}
+		// Elements have no identity, so an element can only be matched with
+		// the original element at the same index when none have been added
+		// or removed. Otherwise unknown members would move to a sibling.
+		if len(orig) != len(enc) {
+			return g.prune(enc, path)
+		}
+		res := make([]interface{}, len(enc))
+		for i, e := range enc {
+			res[i] = g.merge(orig[i], e, JoinPointer(path, strconv.Itoa(i)))
+		}
+		return res
+	case json.Number:
+		// Keep the original representation, for example 1.50, when the value
+		// has not changed.
+		if orig, ok := original.(json.Number); ok {
+			a, errA := orig.Float64()
+			b, errB := enc.Float64()
+			if errA == nil && errB == nil && a == b {
+				return orig
+			}
+		}
+	}
+	// An explicit null decodes to a zero value, keep it null.
+	if original == nil && g.omit(encoded, path) {
+		return nil
+	}
+	return encoded
+}
@@ -0,0 +1,126 @@
+package structs
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+// assertJSON fails the test when the JSON documents got and want differ.
+func assertJSON(t *testing.T, got []byte, want string) {
+	t.Helper()
+	g, err := decodeJSONTree(got)
+	if err != nil {
+		t.Fatalf("invalid JSON %s: %v", got, err)
+	}
+	w, err := decodeJSONTree([]byte(want))
+	if err != nil {
+		t.Fatalf("invalid JSON %s: %v", want, err)
+	}
+	if !reflect.DeepEqual(g, w) {
+		t.Errorf("got JSON %s, want %s", got, want)
+	}
+}
+
+func decodeDocument(t *testing.T, data string) ProductDocument {
+	t.Helper()
+	var doc ProductDocument
+	if err := json.Unmarshal([]byte(data), &doc); err != nil {
+		t.Fatal(err)
+	}
+	return doc
+}
+
+func TestProductDocumentUnchanged(t *testing.T) {
+	const data = `{
+		"id": "",
+		"gtin": "4006381333931",
+		"x_unknown": {"a": [1, 2]},
+		"tradeItem": {
+			"tradeItemInformation": {"extensions": {
+				"foodAndBeverageIngredientModule": {"juiceContentPercent": 1.50, "x_isFoodOrBeverage": null},
+				"marketingInformationModule": {"marketingInformation": {"x_hideTradeItemFromPromotions": false}}
+			}}
+		}
+	}`
+	doc := decodeDocument(t, data)
+	got, err := json.Marshal(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertJSON(t, got, data)
+}
+
+func TestProductDocumentChanged(t *testing.T) {
+	doc := decodeDocument(t, `{"gtin": "4006381333931", "name": "Milk", "x_unknown": true}`)
+	doc.Name = ""
+	doc.Gtin = "96385074"
+	doc.TradeItem.TargetMarkets.TargetMarketCountryCode = "246"
+	got, err := json.Marshal(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertJSON(t, got, `{"gtin": "96385074", "name": "", "x_unknown": true, "tradeItem": {"targetMarket": {"targetMarketCountryCode": "246"}}}`)
+}
+
+func TestProductDocumentValidNullZero(t *testing.T) {
+	doc := decodeDocument(t, `{"gtin": "4006381333931"}`)
+	ingredients := &doc.TradeItem.TradeItemInformation.Extension.FoodAndBeverageIngredientModule
+	ingredients.JuiceContentPercent = ValidFloat64(0)
+	ingredients.XIsFoodOrBeverage = ValidBool(false)
+	doc.TradeItem.TradeItemInformation.Extension.TradeItemLifespanModule.TradeItemLifespan.OpenedTradeItemLifespan = ValidInt(0)
+	got, err := json.Marshal(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertJSON(t, got, `{"gtin": "4006381333931", "tradeItem": {"tradeItemInformation": {"extensions": {
+		"foodAndBeverageIngredientModule": {"juiceContentPercent": 0, "x_isFoodOrBeverage": false},
+		"tradeItemLifespanModule": {"tradeItemLifespan": {"openedTradeItemLifespan": 0}}
+	}}}}`)
+
+	var zero ProductDocument
+	zero.TradeItem.TradeItemInformation.Extension.FoodAndBeverageIngredientModule.XIsFoodOrBeverage = ValidBool(false)
+	got, err = json.Marshal(zero)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertJSON(t, got, `{"tradeItem": {"tradeItemInformation": {"extensions": {"foodAndBeverageIngredientModule": {"x_isFoodOrBeverage": false}}}}}`)
+}
+
+func TestProductDocumentListLengthChanged(t *testing.T) {
+	doc := decodeDocument(t, `{"tradeItem": {"referencedTradeItem": [
+		{"gtin": "4006381333931", "referencedTradeItemTypeCode": "", "x_note": "first"},
+		{"gtin": "96385074", "x_note": "second"}
+	]}}`)
+
+	refs := &doc.TradeItem.ReferencedTradeItems
+	(*refs)[1].ReferencedTradeItemTypeCode = "REPLACED_BY"
+	got, err := json.Marshal(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertJSON(t, got, `{"tradeItem": {"referencedTradeItem": [
+		{"gtin": "4006381333931", "referencedTradeItemTypeCode": "", "x_note": "first"},
+		{"gtin": "96385074", "referencedTradeItemTypeCode": "REPLACED_BY", "x_note": "second"}
+	]}}`)
+
+	*refs = (*refs)[1:]
+	got, err = json.Marshal(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertJSON(t, got, `{"tradeItem": {"referencedTradeItem": [{"gtin": "96385074", "referencedTradeItemTypeCode": "REPLACED_BY"}]}}`)
+}
+
+func TestMarshalPreservingRaw(t *testing.T) {
+	var p MasterProductData
+	p.Gtin = "4006381333931"
+	got, err := MarshalPreserving([]byte(`{"id": "", "gtin": "1"}`), p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertJSON(t, got, `{"id": "", "gtin": "4006381333931"}`)
+	if _, err := MarshalPreserving(json.RawMessage(`{`), p); err == nil {
+		t.Error("invalid original JSON accepted")
+	}
+}
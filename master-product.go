@@ -1,3 +1,5 @@
+// Code generated by structgen from master-product.spec.json. DO NOT EDIT.
+
 package structs
 
 import (
@@ -5,58 +7,63 @@ import (
 	"time"
 )
 
+// CurrentDataFormatVersion is the data format version MasterProductData
+// implements. Documents without x_dataFormatVersion are taken to be of this
+// version.
+const CurrentDataFormatVersion = "1.0"
+
 type MasterProductData struct {
-	ID        string                 `json:"id"`
-	ExtID     string                 `json:"ext_id"`
-	ProductID string                 `json:"product_id"`
-	Gtin      string                 `json:"gtin"`
-	Name      string                 `json:"name"`
-	Header    MasterProductHeaders   `json:"Header"`
-	TradeItem MasterProductTradeItem `json:"tradeItem"`
+	ID        string                 `json:"id" proto:"1"`
+	ExtID     string                 `json:"ext_id" proto:"2"`
+	ProductID string                 `json:"product_id" proto:"3"`
+	Gtin      string                 `json:"gtin" proto:"4"`
+	Name      string                 `json:"name" proto:"5"`
+	Header    MasterProductHeaders   `json:"Header" proto:"6"`
+	TradeItem MasterProductTradeItem `json:"tradeItem" proto:"7"`
 }
 
 type MasterProductHeaders struct {
-	XDataFormatVersion string `json:"x_dataFormatVersion"`
+	XDataFormatVersion string `json:"x_dataFormatVersion" proto:"1"`
 }
 
 type MasterProductTradeItem struct {
-	GTIN                               string                              `json:"gtin"`                              //Global trade item number
-	XTradeItemIdentification           TradeItemIdentification             `json:"x_tradeItemIdentification"`         // Extra information on Global Trade Item Number to identify a trade item.
-	AdditionalTradeItemIdentifications []AdditionalTradeItemIdentification `json:"additionalTradeItemIdentification"` // Alternative means to the Global Trade Item Number to identify a trade item.
-	InformationProviderOfTradeItem     InformationProviderOfTradeItem      `json:"informationProviderOfTradeItem"`    // The identification of a party, by GLN, in a specific party role.
-	ManufacturerOfTradeItems           []ManufacturerOfTradeItem           `json:"manufacturerOfTradeItem"`           // Party name and identification information for the manufacturer(s) of the trade item.
-	GdsnTradeItemClassification        GdsnTradeItemClassification         `json:"gdsnTradeItemClassification"`       // Information specifying the product class to which a trade item belongs and the classification system being applied.
-	ReferencedTradeItems               []ReferencedTradeItem               `json:"referencedTradeItem"`               // A trade item referenced by this trade item for example replaced or replaced by.
-	TargetMarkets                      TradeItemTargetMarket               `json:"targetMarket"`                      // Target Market associated with a Trade Item.
-	TradeItemContactInformations       []TradeItemContactInformation       `json:"tradeItemContactInformation"`       // Contact details for a Trade Item.
-	TradeItemSynchronisationDates      TradeItemSynchronisationDates       `json:"tradeItemSynchronisationDates"`     // Dates relevant to the process of trade item synchronisation for example publication date.
-	TradeItemInformation               TradeItemInformation                `json:"tradeItemInformation"`              // Detailed information on the trade item.
+	GTIN                               string                              `json:"gtin" proto:"1"`                              //Global trade item number
+	XTradeItemIdentification           TradeItemIdentification             `json:"x_tradeItemIdentification" proto:"2"`         // Extra information on Global Trade Item Number to identify a trade item.
+	AdditionalTradeItemIdentifications []AdditionalTradeItemIdentification `json:"additionalTradeItemIdentification" proto:"3"` // Alternative means to the Global Trade Item Number to identify a trade item.
+	InformationProviderOfTradeItem     InformationProviderOfTradeItem      `json:"informationProviderOfTradeItem" proto:"4"`    // The identification of a party, by GLN, in a specific party role.
+	ManufacturerOfTradeItems           []ManufacturerOfTradeItem           `json:"manufacturerOfTradeItem" proto:"5"`           // Party name and identification information for the manufacturer(s) of the trade item.
+	GdsnTradeItemClassification        GdsnTradeItemClassification         `json:"gdsnTradeItemClassification" proto:"6"`       // Information specifying the product class to which a trade item belongs and the classification system being applied.
+	ReferencedTradeItems               []ReferencedTradeItem               `json:"referencedTradeItem" proto:"7"`               // A trade item referenced by this trade item for example replaced or replaced by.
+	TargetMarkets                      TradeItemTargetMarket               `json:"targetMarket" proto:"8"`                      // Target Market associated with a Trade Item.
+	TradeItemContactInformations       []TradeItemContactInformation       `json:"tradeItemContactInformation" proto:"9"`       // Contact details for a Trade Item.
+	TradeItemSynchronisationDates      TradeItemSynchronisationDates       `json:"tradeItemSynchronisationDates" proto:"10"`    // Dates relevant to the process of trade item synchronisation for example publication date.
+	TradeItemInformation               TradeItemInformation                `json:"tradeItemInformation" proto:"11"`             // Detailed information on the trade item.
 }
 
 // TradeItemIdentification contains extra information on Global Trade Item Number to identify a trade item.
 type TradeItemIdentification struct {
 	// A trade item identifier that is in addition to the GTIN.
-	ID string `json:"$"`
+	ID string `json:"$" proto:"1"`
 	// This code will be used to cross-reference the Vendors internal trade item number to the GTIN in a one to one relationship.
-	AdditionalTradeItemIdentificationTypeCode string `json:"@additionalTradeItemIdentificationTypeCode"`
+	AdditionalTradeItemIdentificationTypeCode string `json:"@additionalTradeItemIdentificationTypeCode" proto:"2"`
 	// Start Date-Time of the given GTIN in ISO Format
-	StartDateTime time.Time `json:"@startDateTime"`
+	StartDateTime time.Time `json:"@startDateTime" proto:"3"`
 	// End Date-Time of the given GTIN in ISO Format
-	EndDateTime time.Time `json:"@endDateTime"`
+	EndDateTime time.Time `json:"@endDateTime" proto:"4"`
 }
 
 // AdditionalTradeItemIdentification describes alternative means to the Global Trade Item Number to identify a trade item.
 type AdditionalTradeItemIdentification struct {
 	// A trade item identifier that is in addition to the GTIN.
-	ID string `json:"$"`
+	ID string `json:"$" proto:"1"`
 	// This code will be used to cross-reference the Vendors internal trade item number to the GTIN in a one to one relationship.
-	AdditionalTradeItemIdentificationTypeCode string `json:"@additionalTradeItemIdentificationTypeCode"`
+	AdditionalTradeItemIdentificationTypeCode string `json:"@additionalTradeItemIdentificationTypeCode" proto:"2"`
 	// Start Date-Time of the given GTIN in ISO Format
-	StartDateTime time.Time `json:"@startDateTime"`
+	StartDateTime time.Time `json:"@startDateTime" proto:"3"`
 	// End Date-Time of the given GTIN in ISO Format
-	EndDateTime time.Time `json:"@endDateTime"`
+	EndDateTime time.Time `json:"@endDateTime" proto:"4"`
 	// The snapshot of the code list at a certain point in time.
-	Version string `json:"@version"`
+	Version string `json:"@version" proto:"5"`
 }
 
 // InformationProviderOfTradeItem identifies a party, by GLN, in a specific party role.
@@ -64,11 +71,11 @@ type InformationProviderOfTradeItem struct {
 	// The Global Location Number (GLN) is a structured Identification of a physical location,
 	// legal or functional entity within an enterprise. The GLN is the primary party identifier.
 	// Each party identified in the trading relationship must have a primary party Identification.
-	GLN string `json:"gln"`
+	GLN string `json:"gln" proto:"1"`
 	// The name of the party expressed in text.
-	PartyName string `json:"partyName"`
+	PartyName string `json:"partyName" proto:"2"`
 	// The address associated with the party. This could be the full company address.
-	PartyAddress string `json:"partyAddress"`
+	PartyAddress string `json:"partyAddress" proto:"3"`
 }
 
 // ManufacturerOfTradeItem contains party name and identification information for the manufacturer(s) of the trade item.
@@ -77,205 +84,219 @@ type ManufacturerOfTradeItem struct {
 	// location, legal or functional entity within an enterprise. The GLN is the primary
 	// party identifier. Each party identified in the trading relationship must have
 	// a primary party Identification.
-	GLN string `json:"gln"`
+	GLN string `json:"gln" proto:"1"`
 	// The name of the party expressed in text.
-	PartyName string `json:"partyName"`
+	PartyName string `json:"partyName" proto:"2"`
 	// The address associated with the party. This could be the full company address.
-	PartyAddress string `json:"partyAddress"`
+	PartyAddress string `json:"partyAddress" proto:"3"`
 }
 
 // GdsnTradeItemClassification specify the product class to which a trade item belongs and the classification system being applied.
 type GdsnTradeItemClassification struct {
 	// Code specifying a product category according to the GS1 Global Product Classification (GPC) standard.
-	GpcCategoryCode string `json:"gpcCategoryCode"`
+	GpcCategoryCode string `json:"gpcCategoryCode" proto:"1"`
 	// Category code based on alternate classification schema chosen in addition to the Global Product Classification (GPC).
-	AdditionalTradeItemClassifications []AdditionalTradeItemClassification `json:"additionalTradeItemClassification"`
+	AdditionalTradeItemClassifications []AdditionalTradeItemClassification `json:"additionalTradeItemClassification" proto:"2"`
 }
 
 // AdditionalTradeItemClassification contains category code based on alternate classification
 // schema chosen in addition to the Global Product Classification (GPC).
 type AdditionalTradeItemClassification struct {
 	// Additional classification system code.
-	AdditionalTradeItemClassificationSystemCode string `json:"additionalTradeItemClassificationSystemCode"`
+	AdditionalTradeItemClassificationSystemCode string `json:"additionalTradeItemClassificationSystemCode" proto:"1"`
 	// A code list value for an Additional Trade Item Classification Type.
-	AdditionalTradeItemClassificationValues []AdditionalTradeItemClassificationValue `json:"additionalTradeItemClassificationValue"`
+	AdditionalTradeItemClassificationValues []AdditionalTradeItemClassificationValue `json:"additionalTradeItemClassificationValue" proto:"2"`
 }
 
 // AdditionalTradeItemClassificationValue is a code list value for an Additional Trade Item Classification Type.
 type AdditionalTradeItemClassificationValue struct {
 	// Category code based on alternate classification schema chosen in addition to GS1 classification.
-	AdditionalTradeItemClassificationCodeValue string `json:"additionalTradeItemClassificationCodeValue"`
+	AdditionalTradeItemClassificationCodeValue string `json:"additionalTradeItemClassificationCodeValue" proto:"1"`
 }
 
 // ReferencedTradeItem is a trade item referenced by this trade item for example replaced or replaced by.
 type ReferencedTradeItem struct {
 	// The identification of the referenced trade item.
-	GTIN string `json:"gtin"`
+	GTIN string `json:"gtin" proto:"1"`
 	// A code depicting the type of trade item that is referenced for a specific purpose for example
 	// substitute, replaced by, equivalent trade items.
-	ReferencedTradeItemTypeCode string `json:"referencedTradeItemTypeCode"`
+	ReferencedTradeItemTypeCode string `json:"referencedTradeItemTypeCode" proto:"2"`
 }
 
 // TradeItemTargetMarket is target market associated with a Trade Item.
 type TradeItemTargetMarket struct {
 	// The code that identifies the target market. The taget market is at country level or
 	// higher geographical definition and is where a trade item is intended to be sold.
-	TargetMarketCountryCode string `json:"targetMarketCountryCode"`
+	TargetMarketCountryCode string `json:"targetMarketCountryCode" proto:"1"`
 }
 
 // TradeItemContactInformation is a contact details for a Trade Item.
 type TradeItemContactInformation struct {
 	// The general category of the contact party for a trade item for example Purchasing.
-	ContactTypeCode string `json:"contactTypeCode"`
+	ContactTypeCode string `json:"contactTypeCode" proto:"1"`
 	// The address associated with the contact type. For example, in case of a contact
 	// type of CONSUMER_SUPPORT, this could be the full company address as expressed
 	// on the trade item packaging or label.
-	ContactAddress string `json:"contactAddress"`
+	ContactAddress string `json:"contactAddress" proto:"2"`
 	// A description of the contact for the trade item.
-	ContactDescriptions []ContactDescription `json:"contactDescription"`
+	ContactDescriptions []ContactDescription `json:"contactDescription" proto:"3"`
 	// The name of the company or person associated with the contact type. For example,
 	// in case of a contact type of CONSUMER_SUPPORT, this could be the company name as
 	// expressed on the trade item packaging or label.
-	ContactName string `json:"contactName"`
+	ContactName string `json:"contactName" proto:"4"`
 	// The communication channel for example phone number for a target market for a Trade Item.
-	TargetMarketCommunicationChannels []TargetMarketCommunicationChannel `json:"targetMarketCommunicationChannel"`
+	TargetMarketCommunicationChannels []TargetMarketCommunicationChannel `json:"targetMarketCommunicationChannel" proto:"5"`
 }
 
 // ContactDescription is a description of the contact for the trade item.
 type ContactDescription struct {
-	Description  string `json:"$"`
-	LanguageCode string `json:"@languageCode"`
+	Description  string `json:"$" proto:"1"`
+	LanguageCode string `json:"@languageCode" proto:"2"`
+}
+
+// LocalizedText returns t as a LocalizedText.
+func (t ContactDescription) LocalizedText() LocalizedText {
+	return LocalizedText{Value: t.Description, LanguageCode: t.LanguageCode}
+}
+
+// ContactDescriptionTexts returns the texts of s in order.
+func ContactDescriptionTexts(s []ContactDescription) []LocalizedText {
+	texts := make([]LocalizedText, len(s))
+	for i, t := range s {
+		texts[i] = t.LocalizedText()
+	}
+	return texts
 }
 
 // TargetMarketCommunicationChannel is the communication channel for example phone number for a target market for a Trade Item.
 type TargetMarketCommunicationChannel struct {
 	// A target market associated with a communication channel for example Canada.
-	TargetMarkets []CommunicationChannelTargetMarket `json:"targetMarket"`
+	TargetMarkets []CommunicationChannelTargetMarket `json:"targetMarket" proto:"1"`
 	// The channel or manner in which a communication can be made, such as telephone or email.
-	CommunicationChannels []CommunicationChannel `json:"communicationChannel"`
+	CommunicationChannels []CommunicationChannel `json:"communicationChannel" proto:"2"`
 }
 
 // CommunicationChannelTargetMarket  associated with a communication channel for example Canada.
 type CommunicationChannelTargetMarket struct {
 	// The code that identifies the target market. The target market is at country level or higher geographical
 	// definition and is where a trade item is intended to be sold.
-	TargetMarketCountryCode string `json:"targetMarketCountryCode"`
+	TargetMarketCountryCode string `json:"targetMarketCountryCode" proto:"1"`
 }
 
 // CommunicationChannel is the channel or manner in which a communication can be made, such as telephone or email.
 type CommunicationChannel struct {
 	// The channel or manner in which a communication can be made, such as telephone or email.
-	CommunicationChannelCode string `json:"communicationChannelCode"`
+	CommunicationChannelCode string `json:"communicationChannelCode" proto:"1"`
 	// The channel or manner in which a communication can be made, such as telephone or email.
-	CommunicationValue string `json:"communicationValue"`
+	CommunicationValue string `json:"communicationValue" proto:"2"`
 	// The channel or manner in which a communication can be made, such as telephone or email.
-	CommunicationChannelName string `json:"communicationChannelName"`
+	CommunicationChannelName string `json:"communicationChannelName" proto:"3"`
 }
 
 // TradeItemSynchronisationDates contains relevant dates to the process of trade item synchronisation for example publication date.
 type TradeItemSynchronisationDates struct {
 	// Indicates the point in time where the last modification on a Trade Item was made.
-	LastChangeDateTime time.Time `json:"lastChangeDateTime"`
+	LastChangeDateTime time.Time `json:"lastChangeDateTime" proto:"1"`
 }
 
 // TradeItemInformation is a detailed information on the trade item.
 type TradeItemInformation struct {
 	// DG private use module. dgPrivateUseModule must be a hash or nil. When hash,
 	// dgPrivateUseModule may contain any number of any kind of keys with any kind of values.
-	Extension TradeItemExtension `json:"extensions"`
+	Extension TradeItemExtension `json:"extensions" proto:"1"`
 }
 
 // TradeItemExtension is a DG private use module. dgPrivateUseModule must be a hash or nil. When hash,
 // dgPrivateUseModule may contain any number of any kind of keys with any kind of values.
 type TradeItemExtension struct {
 	// A module containing details on products traditionally containing alcohol.
-	AlcoholInformationModule AlcoholInformationModule `json:"alcoholInformationModule"`
+	AlcoholInformationModule AlcoholInformationModule `json:"alcoholInformationModule" proto:"1"`
 	// A module containing information on allergens for a trade item.
-	AllergenInformationModule AllergenInformationModule `json:"allergenInformationModule"`
+	AllergenInformationModule AllergenInformationModule `json:"allergenInformationModule" proto:"2"`
 	// A module contain instructions on how the consumer is to use or store a trade item.
-	ConsumerInstructionsModule ConsumerInstructionsModule `json:"consumerInstructionsModule"`
+	ConsumerInstructionsModule ConsumerInstructionsModule `json:"consumerInstructionsModule" proto:"3"`
 	// A module detailing substances that can harm people.
-	DangerousSubstanceInformationModule DangerousSubstanceInformationModule `json:"dangerousSubstanceInformationModule"`
+	DangerousSubstanceInformationModule DangerousSubstanceInformationModule `json:"dangerousSubstanceInformationModule" proto:"4"`
 	// A module contain a product dietary suitability.
-	DietInformationModule DietInformationModule `json:"dietInformationModule"`
+	DietInformationModule DietInformationModule `json:"dietInformationModule" proto:"5"`
 	// Information on any farming or processing performed on and agricultural trade item.
-	FarmingAndProcessingInformationModule FarmingAndProcessingInformationModule `json:"farmingAndProcessingInformationModule"`
+	FarmingAndProcessingInformationModule FarmingAndProcessingInformationModule `json:"farmingAndProcessingInformationModule" proto:"6"`
 	// Information on the constituent ingredient make up of the product.
-	FoodAndBeverageIngredientModule FoodAndBeverageIngredientModule `json:"foodAndBeverageIngredientModule"`
+	FoodAndBeverageIngredientModule FoodAndBeverageIngredientModule `json:"foodAndBeverageIngredientModule" proto:"7"`
 	// Information on way the product can be prepared or served.
-	FoodAndBeveragePreparationServingModule FoodAndBeveragePreparationServingModule `json:"foodAndBeveragePreparationServingModule"`
+	FoodAndBeveragePreparationServingModule FoodAndBeveragePreparationServingModule `json:"foodAndBeveragePreparationServingModule" proto:"8"`
 	// Information on physiochemical or other properties of food and beverage products.
-	FoodAndBeveragePropertiesInformationModule FoodAndBeveragePropertiesInformationModule `json:"foodAndBeveragePropertiesInformationModule"`
+	FoodAndBeveragePropertiesInformationModule FoodAndBeveragePropertiesInformationModule `json:"foodAndBeveragePropertiesInformationModule" proto:"9"`
 	// Information on a trade item meant to convey features and benefits and targeted customer.
-	MarketingInformationModule MarketingInformationModule `json:"marketingInformationModule"`
+	MarketingInformationModule MarketingInformationModule `json:"marketingInformationModule" proto:"10"`
 	// A module providing Information on ingredients for items that are not food for
 	// example detergents, medicines.
-	NonfoodIngredientModule NonfoodIngredientModule `json:"nonfoodIngredientModule"`
+	NonfoodIngredientModule NonfoodIngredientModule `json:"nonfoodIngredientModule" proto:"11"`
 	// Information about content of nutrients. Multiple sets of nutrient information
 	// can be specified with varying state, serving size and daily value intake base.
-	NutritionalInformationModule NutritionalInformationModule `json:"nutritionalInformationModule"`
+	NutritionalInformationModule NutritionalInformationModule `json:"nutritionalInformationModule" proto:"12"`
 	// Packaging information for a trade item.
-	PackagingInformationModule PackagingInformationModule `json:"packagingInformationModule"`
+	PackagingInformationModule PackagingInformationModule `json:"packagingInformationModule" proto:"13"`
 	// A module containing details on markings on the packaging of the trade item for
-	//  example dates, environment.
-	PackagingMarkingModule PackagingMarkingModule `json:"packagingMarkingModule"`
+	// example dates, environment.
+	PackagingMarkingModule PackagingMarkingModule `json:"packagingMarkingModule" proto:"14"`
 	// Information on the activity (e.g. bottling) taken place for a trade item
 	// as well as the associated geographic area.
-	PlaceOfItemActivityModule PlaceOfItemActivityModule `json:"placeOfItemActivityModule"`
+	PlaceOfItemActivityModule PlaceOfItemActivityModule `json:"placeOfItemActivityModule" proto:"15"`
 	// A module used to express characteristics for a product for example values for
-	//  a property such as numberOfPlys.
-	ProductCharacteristicsModule ProductCharacteristicsModule `json:"productCharacteristicsModule"`
+	// a property such as numberOfPlys.
+	ProductCharacteristicsModule ProductCharacteristicsModule `json:"productCharacteristicsModule" proto:"16"`
 	// A module containing information usually contained on a safety data sheet
-	//  or on a material safety data sheet as it is referred to in some target
-	//  markets.
-	SafetyDataSheetModule SafetyDataSheetModule `json:"safetyDataSheetModule"`
+	// or on a material safety data sheet as it is referred to in some target
+	// markets.
+	SafetyDataSheetModule SafetyDataSheetModule `json:"safetyDataSheetModule" proto:"17"`
 	// Sales information regarding price and selling conditions/restrictions
 	// of the Trade Item to the consumer.
-	SalesInformationModule SalesInformationModule `json:"salesInformationModule"`
+	SalesInformationModule SalesInformationModule `json:"salesInformationModule" proto:"18"`
 	// A module carrying general descriptions of the trade item including
-	//  brand, form, variant.
-	TradeItemDescriptionModule TradeItemDescriptionModule `json:"tradeItemDescriptionModule"`
+	// brand, form, variant.
+	TradeItemDescriptionModule TradeItemDescriptionModule `json:"tradeItemDescriptionModule" proto:"19"`
 	// A module containing information on the amount of time the item can or should
 	// be used, sold, etc.
-	TradeItemLifespanModule TradeItemLifespanModule `json:"tradeItemLifespanModule"`
+	TradeItemLifespanModule TradeItemLifespanModule `json:"tradeItemLifespanModule" proto:"20"`
 	// A module containing measurement information for the trade item.
-	TradeItemMeasurementsModule TradeItemMeasurementsModule `json:"tradeItemMeasurementsModule"`
+	TradeItemMeasurementsModule TradeItemMeasurementsModule `json:"tradeItemMeasurementsModule" proto:"21"`
 	// Information on temperature considerations for trade item.
-	TradeItemTemperatureInformationModule TradeItemTemperatureInformationModule `json:"tradeItemTemperatureInformationModule"`
+	TradeItemTemperatureInformationModule TradeItemTemperatureInformationModule `json:"tradeItemTemperatureInformationModule" proto:"22"`
 	// A module with information specific to variable weight or dimension trade items.
-	VariableTradeItemInformationModule VariableTradeItemInformationModule `json:"variableTradeItemInformationModule"`
+	VariableTradeItemInformationModule VariableTradeItemInformationModule `json:"variableTradeItemInformationModule" proto:"23"`
 	// Associated code lists
-	DGCodeListModule DGCodeListModule `json:"dgCodeListModule"`
+	DGCodeListModule DGCodeListModule `json:"dgCodeListModule" proto:"24"`
 	// Product media properties
-	DGMediaModule DGMediaModule `json:"dgMediaModule"`
+	DGMediaModule DGMediaModule `json:"dgMediaModule" proto:"25"`
 	// Product presentation properties
-	DGPresentationModule DGPresentationModule `json:"dgPresentationModule"`
+	DGPresentationModule DGPresentationModule `json:"dgPresentationModule" proto:"26"`
 	// Free data.
-	DGPrivateUseModule *json.RawMessage `json:"dgPrivateUseModule"`
+	DGPrivateUseModule *json.RawMessage `json:"dgPrivateUseModule" proto:"27"`
 	// A module containing freely defined product attributes.
-	DGProductAttributeModule DGProductAttributeModule `json:"dgProductAttributeModule"`
+	DGProductAttributeModule DGProductAttributeModule `json:"dgProductAttributeModule" proto:"28"`
 }
 
 // AlcoholInformationModule is a module containing details on products traditionally containing alcohol.
 type AlcoholInformationModule struct {
 	// Details on products traditionally containing alcohol.
-	AlcoholInformation AlcoholInformation `json:"alcoholInformation"`
+	AlcoholInformation AlcoholInformation `json:"alcoholInformation" proto:"1"`
 }
 
 // AlcoholInformation describes details on products traditionally containing alcohol.
 type AlcoholInformation struct {
 	// Percentage of alcohol contained in the base unit trade item.
-	PercentageOfAlcoholByVolume float64 `json:"percentageOfAlcoholByVolume"`
+	PercentageOfAlcoholByVolume NullFloat64 `json:"percentageOfAlcoholByVolume" proto:"1"`
 	// Indication of the amount of sugar contained in the beverage for example if sugar remaining equals 6.5 g/l then enter 6.5 GL.
-	AlcoholicBeverageSugarContents []AlcoholicBeverageSugarContent `json:"alcoholicBeverageSugarContent"`
+	AlcoholicBeverageSugarContents []AlcoholicBeverageSugarContent `json:"alcoholicBeverageSugarContent" proto:"2"`
 }
 
 // AlcoholicBeverageSugarContent indicates of the amount of sugar contained in the beverage for example if sugar remaining equals 6.5 g/l then enter 6.5 GL.
 type AlcoholicBeverageSugarContent struct {
 	// Measurement value.
-	Measurement float64 `json:"$"`
+	Measurement Decimal `json:"$" proto:"1"`
 	// Unit of measure code. Uses code list measurementUnitCode.
-	MeasurementUnitCode string `json:"@measurementUnitCode"`
+	MeasurementUnitCode string `json:"@measurementUnitCode" proto:"2"`
 }
 
 // AllergenInformationModule is a module containing information on allergens for a trade item.
@@ -283,59 +304,73 @@ type AllergenInformationModule struct {
 	// Information on substances that might cause allergic reactions and substances subject to intolerance
 	// when consumed. The allergy information refers to specified regulations that apply to the target market
 	// to which the item information is published.
-	AllergenRelatedInformations []AllergenRelatedInformation `json:"allergenRelatedInformation"`
+	AllergenRelatedInformations []AllergenRelatedInformation `json:"allergenRelatedInformation" proto:"1"`
 }
 
 // AllergenRelatedInformation contains information on substances that might cause allergic reactions
-//  and substances subject to intolerance when consumed. The allergy information refers to specified
+// and substances subject to intolerance when consumed. The allergy information refers to specified
 // regulations that apply to the target market to which the item information is published.
 type AllergenRelatedInformation []struct {
 	// Agency that controls the allergen definition.
-	AllergenSpecificationAgency string `json:"allergenSpecificationAgency"`
+	AllergenSpecificationAgency string `json:"allergenSpecificationAgency" proto:"1"`
 	// Free text field containing the name and version of the regulation or standard that
 	// contains the definition of the allergen.
-	AllergenSpecificationName string `json:"allergenSpecificationName"`
+	AllergenSpecificationName string `json:"allergenSpecificationName" proto:"2"`
 	// Textual description of the presence or absence of allergens as governed by local rules
 	// and regulations, specified as one string.
-	AllergenStatements []AllergenStatement `json:"allergenStatement"`
+	AllergenStatements []AllergenStatement `json:"allergenStatement" proto:"3"`
 	// Description of the presence or absence of allergens as governed by local rules and regulations, specified per allergen.
-	Allergens []Allergen `json:"allergen"`
+	Allergens []Allergen `json:"allergen" proto:"4"`
 }
 
 // AllergenStatement is a textual description of the presence or absence of allergens as governed
 // by local rules and regulations, specified as one string.
 type AllergenStatement struct {
 	// Name of allergen
-	Name string `json:"$"`
+	Name string `json:"$" proto:"1"`
 	// Language code
-	LanguageCode string `json:"@languageCode"`
+	LanguageCode string `json:"@languageCode" proto:"2"`
 	// Substring emphasis. Emphases may overlap.
-	XEmphasis []XEmphasis `json:"x_emphasis"`
+	XEmphasis []XEmphasis `json:"x_emphasis" proto:"3"`
+}
+
+// LocalizedText returns t as a LocalizedText.
+func (t AllergenStatement) LocalizedText() LocalizedText {
+	return LocalizedText{Value: t.Name, LanguageCode: t.LanguageCode}
+}
+
+// AllergenStatementTexts returns the texts of s in order.
+func AllergenStatementTexts(s []AllergenStatement) []LocalizedText {
+	texts := make([]LocalizedText, len(s))
+	for i, t := range s {
+		texts[i] = t.LocalizedText()
+	}
+	return texts
 }
 
 // XEmphasis is a substring emphasis. Emphases may overlap.
 type XEmphasis struct {
 	// Emphasis starting index in characters from the beginning
 	// of the string. Index starts at zero.
-	StartAt int `json:"startAt"`
+	StartAt int `json:"startAt" proto:"1"`
 	// Emphasis length in characters.
-	Length int `json:"length"`
+	Length int `json:"length" proto:"2"`
 }
 
 // Allergen is a description of the presence or absence of allergens as governed by
 // local rules and regulations, specified per allergen.
 type Allergen struct {
 	// Code indicating the type of allergen. Uses code list allergenTypeCode.
-	AllergenTypeCode string `json:"allergenTypeCode"`
+	AllergenTypeCode string `json:"allergenTypeCode" proto:"1"`
 	// Code indicating the level of presence of the allergen.
-	LevelOfContainmentCode string `json:"levelOfContainmentCode"`
+	LevelOfContainmentCode string `json:"levelOfContainmentCode" proto:"2"`
 }
 
 // ConsumerInstructionsModule is a module contain instructions on how the consumer is to
 // use or store a trade item.
 type ConsumerInstructionsModule struct {
 	// Instructions on how the consumer is to use or store a trade item.
-	ConsumerInstructions ConsumerInstructions `json:"consumerInstructions"`
+	ConsumerInstructions ConsumerInstructions `json:"consumerInstructions" proto:"1"`
 }
 
 // ConsumerInstructions contains instructions on how the consumer is to use or store a trade item.
@@ -343,54 +378,82 @@ type ConsumerInstructions struct {
 	// Expresses in text the consumer storage instructions of a product which are normally held on the
 	// label or accompanying the product. This information may or may not be labeled on the pack. Instructions
 	// may refer to a suggested storage temperature, a specific storage requirement.
-	ConsumerStorageInstructions []ConsumerStorageInstruction `json:"consumerStorageInstructions"`
+	ConsumerStorageInstructions []ConsumerStorageInstruction `json:"consumerStorageInstructions" proto:"1"`
 	// Expresses in text the consumer usage instructions of a product which are normally held on the label or accompanying the product. This information may or may not be labeled on the pack. Instructions may refer to a the how the consumer is to use the product, This does not include storage, food preparations, and drug dosage and preparation instructions.
-	ConsumerUsageInstructions []ConsumerUsageInstruction `json:"consumerUsageInstructions"`
+	ConsumerUsageInstructions []ConsumerUsageInstruction `json:"consumerUsageInstructions" proto:"2"`
 }
 
 // ConsumerStorageInstruction expresses in text the consumer storage instructions of a product
 // which are normally held on the label or accompanying the product. This information may or may
 // not be labeled on the pack. Instructions may refer to a suggested storage temperature, a specific
-//  storage requirement.
+// storage requirement.
 type ConsumerStorageInstruction struct {
-	Instruction  string `json:"$"`
-	LanguageCode string `json:"@languageCode"`
+	Instruction  string `json:"$" proto:"1"`
+	LanguageCode string `json:"@languageCode" proto:"2"`
+}
+
+// LocalizedText returns t as a LocalizedText.
+func (t ConsumerStorageInstruction) LocalizedText() LocalizedText {
+	return LocalizedText{Value: t.Instruction, LanguageCode: t.LanguageCode}
+}
+
+// ConsumerStorageInstructionTexts returns the texts of s in order.
+func ConsumerStorageInstructionTexts(s []ConsumerStorageInstruction) []LocalizedText {
+	texts := make([]LocalizedText, len(s))
+	for i, t := range s {
+		texts[i] = t.LocalizedText()
+	}
+	return texts
 }
 
 // ConsumerUsageInstruction expresses in text the consumer usage instructions of a product which are normally
-//  held on the label or accompanying the product. This information may or may not be labeled on the pack.
+// held on the label or accompanying the product. This information may or may not be labeled on the pack.
 // Instructions may refer to a the how the consumer is to use the product, This does not include storage, food
 // preparations, and drug dosage and preparation instructions.
 type ConsumerUsageInstruction struct {
-	Instruction  string `json:"$"`
-	LanguageCode string `json:"@languageCode"`
+	Instruction  string `json:"$" proto:"1"`
+	LanguageCode string `json:"@languageCode" proto:"2"`
+}
+
+// LocalizedText returns t as a LocalizedText.
+func (t ConsumerUsageInstruction) LocalizedText() LocalizedText {
+	return LocalizedText{Value: t.Instruction, LanguageCode: t.LanguageCode}
+}
+
+// ConsumerUsageInstructionTexts returns the texts of s in order.
+func ConsumerUsageInstructionTexts(s []ConsumerUsageInstruction) []LocalizedText {
+	texts := make([]LocalizedText, len(s))
+	for i, t := range s {
+		texts[i] = t.LocalizedText()
+	}
+	return texts
 }
 
 // DangerousSubstanceInformationModule is a module detailing substances that can harm people.
 type DangerousSubstanceInformationModule struct {
 	// Details on substances that can harm people, other living organisms, property, or the environment.
-	DangerousSubstanceInformations []DangerousSubstanceInformation `json:"dangerousSubstanceInformation"`
+	DangerousSubstanceInformations []DangerousSubstanceInformation `json:"dangerousSubstanceInformation" proto:"1"`
 }
 
 // DangerousSubstanceInformation contains details on substances that can harm people, other living
 // organisms, property, or the environment.
 type DangerousSubstanceInformation struct {
 	// Properties of a dangerous substance.
-	DangerousSubstanceProperties []DangerousSubstanceProperty `json:"dangerousSubstanceProperties"`
+	DangerousSubstanceProperties []DangerousSubstanceProperty `json:"dangerousSubstanceProperties" proto:"1"`
 }
 
 // DangerousSubstanceProperty details properties of a dangerous substance.
 type DangerousSubstanceProperty struct {
 	// The name of the type of dangerous substance contained in the trade item.
-	DangerousSubstanceName string `json:"dangerousSubstanceName"`
+	DangerousSubstanceName string `json:"dangerousSubstanceName" proto:"1"`
 	// An indicator whether or not a trade item is classified and labelled as containing
 	// a dangerous substance.
-	IsDangerousSubstance bool `json:"isDangerousSubstance"`
+	IsDangerousSubstance NullBool `json:"isDangerousSubstance" proto:"2"`
 	// The abbreviation codes for labelling obligations and special risks (health risks
 	// of skin, respiratory organs, swallow, eyes, reproduction) for handling of the substance.
-	RiskPhraseCodes []RiskPhraseCode `json:"riskPhraseCode"`
+	RiskPhraseCodes []RiskPhraseCode `json:"riskPhraseCode" proto:"3"`
 	// Safety phrases are defined as safety advice concerning dangerous substances and preparations.
-	SafetyPhraseCodes []SafetyPhraseCode `json:"safetyPhraseCode"`
+	SafetyPhraseCodes []SafetyPhraseCode `json:"safetyPhraseCode" proto:"4"`
 }
 
 // RiskPhraseCode is the abbreviation codes for labelling obligations and special risks
@@ -398,37 +461,37 @@ type DangerousSubstanceProperty struct {
 // of the substance.
 type RiskPhraseCode struct {
 	// The name of the agency that manages a code list.
-	ExternalAgencyName string `json:"externalAgencyName"`
+	ExternalAgencyName string `json:"externalAgencyName" proto:"1"`
 	// The name of the code list maintained by an external agency.
-	ExternalCodeListName         string                            `json:"externalCodeListName"`
-	EnumerationValueInformations []RiskEnumerationValueInformation `json:"enumerationValueInformation"`
+	ExternalCodeListName         string                            `json:"externalCodeListName" proto:"2"`
+	EnumerationValueInformations []RiskEnumerationValueInformation `json:"enumerationValueInformation" proto:"3"`
 }
 
 // RiskEnumerationValueInformation cotains about risk phares codes
 type RiskEnumerationValueInformation struct {
 	// Code List Value maintained by an external code list agency.
-	EnumerationValue string `json:"enumerationValue"`
+	EnumerationValue string `json:"enumerationValue" proto:"1"`
 }
 
 // SafetyPhraseCode defines safety advice concerning dangerous substances and preparations.
 type SafetyPhraseCode struct {
 	// The name of the agency that manages a code list.
-	ExternalAgencyName string `json:"externalAgencyName"`
+	ExternalAgencyName string `json:"externalAgencyName" proto:"1"`
 	// The name of the code list maintained by an external agency.
-	ExternalCodeListName         string                              `json:"externalCodeListName"`
-	EnumerationValueInformations []SafetyEnumerationValueInformation `json:"enumerationValueInformation"`
+	ExternalCodeListName         string                              `json:"externalCodeListName" proto:"2"`
+	EnumerationValueInformations []SafetyEnumerationValueInformation `json:"enumerationValueInformation" proto:"3"`
 }
 
 // SafetyEnumerationValueInformation of safety phrases
 type SafetyEnumerationValueInformation struct {
 	// Code List Value maintained by an external code list agency.
-	EnumerationValue string `json:"enumerationValue"`
+	EnumerationValue string `json:"enumerationValue" proto:"1"`
 }
 
 // DietInformationModule is a module contain a product dietary suitability.
 type DietInformationModule struct {
 	// The diet the product is suitable for.
-	DietInformation DietInformation `json:"dietInformation"`
+	DietInformation DietInformation `json:"dietInformation" proto:"1"`
 }
 
 // DietInformation is the diet the product is suitable for.
@@ -436,35 +499,49 @@ type DietInformation struct {
 	// Expresses in text the dietary description of a product which are normally held
 	// on the label or accompanying the product. This information may or may not be labeled
 	// on the pack. Instructions may refer to a suggested lifestyle or dietary preference.
-	DietTypeDescriptions []DietTypeDescription `json:"dietTypeDescription"`
-	DietTypeInformations []DietTypeInformation `json:"dietTypeInformation"`
+	DietTypeDescriptions []DietTypeDescription `json:"dietTypeDescription" proto:"1"`
+	DietTypeInformations []DietTypeInformation `json:"dietTypeInformation" proto:"2"`
 }
 
 // DietTypeDescription expresses in text the dietary description of a product which are
 // normally held on the label or accompanying the product. This information may or may not
 // be labeled on the pack. Instructions may refer to a suggested lifestyle or dietary preference.
 type DietTypeDescription struct {
-	Description  string `json:"$"`
-	LanguageCode string `json:"@languageCode"`
+	Description  string `json:"$" proto:"1"`
+	LanguageCode string `json:"@languageCode" proto:"2"`
+}
+
+// LocalizedText returns t as a LocalizedText.
+func (t DietTypeDescription) LocalizedText() LocalizedText {
+	return LocalizedText{Value: t.Description, LanguageCode: t.LanguageCode}
+}
+
+// DietTypeDescriptionTexts returns the texts of s in order.
+func DietTypeDescriptionTexts(s []DietTypeDescription) []LocalizedText {
+	texts := make([]LocalizedText, len(s))
+	for i, t := range s {
+		texts[i] = t.LocalizedText()
+	}
+	return texts
 }
 
 // DietTypeInformation Expresses in text the suggested dietary suitability of a product which
 // are normally held on the label or accompanying the product. This information may or may not
 // be labeled on the pack.
 type DietTypeInformation struct {
-	DietTypeCode    string `json:"dietTypeCode"`
-	DietTypeSubcode string `json:"dietTypeSubcode"`
+	DietTypeCode    string `json:"dietTypeCode" proto:"1"`
+	DietTypeSubcode string `json:"dietTypeSubcode" proto:"2"`
 }
 
 // FarmingAndProcessingInformationModule contains information on any farming or processing
 // performed on and agricultural trade item.
 type FarmingAndProcessingInformationModule struct {
 	// Details on the trade item regarding the extent of organic production.
-	TradeItemOrganicInformation TradeItemOrganicInformation `json:"tradeItemOrganicInformation"`
+	TradeItemOrganicInformation TradeItemOrganicInformation `json:"tradeItemOrganicInformation" proto:"1"`
 	// 	Information on farming and processing for a trade item.
-	TradeItemFarmingAndProcessing TradeItemFarmingAndProcessing `json:"tradeItemFarmingAndProcessing"`
+	TradeItemFarmingAndProcessing TradeItemFarmingAndProcessing `json:"tradeItemFarmingAndProcessing" proto:"2"`
 	// Attribute value pair information.
-	AVPList AVPList `json:"avpList"`
+	AVPList AVPList `json:"avpList" proto:"3"`
 }
 
 // TradeItemOrganicInformation details on the trade item regarding the extent of organic production.
@@ -472,110 +549,142 @@ type TradeItemOrganicInformation struct {
 	// Indication of the place where the agricultural raw materials of which the product is composed
 	// have been farmed. It applies only to the trade item, not ingredient by ingredient. Uses code
 	// list organicProductPlaceOfFarmingCode
-	OrganicProductPlaceOfFarmingCode string `json:"organicProductPlaceOfFarmingCode"`
+	OrganicProductPlaceOfFarmingCode string `json:"organicProductPlaceOfFarmingCode" proto:"1"`
 	// Any claim to indicate the organic status of a trade item or of one or more of its components.
-	OrganicClaims []OrganicClaim `json:"organicClaim"`
+	OrganicClaims []OrganicClaim `json:"organicClaim" proto:"2"`
 }
 
 // OrganicClaim contains any claim to indicate the organic status of a trade item or of one or more of its components.
 type OrganicClaim struct {
 	// A Governing body that creates and maintains standards related to organic products. Uses code list organicClaimAgencyCode
-	OrganicClaimAgencyCode []string `json:"organicClaimAgencyCode"`
+	OrganicClaimAgencyCode []string `json:"organicClaimAgencyCode" proto:"1"`
 	// The percent of actual organic materials per weight of the trade item. This is usually claimed on the product
-	OrganicPercentClaim int `json:"organicPercentClaim"`
+	OrganicPercentClaim NullInt `json:"organicPercentClaim" proto:"2"`
 }
 
 // TradeItemFarmingAndProcessing contains information on farming and processing for a trade item.
 type TradeItemFarmingAndProcessing struct {
 	// A statement of the presence or absence of genetically modified protein or DNA. Uses code
 	// list geneticallyModifiedDeclarationCode
-	GeneticallyModifiedDeclarationCode string `json:"geneticallyModifiedDeclarationCode"`
+	GeneticallyModifiedDeclarationCode string `json:"geneticallyModifiedDeclarationCode" proto:"1"`
 	// Code value indicating the preservation technique used to preserve the product from
 	// deterioration. Uses code list preservationTechniqueCode.
-	PreservationTechniqueCode []string `json:"preservationTechniqueCode"`
+	PreservationTechniqueCode []string `json:"preservationTechniqueCode" proto:"2"`
 }
 
 // AVPList is attribute value pair information.
 type AVPList struct {
 	// Attribute values
-	StringAVPs []StringAVP `json:"stringAVP"`
+	StringAVPs []StringAVP `json:"stringAVP" proto:"1"`
 }
 
 // StringAVP presents Attribute values
 type StringAVP []struct {
-	AttributeValue string `json:"$"`
+	AttributeValue string `json:"$" proto:"1"`
 	//Normalised attribute name
-	AttributeName string `json:"@attributeName"`
+	AttributeName string `json:"@attributeName" proto:"2"`
 }
 
 // FoodAndBeverageIngredientModule contains information on the constituent ingredient make up of the product.
 type FoodAndBeverageIngredientModule struct {
 	// Information on the constituent ingredient make up of the product specified as one string.
-	IngredientStatements []IngredientStatement `json:"ingredientStatement"`
+	IngredientStatements []IngredientStatement `json:"ingredientStatement" proto:"1"`
 	// The fruit juice content of the trade item expressed as a percentage.
-	JuiceContentPercent float64 `json:"juiceContentPercent"`
+	JuiceContentPercent NullFloat64 `json:"juiceContentPercent" proto:"2"`
 	// Information on presence or absence of additives or genetic modifications contained in the trade item.
-	AdditiveInformations []AdditiveInformation `json:"additiveInformation"`
+	AdditiveInformations []AdditiveInformation `json:"additiveInformation" proto:"3"`
 	// Information on the constituent ingredient make up of the product split out per ingredient.
-	FoodAndBeverageIngredients []FoodAndBeverageIngredient `json:"foodAndBeverageIngredient"`
+	FoodAndBeverageIngredients []FoodAndBeverageIngredient `json:"foodAndBeverageIngredient" proto:"4"`
 	// Free text field for any additional ingredient information.
-	XAdditionalIngredientStatements []XAdditionalIngredientStatement `json:"x_additionalIngredientStatement"`
+	XAdditionalIngredientStatements []XAdditionalIngredientStatement `json:"x_additionalIngredientStatement" proto:"5"`
 	// Denotes that the product in question is either a food item or a beverage.
-	XIsFoodOrBeverage bool `json:"x_isFoodOrBeverage"`
+	XIsFoodOrBeverage NullBool `json:"x_isFoodOrBeverage" proto:"6"`
 }
 
 // IngredientStatement contains information on the constituent ingredient make up of the
 // product specified as one string.
 type IngredientStatement struct {
-	Name         string `json:"$"`
-	LanguageCode string `json:"@languageCode"`
+	Name         string `json:"$" proto:"1"`
+	LanguageCode string `json:"@languageCode" proto:"2"`
+}
+
+// LocalizedText returns t as a LocalizedText.
+func (t IngredientStatement) LocalizedText() LocalizedText {
+	return LocalizedText{Value: t.Name, LanguageCode: t.LanguageCode}
+}
+
+// IngredientStatementTexts returns the texts of s in order.
+func IngredientStatementTexts(s []IngredientStatement) []LocalizedText {
+	texts := make([]LocalizedText, len(s))
+	for i, t := range s {
+		texts[i] = t.LocalizedText()
+	}
+	return texts
 }
 
 // AdditiveInformation contains information on presence or absence of additives or genetic
 // modifications contained in the trade item.
 type AdditiveInformation struct {
 	// The name of any additive or genetic modification contained or not contained in the trade item.
-	AdditiveName string `json:"additiveName"`
+	AdditiveName string `json:"additiveName" proto:"1"`
 	// Code indicating the level of presence of the additive. Uses code list levelOfContainmentCode
-	LevelOfContainmentCode string `json:"levelOfContainmentCode"`
+	LevelOfContainmentCode string `json:"levelOfContainmentCode" proto:"2"`
 }
 
 // FoodAndBeverageIngredient contains information on the constituent ingredient make up of
 // the product split out per ingredient.
 type FoodAndBeverageIngredient struct {
 	// Value indicating the ingredient order.
-	IngredientSequence string `json:"ingredientSequence"`
+	IngredientSequence string `json:"ingredientSequence" proto:"1"`
 	// Indication of the percentage of the ingredient contained in the product.
-	IngredientContentPercentage float64 `json:"ingredientContentPercentage"`
+	IngredientContentPercentage NullFloat64 `json:"ingredientContentPercentage" proto:"2"`
 	// Text field indicating one ingredient or ingredient group (according to regulations of
 	// the target market). Ingredients include any additives (colorings, preservatives, e-numbers,
 	// etc) that are encompassed.
-	IngredientNames []IngredientName `json:"ingredientName"`
+	IngredientNames []IngredientName `json:"ingredientName" proto:"3"`
 	// Denotes that the ingredient should have it's text emphasised.
-	IsIngredientEmphasised bool `json:"isIngredientEmphasised"`
+	IsIngredientEmphasised bool `json:"isIngredientEmphasised" proto:"4"`
 	// Details on any methods and techniques used by a manufacturer or supplier to
 	// the trade item, ingredients or raw materials.
-	IngredientFarmingProcessing IngredientFarmingProcessing `json:"ingredientFarmingProcessing"`
+	IngredientFarmingProcessing IngredientFarmingProcessing `json:"ingredientFarmingProcessing" proto:"5"`
 	// Information on the organic nature of ingredient.
-	IngredientOrganicInformation IngredientOrganicInformation `json:"ingredientOrganicInformation"`
+	IngredientOrganicInformation IngredientOrganicInformation `json:"ingredientOrganicInformation" proto:"6"`
 	// Information on the activity (e.g. bottling) taken place for an ingredient as well as the associated geographic area.
-	IngredientPlaceOfActivities []IngredientPlaceOfActivity `json:"ingredientPlaceOfActivity"`
+	IngredientPlaceOfActivities []IngredientPlaceOfActivity `json:"ingredientPlaceOfActivity" proto:"7"`
 }
 
 // IngredientName is text field indicating one ingredient or ingredient group (according to regulations of the target market). Ingredients include any additives (colorings, preservatives, e-numbers, etc) that are encompassed.
 type IngredientName []struct {
-	Name         string `json:"$"`
-	LanguageCode string `json:"@languageCode"`
+	Name         string `json:"$" proto:"1"`
+	LanguageCode string `json:"@languageCode" proto:"2"`
 	// Substring emphasis. Emphases may overlap.
-	XEmphasis []IngredientXEmphasis `json:"x_emphasis"`
+	XEmphasis []IngredientXEmphasis `json:"x_emphasis" proto:"3"`
+}
+
+// LocalizedTexts returns the texts of n in order.
+func (n IngredientName) LocalizedTexts() []LocalizedText {
+	texts := make([]LocalizedText, len(n))
+	for i, t := range n {
+		texts[i] = LocalizedText{Value: t.Name, LanguageCode: t.LanguageCode}
+	}
+	return texts
+}
+
+// IngredientNameTexts returns the texts of s in order.
+func IngredientNameTexts(s []IngredientName) []LocalizedText {
+	var texts []LocalizedText
+	for _, n := range s {
+		texts = append(texts, n.LocalizedTexts()...)
+	}
+	return texts
 }
 
 // IngredientXEmphasis is a substring emphasis. Emphases may overlap.
 type IngredientXEmphasis struct {
 	// Emphasis starting index in characters from the beginning of the string. Index starts at zero.
-	StartAt int `json:"startAt"`
+	StartAt int `json:"startAt" proto:"1"`
 	// Emphasis length in characters
-	Length int `json:"length"`
+	Length int `json:"length" proto:"2"`
 }
 
 // IngredientFarmingProcessing details on any methods and techniques used by a manufacturer
@@ -583,10 +692,10 @@ type IngredientXEmphasis struct {
 type IngredientFarmingProcessing struct {
 	// A statement of the presence or absence of genetically modified protein or DNA.
 	// Uses code list geneticallyModifiedDeclarationCode.
-	GeneticallyModifiedDeclarationCode string `json:"geneticallyModifiedDeclarationCode"`
+	GeneticallyModifiedDeclarationCode string `json:"geneticallyModifiedDeclarationCode" proto:"1"`
 	// Code value indicating the preservation technique used to preserve the product from
 	// deterioration. Uses code list preservationTechniqueCode.
-	PreservationTechniqueCode []string `json:"preservationTechniqueCode"`
+	PreservationTechniqueCode []string `json:"preservationTechniqueCode" proto:"2"`
 }
 
 // IngredientOrganicInformation contains information on the organic nature of ingredient.
@@ -594,9 +703,9 @@ type IngredientOrganicInformation struct {
 	// Indication of the place where the agricultural raw materials of which the product is
 	// composed have been farmed. It applies only to the trade item, not ingredient by ingredient.
 	// Uses code list organicProductPlaceOfFarmingCode.
-	OrganicProductPlaceOfFarmingCode string `json:"organicProductPlaceOfFarmingCode"`
+	OrganicProductPlaceOfFarmingCode string `json:"organicProductPlaceOfFarmingCode" proto:"1"`
 	// Any claim to indicate the organic status of a trade item or of one or more of its components.
-	OrganicClaim []IngredientOrganicClaim `json:"organicClaim"`
+	OrganicClaim []IngredientOrganicClaim `json:"organicClaim" proto:"2"`
 }
 
 // IngredientOrganicClaim Any claim to indicate the organic status of a trade item or
@@ -604,44 +713,72 @@ type IngredientOrganicInformation struct {
 type IngredientOrganicClaim struct {
 	// A Governing body that creates and maintains standards related to organic products.
 	// Uses code list organicClaimAgencyCode.
-	OrganicClaimAgencyCode []string `json:"organicClaimAgencyCode"`
+	OrganicClaimAgencyCode []string `json:"organicClaimAgencyCode" proto:"1"`
 	// The percent of actual organic materials per weight of the trade item. This is
 	// usually claimed on the product
-	OrganicPercentClaim int `json:"organicPercentClaim"`
+	OrganicPercentClaim NullInt `json:"organicPercentClaim" proto:"2"`
 }
 
 // IngredientPlaceOfActivity contains information on the activity (e.g. bottling)
-//  taken place for an ingredient as well as the associated geographic area.
+// taken place for an ingredient as well as the associated geographic area.
 type IngredientPlaceOfActivity struct {
 	// A description of the country the item may have originated from or has been processed.
-	CountryOfOriginStatements []CountryOfOriginStatement `json:"countryOfOriginStatement"`
+	CountryOfOriginStatements []CountryOfOriginStatement `json:"countryOfOriginStatement" proto:"1"`
 	// The place a trade item originates from. This is to be specifically used to enable things
-	//  such as cities, mountain ranges, regions that do not comply with ISO standards.
-	ProvenanceStatements []ProvenanceStatement `json:"provenanceStatement"`
+	// such as cities, mountain ranges, regions that do not comply with ISO standards.
+	ProvenanceStatements []ProvenanceStatement `json:"provenanceStatement" proto:"2"`
 	// The country the item may have originated from or has been processed
-	CountryOfOrigins []CountryOfOrigin `json:"countryOfOrigin"`
+	CountryOfOrigins []CountryOfOrigin `json:"countryOfOrigin" proto:"3"`
 	// Details on the activity (e.g. bottling) taken place for a trade item as well as
 	// the associated geographic area.
-	ProductActivityDetails []ProductActivityDetail `json:"productActivityDetails"`
+	ProductActivityDetails []ProductActivityDetail `json:"productActivityDetails" proto:"4"`
 }
 
 // CountryOfOriginStatement is a description of the country the item may have originated from or has been processed.
 type CountryOfOriginStatement struct {
-	Value        string `json:"$"`
-	LanguageCode string `json:"@languageCode"`
+	Value        string `json:"$" proto:"1"`
+	LanguageCode string `json:"@languageCode" proto:"2"`
+}
+
+// LocalizedText returns t as a LocalizedText.
+func (t CountryOfOriginStatement) LocalizedText() LocalizedText {
+	return LocalizedText{Value: t.Value, LanguageCode: t.LanguageCode}
+}
+
+// CountryOfOriginStatementTexts returns the texts of s in order.
+func CountryOfOriginStatementTexts(s []CountryOfOriginStatement) []LocalizedText {
+	texts := make([]LocalizedText, len(s))
+	for i, t := range s {
+		texts[i] = t.LocalizedText()
+	}
+	return texts
 }
 
 // ProvenanceStatement is the place a trade item originates from. This is to be specifically
 // used to enable things such as cities, mountain ranges, regions that do not comply with ISO standards.
 type ProvenanceStatement struct {
-	Value        string `json:"$"`
-	LanguageCode string `json:"@languageCode"`
+	Value        string `json:"$" proto:"1"`
+	LanguageCode string `json:"@languageCode" proto:"2"`
+}
+
+// LocalizedText returns t as a LocalizedText.
+func (t ProvenanceStatement) LocalizedText() LocalizedText {
+	return LocalizedText{Value: t.Value, LanguageCode: t.LanguageCode}
+}
+
+// ProvenanceStatementTexts returns the texts of s in order.
+func ProvenanceStatementTexts(s []ProvenanceStatement) []LocalizedText {
+	texts := make([]LocalizedText, len(s))
+	for i, t := range s {
+		texts[i] = t.LocalizedText()
+	}
+	return texts
 }
 
 // CountryOfOrigin is the country the item may have originated from or has been processed
 type CountryOfOrigin struct {
 	// Code specifying a country. Use code list countryCode.
-	CountryCode string `json:"countryCode"`
+	CountryCode string `json:"countryCode" proto:"1"`
 }
 
 // ProductActivityDetail contains details on the activity (e.g. bottling) taken place for a
@@ -649,56 +786,88 @@ type CountryOfOrigin struct {
 type ProductActivityDetail struct {
 	// A code depicting the type of activity being performed on a trade item. Uses code
 	// list productActivityTypeCode
-	ProductActivityTypeCode string `json:"productActivityTypeCode"`
+	ProductActivityTypeCode string `json:"productActivityTypeCode" proto:"1"`
 	// Country where activity happens
-	CountryOfActivities []CountryOfActivity `json:"countryOfActivity"`
+	CountryOfActivities []CountryOfActivity `json:"countryOfActivity" proto:"2"`
 	// An external code value that depicts a specific zone or region for example a FAO Catch Zone.
-	ProductActivityRegionZoneCodeReferences []ProductActivityRegionZoneCodeReference `json:"productActivityRegionZoneCodeReference"`
+	ProductActivityRegionZoneCodeReferences []ProductActivityRegionZoneCodeReference `json:"productActivityRegionZoneCodeReference" proto:"3"`
 	// Free text field used to describe the activity region.
-	XStatements []XStatement `json:"x_statement"`
+	XStatements []XStatement `json:"x_statement" proto:"4"`
 }
 
 // CountryOfActivity contains country where activity happens
 type CountryOfActivity struct {
 	// Code specifying a country. Use code list countryCode
-	CountryCode string `json:"countryCode"`
+	CountryCode string `json:"countryCode" proto:"1"`
 }
 
 // ProductActivityRegionZoneCodeReference is an external code value that depicts a specific
-//  zone or region for example a FAO Catch Zone.
+// zone or region for example a FAO Catch Zone.
 type ProductActivityRegionZoneCodeReference struct {
 	// The name of the agency that manages a code list.
-	ExternalAgencyName string `json:"externalAgencyName"`
+	ExternalAgencyName string `json:"externalAgencyName" proto:"1"`
 	// The name of the code list maintained by an external agency.
-	ExternalCodeListName string `json:"externalCodeListName"`
+	ExternalCodeListName string `json:"externalCodeListName" proto:"2"`
 	// The version of the code list maintained by an external agency
-	ExternalCodeListVersion string `json:"externalCodeListVersion"`
+	ExternalCodeListVersion string `json:"externalCodeListVersion" proto:"3"`
 	// Code list values
-	EnumerationValueInformation []EnumerationValueInformation `json:"enumerationValueInformation"`
+	EnumerationValueInformation []EnumerationValueInformation `json:"enumerationValueInformation" proto:"4"`
 }
 
 // EnumerationValueInformation code list values
 type EnumerationValueInformation struct {
 	// Code List Value maintained by an external code list agency.
-	EnumerationValue string `json:"enumerationValue"`
+	EnumerationValue string `json:"enumerationValue" proto:"1"`
 }
 
 // XStatement contains free text field used to describe the activity region.
 type XStatement struct {
-	Statement    string `json:"$"`
-	LanguageCode string `json:"@languageCode"`
+	Statement    string `json:"$" proto:"1"`
+	LanguageCode string `json:"@languageCode" proto:"2"`
+}
+
+// LocalizedText returns t as a LocalizedText.
+func (t XStatement) LocalizedText() LocalizedText {
+	return LocalizedText{Value: t.Statement, LanguageCode: t.LanguageCode}
+}
+
+// XStatementTexts returns the texts of s in order.
+func XStatementTexts(s []XStatement) []LocalizedText {
+	texts := make([]LocalizedText, len(s))
+	for i, t := range s {
+		texts[i] = t.LocalizedText()
+	}
+	return texts
 }
 
 // XAdditionalIngredientStatement is a free text field for any additional ingredient information.
 type XAdditionalIngredientStatement []struct {
-	Statement    string `json:"$"`
-	LanguageCode string `json:"@languageCode"`
+	Statement    string `json:"$" proto:"1"`
+	LanguageCode string `json:"@languageCode" proto:"2"`
+}
+
+// LocalizedTexts returns the texts of n in order.
+func (n XAdditionalIngredientStatement) LocalizedTexts() []LocalizedText {
+	texts := make([]LocalizedText, len(n))
+	for i, t := range n {
+		texts[i] = LocalizedText{Value: t.Statement, LanguageCode: t.LanguageCode}
+	}
+	return texts
+}
+
+// XAdditionalIngredientStatementTexts returns the texts of s in order.
+func XAdditionalIngredientStatementTexts(s []XAdditionalIngredientStatement) []LocalizedText {
+	var texts []LocalizedText
+	for _, n := range s {
+		texts = append(texts, n.LocalizedTexts()...)
+	}
+	return texts
 }
 
 // FoodAndBeveragePreparationServingModule is information on way the product can be prepared or served.
 type FoodAndBeveragePreparationServingModule struct {
 	// Preparation and serving information for a food and beverage item.
-	PreparationServings []PreparationServing `json:"preparationServing"`
+	PreparationServings []PreparationServing `json:"preparationServing" proto:"1"`
 }
 
 // PreparationServing contains preparation and serving information for a food and beverage item.
@@ -707,48 +876,76 @@ type PreparationServing struct {
 	// The convenience level indicates the level of preparation in percentage
 	// required to prepare and helps the consumer to assess how long it will take
 	// to prepare the meal.
-	ConvenienceLevelPercent int `json:"convenienceLevelPercent"`
+	ConvenienceLevelPercent NullInt `json:"convenienceLevelPercent" proto:"1"`
 	// Textual instruction on how to prepare the product before serving.
-	PreparationInstructions []PreparationInstruction `json:"preparationInstructions"`
+	PreparationInstructions []PreparationInstruction `json:"preparationInstructions" proto:"2"`
 	// A code specifying the technique used to make the product ready for consumption. Uses code list preparationTypeCode.
-	PreparationTypeCode string `json:"preparationTypeCode"`
+	PreparationTypeCode string `json:"preparationTypeCode" proto:"3"`
 	// Free text field for serving suggestion.
-	ServingSuggestions []ServingSuggestion `json:"servingSuggestion"`
+	ServingSuggestions []ServingSuggestion `json:"servingSuggestion" proto:"4"`
 	// Information on the yield of a product.
-	ProductYieldInformations []ProductYieldInformation `json:"productYieldInformation"`
+	ProductYieldInformations []ProductYieldInformation `json:"productYieldInformation" proto:"5"`
 }
 
 // PreparationInstruction textual instruction on how to prepare the product before serving.
 type PreparationInstruction struct {
-	Instruction  string `json:"$"`
-	LanguageCode string `json:"@languageCode"`
+	Instruction  string `json:"$" proto:"1"`
+	LanguageCode string `json:"@languageCode" proto:"2"`
+}
+
+// LocalizedText returns t as a LocalizedText.
+func (t PreparationInstruction) LocalizedText() LocalizedText {
+	return LocalizedText{Value: t.Instruction, LanguageCode: t.LanguageCode}
+}
+
+// PreparationInstructionTexts returns the texts of s in order.
+func PreparationInstructionTexts(s []PreparationInstruction) []LocalizedText {
+	texts := make([]LocalizedText, len(s))
+	for i, t := range s {
+		texts[i] = t.LocalizedText()
+	}
+	return texts
 }
 
 // ServingSuggestion is a ree text field for serving suggestion.
 type ServingSuggestion struct {
-	Suggestion   string `json:"$"`
-	LanguageCode string `json:"@languageCode"`
+	Suggestion   string `json:"$" proto:"1"`
+	LanguageCode string `json:"@languageCode" proto:"2"`
+}
+
+// LocalizedText returns t as a LocalizedText.
+func (t ServingSuggestion) LocalizedText() LocalizedText {
+	return LocalizedText{Value: t.Suggestion, LanguageCode: t.LanguageCode}
+}
+
+// ServingSuggestionTexts returns the texts of s in order.
+func ServingSuggestionTexts(s []ServingSuggestion) []LocalizedText {
+	texts := make([]LocalizedText, len(s))
+	for i, t := range s {
+		texts[i] = t.LocalizedText()
+	}
+	return texts
 }
 
 // ProductYieldInformation is a information on the yield of a product.
 type ProductYieldInformation struct {
 	// Measurement
-	ProductYield ProductYieldMeasurement `json:"productYield"`
+	ProductYield ProductYieldMeasurement `json:"productYield" proto:"1"`
 	// Code indicating the type of yield measurement. Uses code list productYieldTypeCode.
-	ProductYieldTypeCode string `json:"productYieldTypeCode"`
+	ProductYieldTypeCode string `json:"productYieldTypeCode" proto:"2"`
 }
 
 // ProductYieldMeasurement represents measurement
 type ProductYieldMeasurement struct {
-	Measurement         int    `json:"$"`
-	MeasurementUnitCode string `json:"@measurementUnitCode"`
+	Measurement         Decimal `json:"$" proto:"1"`
+	MeasurementUnitCode string  `json:"@measurementUnitCode" proto:"2"`
 }
 
 // FoodAndBeveragePropertiesInformationModule contains information on
 // physiochemical or other properties of food and beverage products.
 type FoodAndBeveragePropertiesInformationModule struct {
 	// Information on the product's physicochemical characteristics.
-	PhysiochemicalCharacteristics []PhysiochemicalCharacteristic `json:"physiochemicalCharacteristic"`
+	PhysiochemicalCharacteristics []PhysiochemicalCharacteristic `json:"physiochemicalCharacteristic" proto:"1"`
 }
 
 // PhysiochemicalCharacteristic is an information on the product's
@@ -756,223 +953,307 @@ type FoodAndBeveragePropertiesInformationModule struct {
 type PhysiochemicalCharacteristic struct {
 	// Code indicating the type of physiochemical characteristic. Use code list
 	// physiochemicalCharacteristicCode.
-	PhysiochemicalCharacteristicCode string `json:"physiochemicalCharacteristicCode"`
+	PhysiochemicalCharacteristicCode string `json:"physiochemicalCharacteristicCode" proto:"1"`
 	// Measurement value of the physicochemical characteristic.
-	PhysiochemicalCharacteristicValues []PhysiochemicalCharacteristicValue `json:"physiochemicalCharacteristicValue"`
+	PhysiochemicalCharacteristicValues []PhysiochemicalCharacteristicValue `json:"physiochemicalCharacteristicValue" proto:"2"`
 }
 
 // PhysiochemicalCharacteristicValue is a measurement value.
 type PhysiochemicalCharacteristicValue struct {
-	Measurement         float64 `json:"$"`
-	MeasurementUnitCode string  `json:"@measurementUnitCode"`
+	Measurement         Decimal `json:"$" proto:"1"`
+	MeasurementUnitCode string  `json:"@measurementUnitCode" proto:"2"`
 }
 
 // MarketingInformationModule contains information of a trade item meant to
 // convey features and benefits and targeted customer.
 type MarketingInformationModule struct {
 	// Information on a trade item meant to convey features and benefits.
-	MarketingInformation MarketingInformation `json:"marketingInformation"`
+	MarketingInformation MarketingInformation `json:"marketingInformation" proto:"1"`
 }
 
 // MarketingInformation contains information of a trade item meant to convey features and benefits.
 type MarketingInformation struct {
 	// Marketing message associated to the Trade item.
-	TradeItemMarketingMessages []TradeItemMarketingMessage `json:"tradeItemMarketingMessage"`
+	TradeItemMarketingMessages []TradeItemMarketingMessage `json:"tradeItemMarketingMessage" proto:"1"`
 	// Words or phrases that enables web search engines to find trade items on the internet
 	// for example Shampoo, Lather, Baby.
-	TradeItemKeyWords []TradeItemKeyWord `json:"tradeItemKeyWords"`
+	TradeItemKeyWords []TradeItemKeyWord `json:"tradeItemKeyWords" proto:"2"`
 	// An indicator whether or not the Trade Item is excluded and hidden from promotions.
 	// When not defined, assumed to be false.
-	XHideTradeItemFromPromotions bool `json:"x_hideTradeItemFromPromotions"`
+	XHideTradeItemFromPromotions bool `json:"x_hideTradeItemFromPromotions" proto:"3"`
 }
 
 // TradeItemMarketingMessage contains marketing message associated to the Trade item.
 type TradeItemMarketingMessage struct {
-	Message      string `json:"$"`
-	LanguageCode string `json:"@languageCode"`
+	Message      string `json:"$" proto:"1"`
+	LanguageCode string `json:"@languageCode" proto:"2"`
+}
+
+// LocalizedText returns t as a LocalizedText.
+func (t TradeItemMarketingMessage) LocalizedText() LocalizedText {
+	return LocalizedText{Value: t.Message, LanguageCode: t.LanguageCode}
+}
+
+// TradeItemMarketingMessageTexts returns the texts of s in order.
+func TradeItemMarketingMessageTexts(s []TradeItemMarketingMessage) []LocalizedText {
+	texts := make([]LocalizedText, len(s))
+	for i, t := range s {
+		texts[i] = t.LocalizedText()
+	}
+	return texts
 }
 
 // TradeItemKeyWord contains words or phrases that enables web search engines
-//  to find trade items on the internet for example Shampoo, Lather, Baby.
+// to find trade items on the internet for example Shampoo, Lather, Baby.
 type TradeItemKeyWord struct {
-	KeyWord      string `json:"$"`
-	LanguageCode string `json:"@languageCode"`
+	KeyWord      string `json:"$" proto:"1"`
+	LanguageCode string `json:"@languageCode" proto:"2"`
+}
+
+// LocalizedText returns t as a LocalizedText.
+func (t TradeItemKeyWord) LocalizedText() LocalizedText {
+	return LocalizedText{Value: t.KeyWord, LanguageCode: t.LanguageCode}
+}
+
+// TradeItemKeyWordTexts returns the texts of s in order.
+func TradeItemKeyWordTexts(s []TradeItemKeyWord) []LocalizedText {
+	texts := make([]LocalizedText, len(s))
+	for i, t := range s {
+		texts[i] = t.LocalizedText()
+	}
+	return texts
 }
 
 // NonfoodIngredientModule is a module providing Information on ingredients for
 // items that are not food for example detergents, medicines.
 type NonfoodIngredientModule struct {
-	//  Ingredient statement for non-food items.
-	NonfoodIngredientStatements []NonfoodIngredientStatement `json:"nonfoodIngredientStatement"`
+	// Ingredient statement for non-food items.
+	NonfoodIngredientStatements []NonfoodIngredientStatement `json:"nonfoodIngredientStatement" proto:"1"`
 	// Specifies a non-food ingredient of concern for a trade item as a code.
 	// Uses code list nonfoodIngredientOfConcernCode.
-	NonfoodIngredientOfConcernCode []string `json:"nonfoodIngredientOfConcernCode"`
+	NonfoodIngredientOfConcernCode []string `json:"nonfoodIngredientOfConcernCode" proto:"2"`
 	// Information on presence or absence of additives or genetic modifications
 	// contained in the trade item.
-	AdditiveInformations []NonFoodAdditiveInformation `json:"additiveInformation"`
+	AdditiveInformations []NonFoodAdditiveInformation `json:"additiveInformation" proto:"3"`
 	// Information on ingredients for items that are not food for example
 	// detergents, medicines.
-	NonfoodIngredients []NonfoodIngredient `json:"nonfoodIngredient"`
+	NonfoodIngredients []NonfoodIngredient `json:"nonfoodIngredient" proto:"4"`
 }
 
 // NonfoodIngredientStatement is a ingredient statement for non-food items.
 type NonfoodIngredientStatement struct {
-	Statement    string `json:"$"`
-	LanguageCode string `json:"@languageCode"`
+	Statement    string `json:"$" proto:"1"`
+	LanguageCode string `json:"@languageCode" proto:"2"`
+}
+
+// LocalizedText returns t as a LocalizedText.
+func (t NonfoodIngredientStatement) LocalizedText() LocalizedText {
+	return LocalizedText{Value: t.Statement, LanguageCode: t.LanguageCode}
+}
+
+// NonfoodIngredientStatementTexts returns the texts of s in order.
+func NonfoodIngredientStatementTexts(s []NonfoodIngredientStatement) []LocalizedText {
+	texts := make([]LocalizedText, len(s))
+	for i, t := range s {
+		texts[i] = t.LocalizedText()
+	}
+	return texts
 }
 
 // NonFoodAdditiveInformation contains information on presence or absence of additives
 type NonFoodAdditiveInformation struct {
 	// Name of additive ingredient
-	AdditiveName string `json:"additiveName"`
+	AdditiveName string `json:"additiveName" proto:"1"`
 	// Code indicating the level of presence of the additive. Uses code list levelOfContainmentCode.
-	LevelOfContainmentCode string `json:"levelOfContainmentCode"`
+	LevelOfContainmentCode string `json:"levelOfContainmentCode" proto:"2"`
 }
 
 // NonfoodIngredient contains information on ingredients for items that are not food
 // for example detergents, medicines.
 type NonfoodIngredient struct {
 	// The name of the non-food ingredient.
-	IngredientName string `json:"ingredientName"`
+	IngredientName string `json:"ingredientName" proto:"1"`
 	// Denotes the nonfood ingredient that should have it's text emphasised in
 	// some fashion on the item's packaging.
-	IsNonfoodIngredientEmphasized bool `json:"isNonfoodIngredientEmphasized"`
+	IsNonfoodIngredientEmphasized bool `json:"isNonfoodIngredientEmphasized" proto:"2"`
 	// Substring emphasis for ingredientName.
-	XEmphasis []XEmphasis `json:"x_emphasis"`
+	XEmphasis []XEmphasis `json:"x_emphasis" proto:"3"`
 }
 
 // NutritionalInformationModule contains information about content of nutrients.
-//  Multiple sets of nutrient information can be specified with varying state,
+// Multiple sets of nutrient information can be specified with varying state,
 // serving size and daily value intake base.
 type NutritionalInformationModule struct {
 	// Free text field for any additional nutritional claims.
-	NutritionalClaims []NutritionalClaim `json:"nutritionalClaim"`
+	NutritionalClaims []NutritionalClaim `json:"nutritionalClaim" proto:"1"`
 	// Details on a nutritional claim for a trade item permitted by known regulations for a target market.
-	NutritionalClaimDetails []NutritionalClaimDetail `json:"nutritionalClaimDetail"`
+	NutritionalClaimDetails []NutritionalClaimDetail `json:"nutritionalClaimDetail" proto:"2"`
 	// Nutrient information for a trade item.
-	NutrientHeaders []NutrientHeader `json:"nutrientHeader"`
+	NutrientHeaders []NutrientHeader `json:"nutrientHeader" proto:"3"`
 }
 
 // NutritionalClaim is a free text field for any additional nutritional claims.
 type NutritionalClaim struct {
-	Claim        string `json:"$"`
-	LanguageCode string `json:"@languageCode"`
+	Claim        string `json:"$" proto:"1"`
+	LanguageCode string `json:"@languageCode" proto:"2"`
+}
+
+// LocalizedText returns t as a LocalizedText.
+func (t NutritionalClaim) LocalizedText() LocalizedText {
+	return LocalizedText{Value: t.Claim, LanguageCode: t.LanguageCode}
+}
+
+// NutritionalClaimTexts returns the texts of s in order.
+func NutritionalClaimTexts(s []NutritionalClaim) []LocalizedText {
+	texts := make([]LocalizedText, len(s))
+	for i, t := range s {
+		texts[i] = t.LocalizedText()
+	}
+	return texts
 }
 
 // NutritionalClaimDetail contains Details on a nutritional claim for a trade
 // item permitted by known regulations for a target market.
 type NutritionalClaimDetail struct {
 	// A code depicting the degree to which a trade item contains a specific nutrient
-	//  or ingredient in relation to a health claim. Uses code list nutritionalClaimTypeCode.
-	NutritionalClaimTypeCode string `json:"nutritionalClaimTypeCode"`
+	// or ingredient in relation to a health claim. Uses code list nutritionalClaimTypeCode.
+	NutritionalClaimTypeCode string `json:"nutritionalClaimTypeCode" proto:"1"`
 	// The type of nutrient, ingredient, vitamins and minerals that the nutritional claim is
-	//  in reference to for example fat, copper, milk. Uses code list nutritionalClaimNutrientElementCode.
-	NutritionalClaimNutrientElementCode string `json:"nutritionalClaimNutrientElementCode"`
+	// in reference to for example fat, copper, milk. Uses code list nutritionalClaimNutrientElementCode.
+	NutritionalClaimNutrientElementCode string `json:"nutritionalClaimNutrientElementCode" proto:"2"`
 }
 
 // NutrientHeader contains nutrient  information for a trade item.
 type NutrientHeader struct {
 	// Code specifying the preparation state or type the nutrient information
-	//  applies to, for example, unprepared, boiled, fried. Uses code
-	//  list preparationStateCode.
-	PreparationStateCode string `json:"preparationStateCode"`
+	// applies to, for example, unprepared, boiled, fried. Uses code
+	// list preparationStateCode.
+	PreparationStateCode string `json:"preparationStateCode" proto:"1"`
 	// Free text field specifying the daily value intake base for on which
 	// the daily value intake per nutrient has been based.
-	DailyValueIntakeReferences DailyValueIntakeReference `json:"dailyValueIntakeReference"`
+	DailyValueIntakeReferences DailyValueIntakeReference `json:"dailyValueIntakeReference" proto:"2"`
 	// Unit of measure code. Uses code list measurementUnitCode.
-	NutrientBasisQuantity NutrientBasisQuantity `json:"nutrientBasisQuantity"`
+	NutrientBasisQuantity NutrientBasisQuantity `json:"nutrientBasisQuantity" proto:"3"`
 	// Measurement value specifying the serving size in which the information
-	//  per nutrient has been stated.
-	ServingSizes []ServingSize `json:"servingSize"`
+	// per nutrient has been stated.
+	ServingSizes []ServingSize `json:"servingSize" proto:"4"`
 	// A free text field specifying the serving size for which the nutrient information has been stated.
-	ServingSizeDescriptions []ServingSizeDescription `json:"servingSizeDescription"`
+	ServingSizeDescriptions []ServingSizeDescription `json:"servingSizeDescription" proto:"5"`
 	// Nutrient detail for a trade item.
-	NutrientDetails []NutrientDetail `json:"nutrientDetail"`
+	NutrientDetails []NutrientDetail `json:"nutrientDetail" proto:"6"`
 }
 
 // DailyValueIntakeReference is a free text field specifying the daily value intake base
 // for on which the daily value intake per nutrient has been based.
 type DailyValueIntakeReference struct {
-	Value        string `json:"$"`
-	LanguageCode string `json:"@languageCode"`
+	Value        string `json:"$" proto:"1"`
+	LanguageCode string `json:"@languageCode" proto:"2"`
+}
+
+// LocalizedText returns t as a LocalizedText.
+func (t DailyValueIntakeReference) LocalizedText() LocalizedText {
+	return LocalizedText{Value: t.Value, LanguageCode: t.LanguageCode}
+}
+
+// DailyValueIntakeReferenceTexts returns the texts of s in order.
+func DailyValueIntakeReferenceTexts(s []DailyValueIntakeReference) []LocalizedText {
+	texts := make([]LocalizedText, len(s))
+	for i, t := range s {
+		texts[i] = t.LocalizedText()
+	}
+	return texts
 }
 
 // NutrientBasisQuantity is a unit of measure code. Uses code list measurementUnitCode.
 type NutrientBasisQuantity struct {
-	Measurement         int    `json:"$"`
-	MeasurementUnitCode string `json:"@measurementUnitCode"`
+	Measurement         Decimal `json:"$" proto:"1"`
+	MeasurementUnitCode string  `json:"@measurementUnitCode" proto:"2"`
 }
 
 // ServingSize is a measurement value specifying the serving size in which the
-//  information per nutrient has been stated.
+// information per nutrient has been stated.
 type ServingSize struct {
-	Measurement         int    `json:"$"`
-	MeasurementUnitCode string `json:"@measurementUnitCode"`
+	Measurement         Decimal `json:"$" proto:"1"`
+	MeasurementUnitCode string  `json:"@measurementUnitCode" proto:"2"`
 }
 
 // ServingSizeDescription is a free text field specifying the serving size
 // for which the nutrient information has been stated.
 type ServingSizeDescription struct {
-	Description  string `json:"$"`
-	LanguageCode string `json:"@languageCode"`
+	Description  string `json:"$" proto:"1"`
+	LanguageCode string `json:"@languageCode" proto:"2"`
+}
+
+// LocalizedText returns t as a LocalizedText.
+func (t ServingSizeDescription) LocalizedText() LocalizedText {
+	return LocalizedText{Value: t.Description, LanguageCode: t.LanguageCode}
+}
+
+// ServingSizeDescriptionTexts returns the texts of s in order.
+func ServingSizeDescriptionTexts(s []ServingSizeDescription) []LocalizedText {
+	texts := make([]LocalizedText, len(s))
+	for i, t := range s {
+		texts[i] = t.LocalizedText()
+	}
+	return texts
 }
 
 // NutrientDetail describes nutrient detail for a trade item.
 type NutrientDetail struct {
 	// Nutrient type code. Uses code list nutrientTypeCode.
-	NutrientTypeCode string `json:"nutrientTypeCode"`
+	NutrientTypeCode string `json:"nutrientTypeCode" proto:"1"`
 	// The percentage of the recommended daily intake of a nutrient as
 	// recommended by authorities of the target market. Is expressed relative
-	//  to the serving size and base daily value intake.
-	DailyValueIntakePercent float64 `json:"dailyValueIntakePercent"`
+	// to the serving size and base daily value intake.
+	DailyValueIntakePercent NullFloat64 `json:"dailyValueIntakePercent" proto:"2"`
 	// Code indicating whether the specified nutrient content is exact or
 	// approximate. One should follow local regulatory guidelines when
 	// selecting a precision. Uses code list measurementPrecisionCode.
-	MeasurementPrecisionCode string `json:"measurementPrecisionCode"`
+	MeasurementPrecisionCode string `json:"measurementPrecisionCode" proto:"3"`
 	// Measurement value indicating the amount of nutrient contained
-	//  in the product. Is expressed relative to the serving size.
-	QuantityContaineds []QuantityContained `json:"quantityContained"`
+	// in the product. Is expressed relative to the serving size.
+	QuantityContaineds []QuantityContained `json:"quantityContained" proto:"4"`
 }
 
 // QuantityContained is a measurement value indicating the amount of nutrient
 // contained in the product. Is expressed relative to the serving size.
 type QuantityContained struct {
-	Measurement         int    `json:"$"`
-	MeasurementUnitCode string `json:"@measurementUnitCode"`
+	Measurement         Decimal `json:"$" proto:"1"`
+	MeasurementUnitCode string  `json:"@measurementUnitCode" proto:"2"`
 }
 
 // PackagingInformationModule  contains packaging information for a trade item.
 type PackagingInformationModule struct {
 	// Details on packaging for a trade item.
-	Packagings []Packaging `json:"packaging"`
+	Packagings []Packaging `json:"packaging" proto:"1"`
 }
 
 // Packaging details for a trade item.
 type Packaging struct {
 	// The process the packaging could undertake for recyclable & sustainability programs.
-	PackagingRecyclingProcessTypeCode []string `json:"packagingRecyclingProcessTypeCode"`
+	PackagingRecyclingProcessTypeCode []string `json:"packagingRecyclingProcessTypeCode" proto:"1"`
 	// The dominant means used to transport, store, handle or display the trade
 	// item as defined by the data source. This packaging is not used to describe
-	//  any manufacturing process.Uses code list packagingTypeCode.
-	PackagingTypeCode string `json:"packagingTypeCode"`
+	// any manufacturing process.Uses code list packagingTypeCode.
+	PackagingTypeCode string `json:"packagingTypeCode" proto:"2"`
 	// Details on packaging material for a trade item's packaging.
-	PackagingMaterials []PackagingMaterial `json:"packagingMaterial"`
+	PackagingMaterials []PackagingMaterial `json:"packagingMaterial" proto:"3"`
 }
 
 // PackagingMaterial is details on packaging material for a trade item's packaging.
 type PackagingMaterial struct {
 	// The materials used for the packaging of the trade item. Uses code list packagingMaterialTypeCode.
-	PackagingMaterialTypeCode string `json:"packagingMaterialTypeCode"`
+	PackagingMaterialTypeCode string `json:"packagingMaterialTypeCode" proto:"1"`
 	// Determines whether packaging material is recoverable. Recoverable materials are those which
 	// are capable of beingreused or returned to use in the form of raw materials.
-	IsPackagingMaterialRecoverable bool `json:"isPackagingMaterialRecoverable"`
+	IsPackagingMaterialRecoverable NullBool `json:"isPackagingMaterialRecoverable" proto:"2"`
 }
 
 // PackagingMarkingModule is a module containing details on markings on the
 // packaging of the trade item for example dates, environment.
 type PackagingMarkingModule struct {
 	// Details on markings on the packaging of the trade item.
-	PackagingMarking PackagingMarking `json:"packagingMarking"`
+	PackagingMarking PackagingMarking `json:"packagingMarking" proto:"1"`
 }
 
 // PackagingMarking is details on markings on the packaging of the trade item.
@@ -980,7 +1261,7 @@ type PackagingMarking struct {
 	// A marking that the trade item received recognition, endorsement, certification by
 	// following guidelines by the label issuing agency. Uses code list
 	// packagingMarkedLabelAccreditationCode.
-	PackagingMarkedLabelAccreditationCode []string `json:"packagingMarkedLabelAccreditationCode"`
+	PackagingMarkedLabelAccreditationCode []string `json:"packagingMarkedLabelAccreditationCode" proto:"1"`
 }
 
 // PlaceOfItemActivityModule contains information on the activity (e.g. bottling)
@@ -988,22 +1269,22 @@ type PackagingMarking struct {
 type PlaceOfItemActivityModule struct {
 	// Information on the activity (e.g. bottling) taken place for a trade
 	// item as well as the associated geographic area.
-	PlaceOfProductActivity PlaceOfProductActivity `json:"placeOfProductActivity"`
+	PlaceOfProductActivity PlaceOfProductActivity `json:"placeOfProductActivity" proto:"1"`
 }
 
 // PlaceOfProductActivity contains information on the activity (e.g. bottling)
-//  taken place for a trade item as well as the associated geographic area.
+// taken place for a trade item as well as the associated geographic area.
 type PlaceOfProductActivity struct {
 	// A description of the country the item may have originated from or has been processed.
-	CountryOfOriginStatements []CountryOfOriginStatement `json:"countryOfOriginStatement"`
+	CountryOfOriginStatements []CountryOfOriginStatement `json:"countryOfOriginStatement" proto:"1"`
 	// The place a trade item originates from. This is to be specifically used to enable
 	// things such as cities, mountain ranges, regions that do not comply with ISO standards.
-	ProvenanceStatements []ProvenanceStatement `json:"provenanceStatement"`
+	ProvenanceStatements []ProvenanceStatement `json:"provenanceStatement" proto:"2"`
 	// The country the item may have originated from or has been processed.
-	CountryOfOrigins []CountryOfOrigin `json:"countryOfOrigin"`
+	CountryOfOrigins []CountryOfOrigin `json:"countryOfOrigin" proto:"3"`
 	// Details on the activity (e.g. bottling) taken place for a trade item
 	// as well as the associated geographic area.
-	ProductActivityDetails []ProductActivityDetail `json:"productActivityDetails"`
+	ProductActivityDetails []ProductActivityDetail `json:"productActivityDetails" proto:"4"`
 }
 
 // ProductCharacteristicsModule is a module used to express characteristics
@@ -1011,137 +1292,183 @@ type PlaceOfProductActivity struct {
 type ProductCharacteristicsModule struct {
 	// A characteristic for a product for example values for a property such as
 	// numberOfPlys along with its associated value.
-	ProductCharacteristics []ProductCharacteristic `json:"productCharacteristics"`
+	ProductCharacteristics []ProductCharacteristic `json:"productCharacteristics" proto:"1"`
 }
 
 // ProductCharacteristic describes characteristic for a product for example
-//  values for a property such as numberOfPlys along with its associated value.
+// values for a property such as numberOfPlys along with its associated value.
 type ProductCharacteristic struct {
 	// The name of the product characteristic being described.Uses code list productCharacteristicCode.
-	ProductCharacteristicCode string `json:"productCharacteristicCode"`
+	ProductCharacteristicCode string `json:"productCharacteristicCode" proto:"1"`
 	// The product characteristic value expressed as a description (text with language).
-	ProductCharacteristicValueDescriptions []ProductCharacteristicValueDescription `json:"productCharacteristicValueDescription"`
+	ProductCharacteristicValueDescriptions []ProductCharacteristicValueDescription `json:"productCharacteristicValueDescription" proto:"2"`
 	// The product characteristic value expressed as a string (text value with no language).
-	ProductCharacteristicValueString []string `json:"productCharacteristicValueString"`
+	ProductCharacteristicValueString []string `json:"productCharacteristicValueString" proto:"3"`
 }
 
 // ProductCharacteristicValueDescription expresses a product characteristic as a
 // description (text with language).
 type ProductCharacteristicValueDescription []struct {
-	Description  string `json:"$"`
-	LanguageCode string `json:"@languageCode"`
+	Description  string `json:"$" proto:"1"`
+	LanguageCode string `json:"@languageCode" proto:"2"`
+}
+
+// LocalizedTexts returns the texts of n in order.
+func (n ProductCharacteristicValueDescription) LocalizedTexts() []LocalizedText {
+	texts := make([]LocalizedText, len(n))
+	for i, t := range n {
+		texts[i] = LocalizedText{Value: t.Description, LanguageCode: t.LanguageCode}
+	}
+	return texts
+}
+
+// ProductCharacteristicValueDescriptionTexts returns the texts of s in order.
+func ProductCharacteristicValueDescriptionTexts(s []ProductCharacteristicValueDescription) []LocalizedText {
+	var texts []LocalizedText
+	for _, n := range s {
+		texts = append(texts, n.LocalizedTexts()...)
+	}
+	return texts
 }
 
 // SafetyDataSheetModule is a module containing information usually contained
-//  on a safety data sheet or on a material safety data sheet as it is referred
-//  to in some target markets.
+// on a safety data sheet or on a material safety data sheet as it is referred
+// to in some target markets.
 type SafetyDataSheetModule struct {
 	// Trade item information usually contained on a safety data sheet
-	//  or on a material safety data sheet as it is referred to in some
-	//  target markets.
-	SafetyDataSheetInformations []SafetyDataSheetInformation `json:"safetyDataSheetInformation"`
+	// or on a material safety data sheet as it is referred to in some
+	// target markets.
+	SafetyDataSheetInformations []SafetyDataSheetInformation `json:"safetyDataSheetInformation" proto:"1"`
 }
 
 // SafetyDataSheetInformation contains trade item information usually contained on a safety
-//  data sheet or on a material safety data sheet as it is referred to in some target markets.
+// data sheet or on a material safety data sheet as it is referred to in some target markets.
 type SafetyDataSheetInformation struct {
 	// An indicator whether the Trade Item is regulated for shipment by any agency.
-	IsRegulatedForTransportation bool `json:"isRegulatedForTransportation"`
+	IsRegulatedForTransportation NullBool `json:"isRegulatedForTransportation" proto:"1"`
 	// Details related to the Globally Harmonized System of Classification and Labelling of Chemicals.
-	GHSDetail GHSDetail `json:"gHSDetail"`
+	GHSDetail GHSDetail `json:"gHSDetail" proto:"2"`
 	// Information on Physical or Chemical Properties for a trade item for example water solubility.
-	PhysicalChemicalPropertyInformation PhysicalChemicalPropertyInformation `json:"physicalChemicalPropertyInformation"`
+	PhysicalChemicalPropertyInformation PhysicalChemicalPropertyInformation `json:"physicalChemicalPropertyInformation" proto:"3"`
 }
 
 // GHSDetail Details related to the Globally Harmonized System of Classification and Labelling of Chemicals.
 type GHSDetail struct {
 	// Words such as "Danger" or "Warning" used to emphasize hazards and indicate
-	//  the relative level of severity of the hazard. For GHS these are assigned to
-	//  a GHS hazard class and category. Some lower level hazard categories do not use
-	//  signal words. Uses code list gHSSignalWordsCode.
-	GHSSignalWordsCode string `json:"gHSSignalWordsCode"`
+	// the relative level of severity of the hazard. For GHS these are assigned to
+	// a GHS hazard class and category. Some lower level hazard categories do not use
+	// signal words. Uses code list gHSSignalWordsCode.
+	GHSSignalWordsCode string `json:"gHSSignalWordsCode" proto:"1"`
 	// A code depicting the symbols which convey health, physical and environmental
 	// hazard information, assigned to a hazard class and category for example GHS.
 	// Pictograms include the harmonized hazard symbols plus other graphic elements,
 	// such as borders, background patterns or colours that are intended to convey
-	//  specific information. Examples of all the pictograms and downloadable files
-	//  for GHS can be accessed on the UN website for the GHS. Uses code list
-	//  gHSSymbolDescriptionCode.
-	GHSSymbolDescriptionCode []string `json:"gHSSymbolDescriptionCode"`
+	// specific information. Examples of all the pictograms and downloadable files
+	// for GHS can be accessed on the UN website for the GHS. Uses code list
+	// gHSSymbolDescriptionCode.
+	GHSSymbolDescriptionCode []string `json:"gHSSymbolDescriptionCode" proto:"2"`
 	// Standard phrases describing the nature of a hazard per GHS.
-	HazardStatements []HazardStatement `json:"hazardStatement"`
+	HazardStatements []HazardStatement `json:"hazardStatement" proto:"3"`
 	// Measures listed on a hazardous label to minimize or prevent adverse
 	// effects related to GHS.
-	PrecautionaryStatements []PrecautionaryStatement `json:"precautionaryStatement"`
+	PrecautionaryStatements []PrecautionaryStatement `json:"precautionaryStatement" proto:"4"`
 }
 
 // HazardStatement contains standard phrases describing the nature of a hazard per GHS.
 type HazardStatement struct {
 	// Standard phrases assigned to a hazard class and category that describe the
 	// nature of the hazard.
-	HazardStatementsCode string `json:"hazardStatementsCode"`
+	HazardStatementsCode string `json:"hazardStatementsCode" proto:"1"`
 	// A description of standard phrases assigned to a hazard class and category
 	// that describe the nature of the hazard.
-	HazardStatementsDescriptions []HazardStatementsDescription `json:"hazardStatementsDescription"`
+	HazardStatementsDescriptions []HazardStatementsDescription `json:"hazardStatementsDescription" proto:"2"`
 }
 
 // HazardStatementsDescription is a description of standard phrases assigned to
 // a hazard class and category that describe the nature of the hazard.
 type HazardStatementsDescription struct {
-	Description  string `json:"$"`
-	LanguageCode string `json:"@languageCode"`
+	Description  string `json:"$" proto:"1"`
+	LanguageCode string `json:"@languageCode" proto:"2"`
+}
+
+// LocalizedText returns t as a LocalizedText.
+func (t HazardStatementsDescription) LocalizedText() LocalizedText {
+	return LocalizedText{Value: t.Description, LanguageCode: t.LanguageCode}
+}
+
+// HazardStatementsDescriptionTexts returns the texts of s in order.
+func HazardStatementsDescriptionTexts(s []HazardStatementsDescription) []LocalizedText {
+	texts := make([]LocalizedText, len(s))
+	for i, t := range s {
+		texts[i] = t.LocalizedText()
+	}
+	return texts
 }
 
 // PrecautionaryStatement contains measures listed on a hazardous label to minimize
-//  or prevent adverse effects related to GHS.
+// or prevent adverse effects related to GHS.
 type PrecautionaryStatement struct {
 	// Measures listed on a hazardous label to minimize or prevent adverse effects.
-	//  For GHS, the precautionary statements have been linked to each GHS hazard
-	//  statement and type of hazard. Precautionary statements for GHS cover prevention,
-	//  response in cases of accidental spillage or exposure, storage, and disposal.
-	PrecautionaryStatementsCode string `json:"precautionaryStatementsCode"`
+	// For GHS, the precautionary statements have been linked to each GHS hazard
+	// statement and type of hazard. Precautionary statements for GHS cover prevention,
+	// response in cases of accidental spillage or exposure, storage, and disposal.
+	PrecautionaryStatementsCode string `json:"precautionaryStatementsCode" proto:"1"`
 	// A description of the measures listed on a hazardous label to minimize or
-	//  prevent adverse effects.
-	PrecautionaryStatementsDescriptions []PrecautionaryStatementsDescription `json:"precautionaryStatementsDescription"`
+	// prevent adverse effects.
+	PrecautionaryStatementsDescriptions []PrecautionaryStatementsDescription `json:"precautionaryStatementsDescription" proto:"2"`
 }
 
 // PrecautionaryStatementsDescription is a description of the measures listed on
-//  a hazardous label to minimize or prevent adverse effects.
+// a hazardous label to minimize or prevent adverse effects.
 type PrecautionaryStatementsDescription struct {
-	Description  string `json:"$"`
-	LanguageCode string `json:"@languageCode"`
+	Description  string `json:"$" proto:"1"`
+	LanguageCode string `json:"@languageCode" proto:"2"`
+}
+
+// LocalizedText returns t as a LocalizedText.
+func (t PrecautionaryStatementsDescription) LocalizedText() LocalizedText {
+	return LocalizedText{Value: t.Description, LanguageCode: t.LanguageCode}
+}
+
+// PrecautionaryStatementsDescriptionTexts returns the texts of s in order.
+func PrecautionaryStatementsDescriptionTexts(s []PrecautionaryStatementsDescription) []LocalizedText {
+	texts := make([]LocalizedText, len(s))
+	for i, t := range s {
+		texts[i] = t.LocalizedText()
+	}
+	return texts
 }
 
 // PhysicalChemicalPropertyInformation contains information on Physical or
-//  Chemical Properties for a trade item for example water solubility.
+// Chemical Properties for a trade item for example water solubility.
 type PhysicalChemicalPropertyInformation struct {
 	// Details on a flash point for a trade item.
-	FlashPoints []FlashPoint `json:"flashPoint"`
+	FlashPoints []FlashPoint `json:"flashPoint" proto:"1"`
 	// PH is defined as the acidity or alkalinity of an aqueous solution.
 	// It is defined as the logarithm of the reciprocal of the hydrogenion
-	//  concentration of a solution. pH= log10 1/[H+].
-	PHInformation PHInformation `json:"pHInformation"`
+	// concentration of a solution. pH= log10 1/[H+].
+	PHInformation PHInformation `json:"pHInformation" proto:"2"`
 }
 
 // FlashPoint contains details on a flash point for a trade item.
 type FlashPoint struct {
 	// The temperature at which a substance gives off a sufficient
-	//  vapour to support combustion. This uses a measurement consisting
-	//  of a unit of measure and value. With the above request it requires
-	//  the flash point not to be the lowest but the point at which flash point
-	//  occurs and it could be that temperature and lower for some products. The
-	//  scientific Measurement Precision code would determine that.
-	FlashPointTemperatures []FlashPointTemperature `json:"flashPointTemperature"`
+	// vapour to support combustion. This uses a measurement consisting
+	// of a unit of measure and value. With the above request it requires
+	// the flash point not to be the lowest but the point at which flash point
+	// occurs and it could be that temperature and lower for some products. The
+	// scientific Measurement Precision code would determine that.
+	FlashPointTemperatures []FlashPointTemperature `json:"flashPointTemperature" proto:"1"`
 }
 
 // FlashPointTemperature the temperature at which a substance gives off a sufficient
-//  vapour to support combustion. This uses a measurement consisting of a unit of
-//  measure and value. With the above request it requires the flash point not to be
-//  the lowest but the point at which flash point occurs and it could be that temperature
-//  and lower for some products. The scientific Measurement Precision code would determine that.
+// vapour to support combustion. This uses a measurement consisting of a unit of
+// measure and value. With the above request it requires the flash point not to be
+// the lowest but the point at which flash point occurs and it could be that temperature
+// and lower for some products. The scientific Measurement Precision code would determine that.
 type FlashPointTemperature struct {
-	Temperature                    int    `json:"$"`
-	TemperatureMeasurementUnitCode string `json:"@temperatureMeasurementUnitCode"`
+	Temperature                    Decimal `json:"$" proto:"1"`
+	TemperatureMeasurementUnitCode string  `json:"@temperatureMeasurementUnitCode" proto:"2"`
 }
 
 // PHInformation describes a PH value
@@ -1150,11 +1477,11 @@ type FlashPointTemperature struct {
 // pH= log10 1/[H+].
 type PHInformation struct {
 	// The exact PH amount for a chemical ingredient (not a range).
-	ExactPH int `json:"exactPH"`
+	ExactPH NullFloat64 `json:"exactPH" proto:"1"`
 	// The maximum range for PH.
-	MaximumPH float64 `json:"maximumPH"`
+	MaximumPH NullFloat64 `json:"maximumPH" proto:"2"`
 	// The minimum range value for PH.
-	MinimumPH float64 `json:"minimumPH"`
+	MinimumPH NullFloat64 `json:"minimumPH" proto:"3"`
 }
 
 // SalesInformationModule describes sales information regarding price and selling
@@ -1162,7 +1489,7 @@ type PHInformation struct {
 type SalesInformationModule struct {
 	// Restrictions or requirements on the retailer for sales of the Trade Item
 	// to the consumer.
-	SalesInformation SalesInformation `json:"salesInformation"`
+	SalesInformation SalesInformation `json:"salesInformation" proto:"1"`
 }
 
 // SalesInformation describes restrictions or requirements on the retailer for
@@ -1171,349 +1498,489 @@ type SalesInformation struct {
 	// A code depicting restrictions imposed on the Trade Item regarding how
 	// it can be sold to the consumer for example Prescription Required. Uses
 	// code list consumerSalesConditionCode.
-	ConsumerSalesConditionCode []string `json:"consumerSalesConditionCode"`
+	ConsumerSalesConditionCode []string `json:"consumerSalesConditionCode" proto:"1"`
 	// Indicator to show how a product is sold. Uses code list priceByMeasureTypeCode.
-	PriceByMeasureTypeCode string `json:"priceByMeasureTypeCode"`
+	PriceByMeasureTypeCode string `json:"priceByMeasureTypeCode" proto:"2"`
 	// The quantity of the product at usage. Applicable for concentrated products
 	// and products where the comparison price is calculated based on a measurement
 	// other than netContent.
-	PriceComparisonMeasurements []PriceComparisonMeasurement `json:"priceComparisonMeasurement"`
+	PriceComparisonMeasurements []PriceComparisonMeasurement `json:"priceComparisonMeasurement" proto:"3"`
 	// Describes the measurement used for selling unit of the Trade Item to the end consumer.
-	SellingUnitOfMeasure string `json:"sellingUnitOfMeasure"`
+	SellingUnitOfMeasure string `json:"sellingUnitOfMeasure" proto:"4"`
 	// Defines compliancy with EU 1169 regulation.
-	XEu1169Compliance XEu1169Compliance `json:"x_eu1169Compliance"`
+	XEu1169Compliance XEu1169Compliance `json:"x_eu1169Compliance" proto:"5"`
 	// An indicator whether or not the Trade Item is excluded from loyalty programs.
-	XIsExcludedFromLoyaltyPrograms bool `json:"x_isExcludedFromLoyaltyPrograms"`
+	XIsExcludedFromLoyaltyPrograms bool `json:"x_isExcludedFromLoyaltyPrograms" proto:"6"`
 	// Defines how much the quantity of a Trade Item is changed when additional items
 	// are added or removed from shopping basket.
-	XSellingContentIncrement int `json:"x_sellingContentIncrement"`
+	XSellingContentIncrement int `json:"x_sellingContentIncrement" proto:"7"`
 	// Defines the initial quantity of Trade Item when the first instance of the item
 	// is added to shopping basket.
-	XSellingContentInitial int `json:"x_sellingContentInitial"`
+	XSellingContentInitial int `json:"x_sellingContentInitial" proto:"8"`
 	// Defines the measurement unit code used for selling of the Trade Item to the end
 	// consumer. Uses code list sellingUnitOfMeasure.
-	XSellingUnitOfMeasureCode string `json:"x_sellingUnitOfMeasureCode"`
+	XSellingUnitOfMeasureCode string `json:"x_sellingUnitOfMeasureCode" proto:"9"`
 }
 
 // PriceComparisonMeasurement is the quantity of the product at usage. Applicable
 // for concentrated products and products where the comparison price is calculated
 // based on a measurement other than netContent.
 type PriceComparisonMeasurement struct {
-	Measurement         float64 `json:"$"`
-	MeasurementUnitCode string  `json:"@measurementUnitCode"`
+	Measurement         Decimal `json:"$" proto:"1"`
+	MeasurementUnitCode string  `json:"@measurementUnitCode" proto:"2"`
 }
 
 // XEu1169Compliance defines compliancy with EU 1169 regulation.
 type XEu1169Compliance struct {
 	// Regulation compliancy. Uses code list x_complianceCode.
-	XComplianceCode string `json:"x_complianceCode"`
+	XComplianceCode string `json:"x_complianceCode" proto:"1"`
 }
 
 // TradeItemDescriptionModule a module carrying general descriptions of the trade item including
 // brand, form, variant.
 type TradeItemDescriptionModule struct {
 	// Description Information for the trade item.
-	TradeItemDescriptionInformation TradeItemDescriptionInformation `json:"tradeItemDescriptionInformation"`
+	TradeItemDescriptionInformation TradeItemDescriptionInformation `json:"tradeItemDescriptionInformation" proto:"1"`
 }
 
 // TradeItemDescriptionInformation is description information for the trade item.
 type TradeItemDescriptionInformation struct {
 	//Additional variants necessary to communicate to the industry to
-	//  help define the product.
-	AdditionalTradeItemDescriptions []AdditionalTradeItemDescription `json:"additionalTradeItemDescription"`
+	// help define the product.
+	AdditionalTradeItemDescriptions []AdditionalTradeItemDescription `json:"additionalTradeItemDescription" proto:"1"`
 	// A free form short length description of the trade item that can
 	// be used to identify the trade item at point of sale.
-	DescriptionShorts []DescriptionShort `json:"descriptionShort"`
+	DescriptionShorts []DescriptionShort `json:"descriptionShort" proto:"2"`
 	// Describes use of the product or service by the consumer. Should help
 	// clarify the product classification associated with the GTIN.
-	FunctionalNames []FunctionalName `json:"functionalName"`
+	FunctionalNames []FunctionalName `json:"functionalName" proto:"3"`
 	// An understandable and useable description of a trade item using brand
 	// and other descriptors. This attribute is filled with as little abbreviation
-	//  as possible while keeping to a reasonable length. This should be a meaningful
-	//  description of the trade item with full spelling to facilitate message processing.
-	//  Retailers can use this description as the base to fully understand the brand,
-	//  flavour, scent etc. of the specific GTIN in order to accurately create a product
-	//  description as needed for their internal systems.
-	TradeItemDescriptions []TradeItemDescription `json:"tradeItemDescription"`
+	// as possible while keeping to a reasonable length. This should be a meaningful
+	// description of the trade item with full spelling to facilitate message processing.
+	// Retailers can use this description as the base to fully understand the brand,
+	// flavour, scent etc. of the specific GTIN in order to accurately create a product
+	// description as needed for their internal systems.
+	TradeItemDescriptions []TradeItemDescription `json:"tradeItemDescription" proto:"4"`
 	// Free text field used to identify the variant of the product. Variants are
-	//  the distinguishing characteristics that differentiate products with the
-	//  same brand and size including such things as the particular flavor, fragrance, taste.
-	VariantDescriptions []VariantDescription `json:"variantDescription"`
+	// the distinguishing characteristics that differentiate products with the
+	// same brand and size including such things as the particular flavor, fragrance, taste.
+	VariantDescriptions []VariantDescription `json:"variantDescription" proto:"5"`
 	// Information on brands and sub-brands for a trade item.
-	BrandNameInformation BrandNameInformation `json:"brandNameInformation"`
+	BrandNameInformation BrandNameInformation `json:"brandNameInformation" proto:"6"`
 }
 
 // AdditionalTradeItemDescription contains additional variants
-//  necessary to communicate to the industry to help define the product.
+// necessary to communicate to the industry to help define the product.
 type AdditionalTradeItemDescription struct {
-	Description  string `json:"$"`
-	LanguageCode string `json:"@languageCode"`
+	Description  string `json:"$" proto:"1"`
+	LanguageCode string `json:"@languageCode" proto:"2"`
+}
+
+// LocalizedText returns t as a LocalizedText.
+func (t AdditionalTradeItemDescription) LocalizedText() LocalizedText {
+	return LocalizedText{Value: t.Description, LanguageCode: t.LanguageCode}
+}
+
+// AdditionalTradeItemDescriptionTexts returns the texts of s in order.
+func AdditionalTradeItemDescriptionTexts(s []AdditionalTradeItemDescription) []LocalizedText {
+	texts := make([]LocalizedText, len(s))
+	for i, t := range s {
+		texts[i] = t.LocalizedText()
+	}
+	return texts
 }
 
 // DescriptionShort is a free form short length description of the trade item that can
 // be used to identify the trade item at point of sale.
 type DescriptionShort struct {
-	Description  string `json:"$"`
-	LanguageCode string `json:"@languageCode"`
+	Description  string `json:"$" proto:"1"`
+	LanguageCode string `json:"@languageCode" proto:"2"`
+}
+
+// LocalizedText returns t as a LocalizedText.
+func (t DescriptionShort) LocalizedText() LocalizedText {
+	return LocalizedText{Value: t.Description, LanguageCode: t.LanguageCode}
+}
+
+// DescriptionShortTexts returns the texts of s in order.
+func DescriptionShortTexts(s []DescriptionShort) []LocalizedText {
+	texts := make([]LocalizedText, len(s))
+	for i, t := range s {
+		texts[i] = t.LocalizedText()
+	}
+	return texts
 }
 
 // FunctionalName describes use of the product or service by the consumer.
 // Should help clarify the product classification associated with the GTIN.
 type FunctionalName struct {
-	Name         string `json:"$"`
-	LanguageCode string `json:"@languageCode"`
+	Name         string `json:"$" proto:"1"`
+	LanguageCode string `json:"@languageCode" proto:"2"`
+}
+
+// LocalizedText returns t as a LocalizedText.
+func (t FunctionalName) LocalizedText() LocalizedText {
+	return LocalizedText{Value: t.Name, LanguageCode: t.LanguageCode}
+}
+
+// FunctionalNameTexts returns the texts of s in order.
+func FunctionalNameTexts(s []FunctionalName) []LocalizedText {
+	texts := make([]LocalizedText, len(s))
+	for i, t := range s {
+		texts[i] = t.LocalizedText()
+	}
+	return texts
 }
 
 // TradeItemDescription is an understandable and useable description of
-//  a trade item using brand and other descriptors. This attribute is
+// a trade item using brand and other descriptors. This attribute is
 // filled with as little abbreviation as possible while keeping to a reasonable
-//  length. This should be a meaningful description of the trade item with full
-//  spelling to facilitate message processing. Retailers can use this description
-//  as the base to fully understand the brand, flavour, scent etc. of the specific
-//  GTIN in order to accurately create a product description as needed for their
-//  internal systems.
+// length. This should be a meaningful description of the trade item with full
+// spelling to facilitate message processing. Retailers can use this description
+// as the base to fully understand the brand, flavour, scent etc. of the specific
+// GTIN in order to accurately create a product description as needed for their
+// internal systems.
 type TradeItemDescription struct {
-	Description  string `json:"$"`
-	LanguageCode string `json:"@languageCode"`
+	Description  string `json:"$" proto:"1"`
+	LanguageCode string `json:"@languageCode" proto:"2"`
+}
+
+// LocalizedText returns t as a LocalizedText.
+func (t TradeItemDescription) LocalizedText() LocalizedText {
+	return LocalizedText{Value: t.Description, LanguageCode: t.LanguageCode}
+}
+
+// TradeItemDescriptionTexts returns the texts of s in order.
+func TradeItemDescriptionTexts(s []TradeItemDescription) []LocalizedText {
+	texts := make([]LocalizedText, len(s))
+	for i, t := range s {
+		texts[i] = t.LocalizedText()
+	}
+	return texts
 }
 
 // VariantDescription free text field used to identify the variant of the product.
 // Variants are the distinguishing characteristics that differentiate products with
-//  the same brand and size including such things as the particular flavor, fragrance, taste.
+// the same brand and size including such things as the particular flavor, fragrance, taste.
 type VariantDescription struct {
-	Description  string `json:"$"`
-	LanguageCode string `json:"@languageCode"`
+	Description  string `json:"$" proto:"1"`
+	LanguageCode string `json:"@languageCode" proto:"2"`
+}
+
+// LocalizedText returns t as a LocalizedText.
+func (t VariantDescription) LocalizedText() LocalizedText {
+	return LocalizedText{Value: t.Description, LanguageCode: t.LanguageCode}
+}
+
+// VariantDescriptionTexts returns the texts of s in order.
+func VariantDescriptionTexts(s []VariantDescription) []LocalizedText {
+	texts := make([]LocalizedText, len(s))
+	for i, t := range s {
+		texts[i] = t.LocalizedText()
+	}
+	return texts
 }
 
 // BrandNameInformation contains information on brands and sub-brands for a trade item.
 type BrandNameInformation struct {
 	// The recognisable name used by a brand owner to uniquely identify a line of trade
-	//  item or services. This is recognizable by the consumer.
-	BrandName string `json:"brandName"`
+	// item or services. This is recognizable by the consumer.
+	BrandName string `json:"brandName" proto:"1"`
 	// The recognisable name used by a brand owner to uniquely identify a line of trade
-	//  item or services expressed in a different language than the primary brand name (brandName).
-	LanguageSpecificBrandNames []LanguageSpecificBrandName `json:"languageSpecificBrandName"`
+	// item or services expressed in a different language than the primary brand name (brandName).
+	LanguageSpecificBrandNames []LanguageSpecificBrandName `json:"languageSpecificBrandName" proto:"2"`
 	// A second level of brand expressed in a different language than the primary sub-brand name (subBrand).
-	LanguageSpecificSubbrandNames []LanguageSpecificSubbrandName `json:"languageSpecificSubbrandName"`
+	LanguageSpecificSubbrandNames []LanguageSpecificSubbrandName `json:"languageSpecificSubbrandName" proto:"3"`
 	// Second level of brand. Can be a trademark. It is the primary differentiating factor
 	// that a brand owner wants to communicate to the consumer or buyer.
-	SubBrand string `json:"subBrand"`
+	SubBrand string `json:"subBrand" proto:"4"`
 }
 
 // LanguageSpecificBrandName is the recognisable name used by a brand owner to uniquely identify
 // a line of trade item or services expressed in a different language than the primary brand name (brandName).
 type LanguageSpecificBrandName struct {
-	Name         string `json:"$"`
-	LanguageCode string `json:"@languageCode"`
+	Name         string `json:"$" proto:"1"`
+	LanguageCode string `json:"@languageCode" proto:"2"`
+}
+
+// LocalizedText returns t as a LocalizedText.
+func (t LanguageSpecificBrandName) LocalizedText() LocalizedText {
+	return LocalizedText{Value: t.Name, LanguageCode: t.LanguageCode}
+}
+
+// LanguageSpecificBrandNameTexts returns the texts of s in order.
+func LanguageSpecificBrandNameTexts(s []LanguageSpecificBrandName) []LocalizedText {
+	texts := make([]LocalizedText, len(s))
+	for i, t := range s {
+		texts[i] = t.LocalizedText()
+	}
+	return texts
 }
 
 // LanguageSpecificSubbrandName is a second level of brand expressed in a different
-//  language than the primary sub-brand name (subBrand).
+// language than the primary sub-brand name (subBrand).
 type LanguageSpecificSubbrandName struct {
-	Name         string `json:"$"`
-	LanguageCode string `json:"@languageCode"`
+	Name         string `json:"$" proto:"1"`
+	LanguageCode string `json:"@languageCode" proto:"2"`
+}
+
+// LocalizedText returns t as a LocalizedText.
+func (t LanguageSpecificSubbrandName) LocalizedText() LocalizedText {
+	return LocalizedText{Value: t.Name, LanguageCode: t.LanguageCode}
+}
+
+// LanguageSpecificSubbrandNameTexts returns the texts of s in order.
+func LanguageSpecificSubbrandNameTexts(s []LanguageSpecificSubbrandName) []LocalizedText {
+	texts := make([]LocalizedText, len(s))
+	for i, t := range s {
+		texts[i] = t.LocalizedText()
+	}
+	return texts
 }
 
 // TradeItemLifespanModule is a module containing information on the amount
 // of time the item can or should be used, sold, etc.
 type TradeItemLifespanModule struct {
 	// Information on the amount of time the item can or should be used, sold, etc.
-	TradeItemLifespan TradeItemLifespan `json:"tradeItemLifespan"`
+	TradeItemLifespan TradeItemLifespan `json:"tradeItemLifespan" proto:"1"`
 }
 
 // TradeItemLifespan contains information on the amount of time the item can or
-//  should be used, sold, etc.
+// should be used, sold, etc.
 type TradeItemLifespan struct {
 	// The period of day, guaranteed by the manufacturer, before the expiration date of the product, based on the production.
-	MinimumTradeItemLifespanFromTimeOfProduction int `json:"minimumTradeItemLifespanFromTimeOfProduction"`
+	MinimumTradeItemLifespanFromTimeOfProduction NullInt `json:"minimumTradeItemLifespanFromTimeOfProduction" proto:"1"`
 	// The number of days the trade item that had been opened can remain on the shelf and must then be removed.
-	OpenedTradeItemLifespan int `json:"openedTradeItemLifespan"`
+	OpenedTradeItemLifespan NullInt `json:"openedTradeItemLifespan" proto:"2"`
 }
 
 // TradeItemMeasurementsModule is a module containing measurement
 // information for the trade item.
 type TradeItemMeasurementsModule struct {
 	// Measurement information for the trade item.
-	TradeItemMeasurements TradeItemMeasurements `json:"tradeItemMeasurements"`
+	TradeItemMeasurements TradeItemMeasurements `json:"tradeItemMeasurements" proto:"1"`
 }
 
 // TradeItemMeasurements is measurement information for the trade item.
 type TradeItemMeasurements struct {
-	Depth  GDSNDepth  `json:"depth"`
-	Height GDSNHeight `json:"height"`
-	Width  GDSNWidth  `json:"width"`
+	Depth  GDSNDepth  `json:"depth" proto:"1"`
+	Height GDSNHeight `json:"height" proto:"2"`
+	Width  GDSNWidth  `json:"width" proto:"3"`
 	//The amount of the trade item contained by a package, usually as claimed on the label. For example, Water 750ml - net content = "750 MLT" ; 20 count pack of diapers, net content = "20 ea.". In case of multi-pack, indicates the net content of the total trade item. For fixed value trade items use the value claimed on the package, to avoid variable fill rate issue that arises with some trade item which are sold by volume or weight, and whose actual content may vary slightly from batch to batch. In case of variable quantity trade items, indicates the average quantity.
-	NetContent []GDSNNetContent `json:"netContent"`
+	NetContent []GDSNNetContent `json:"netContent" proto:"4"`
 	// Information on the weight of a trade item.
-	TradeItemWeight TradeItemWeight `json:"tradeItemWeight"`
+	TradeItemWeight TradeItemWeight `json:"tradeItemWeight" proto:"5"`
 }
 
 // GDSNDepth presents depth value of product.
 type GDSNDepth struct {
-	Value               float64 `json:"$"`
-	MeasurementUnitCode string  `json:"@measurementUnitCode"`
+	Value               Decimal `json:"$" proto:"1"`
+	MeasurementUnitCode string  `json:"@measurementUnitCode" proto:"2"`
 }
 
 // GDSNHeight presents height value of product.
 type GDSNHeight struct {
-	Value               float64 `json:"$"`
-	MeasurementUnitCode string  `json:"@measurementUnitCode"`
+	Value               Decimal `json:"$" proto:"1"`
+	MeasurementUnitCode string  `json:"@measurementUnitCode" proto:"2"`
 }
 
 // GDSNWidth presents width value of product.
 type GDSNWidth struct {
-	Value               float64 `json:"$"`
-	MeasurementUnitCode string  `json:"@measurementUnitCode"`
+	Value               Decimal `json:"$" proto:"1"`
+	MeasurementUnitCode string  `json:"@measurementUnitCode" proto:"2"`
 }
 
 // GDSNNetContent is the amount of the trade item contained by a package,
-//  usually as claimed on the label. For example, Water 750ml - net
+// usually as claimed on the label. For example, Water 750ml - net
 // content = "750 MLT" ; 20 count pack of diapers, net content = "20 ea.".
-//  In case of multi-pack, indicates the net content of the total trade item.
-//  For fixed value trade items use the value claimed on the package, to avoid
+// In case of multi-pack, indicates the net content of the total trade item.
+// For fixed value trade items use the value claimed on the package, to avoid
 // variable fill rate issue that arises with some trade item which are sold
 // by volume or weight, and whose actual content may vary slightly from batch
-//  to batch. In case of variable quantity trade items, indicates the average quantity.
+// to batch. In case of variable quantity trade items, indicates the average quantity.
 type GDSNNetContent struct {
-	Measurement         int    `json:"$"`
-	MeasurementUnitCode string `json:"@measurementUnitCode"`
+	Measurement         Decimal `json:"$" proto:"1"`
+	MeasurementUnitCode string  `json:"@measurementUnitCode" proto:"2"`
 }
 
 // TradeItemWeight is information on the weight of a trade item.
 type TradeItemWeight struct {
-	DrainedWeight GDSNDrainedWeight `json:"drainedWeight"`
-	GrossWeight   GDSNDrainedWeight `json:"grossWeight"`
-	NetWeight     GDSNNetWeight     `json:"netWeight"`
+	DrainedWeight GDSNDrainedWeight `json:"drainedWeight" proto:"1"`
+	GrossWeight   GDSNGrossWeight   `json:"grossWeight" proto:"2"`
+	NetWeight     GDSNNetWeight     `json:"netWeight" proto:"3"`
 }
 
 type GDSNDrainedWeight struct {
-	Measurement         int    `json:"$"`
-	MeasurementUnitCode string `json:"@measurementUnitCode"`
+	Measurement         Decimal `json:"$" proto:"1"`
+	MeasurementUnitCode string  `json:"@measurementUnitCode" proto:"2"`
 }
 
 type GDSNGrossWeight struct {
-	Measurement         int    `json:"$"`
-	MeasurementUnitCode string `json:"@measurementUnitCode"`
+	Measurement         Decimal `json:"$" proto:"1"`
+	MeasurementUnitCode string  `json:"@measurementUnitCode" proto:"2"`
 }
 
 type GDSNNetWeight struct {
-	Measurement         int    `json:"$"`
-	MeasurementUnitCode string `json:"@measurementUnitCode"`
+	Measurement         Decimal `json:"$" proto:"1"`
+	MeasurementUnitCode string  `json:"@measurementUnitCode" proto:"2"`
 }
 
 // TradeItemTemperatureInformationModule is information on temperature considerations for trade item.
 type TradeItemTemperatureInformationModule struct {
 	// The condition of the product sold to the end consumer. Uses code
 	// list tradeItemTemperatureConditionTypeCode.
-	TradeItemTemperatureConditionTypeCode string `json:"tradeItemTemperatureConditionTypeCode"`
+	TradeItemTemperatureConditionTypeCode string `json:"tradeItemTemperatureConditionTypeCode" proto:"1"`
 	// Details on permissible temperatures of a trade item during various points of the supply chain.
-	TradeItemTemperatureInformations []TradeItemTemperatureInformation `json:"tradeItemTemperatureInformation"`
+	TradeItemTemperatureInformations []TradeItemTemperatureInformation `json:"tradeItemTemperatureInformation" proto:"2"`
 }
 
 // TradeItemTemperatureInformation describes details on permissible temperatures of
-//  a trade item during various points of the supply chain.
+// a trade item during various points of the supply chain.
 type TradeItemTemperatureInformation struct {
-	MaximumTemperature          GDSNTemperature `json:"maximumTemperature"`
-	MaximumToleranceTemperature GDSNTemperature `json:"maximumToleranceTemperature"`
-	MinimumTemperature          GDSNTemperature `json:"minimumTemperature"`
-	MinumumToleranceTemperature GDSNTemperature `json:"minumumToleranceTemperature"`
+	MaximumTemperature          GDSNTemperature `json:"maximumTemperature" proto:"1"`
+	MaximumToleranceTemperature GDSNTemperature `json:"maximumToleranceTemperature" proto:"2"`
+	MinimumTemperature          GDSNTemperature `json:"minimumTemperature" proto:"3"`
+	MinumumToleranceTemperature GDSNTemperature `json:"minumumToleranceTemperature" proto:"4"`
 	// Code qualifying the type of a temperature requirement for example Storage.
 	// Uses code list temperatureQualifierCode.
-	TemperatureQualifierCode string `json:"temperatureQualifierCode"`
+	TemperatureQualifierCode string `json:"temperatureQualifierCode" proto:"5"`
 }
 
 // GDSNTemperature provides temperature measurement value and associated unit of measure code.
 type GDSNTemperature struct {
-	Temperature                    int    `json:"$"`
-	TemperatureMeasurementUnitCode string `json:"@temperatureMeasurementUnitCode"`
+	Temperature                    Decimal `json:"$" proto:"1"`
+	TemperatureMeasurementUnitCode string  `json:"@temperatureMeasurementUnitCode" proto:"2"`
 }
 
 // VariableTradeItemInformationModule is a module with information specific to variable weight or dimension trade items.
 type VariableTradeItemInformationModule struct {
 	// Information specific to variable weight or dimension trade items.
-	VariableTradeItemInformation VariableTradeItemInformation `json:"variableTradeItemInformation"`
+	VariableTradeItemInformation VariableTradeItemInformation `json:"variableTradeItemInformation" proto:"1"`
 }
 
 // VariableTradeItemInformation is information specific to variable weight or dimension trade items.
 type VariableTradeItemInformation struct {
 	// Indicates that an article is not a fixed quantity, but that the quantity is variable. Can be weight,
 	// length, volume. trade item is used or traded in continuous rather than discrete quantities.
-	IsTradeItemAVariableUnit bool `json:"isTradeItemAVariableUnit"`
+	IsTradeItemAVariableUnit NullBool `json:"isTradeItemAVariableUnit" proto:"1"`
 	// Indicator to show whether product is loose or pre-packed. Uses code list variableTradeItemTypeCode.
-	VariableTradeItemTypeCode string `json:"variableTradeItemTypeCode"`
+	VariableTradeItemTypeCode string `json:"variableTradeItemTypeCode" proto:"2"`
 	// Indication of the percentage value that the actual weight of the trade item may differ from the average
 	// or estimated weight given. For example, Roast beef off the bone 3.5 kg, Gross weight 3500 Grams,
-	//  Range = 14 %. This means that this item may be produced with weight values ranging from 3.010 kg to 3.990 kg.
-	VariableWeightAllowableDeviationPercentage int `json:"variableWeightAllowableDeviationPercentage"`
+	// Range = 14 %. This means that this item may be produced with weight values ranging from 3.010 kg to 3.990 kg.
+	VariableWeightAllowableDeviationPercentage NullInt `json:"variableWeightAllowableDeviationPercentage" proto:"3"`
 }
 
 // DGCodeListModule lists associated code lists
 type DGCodeListModule struct {
-	CodeLists []CodeList `json:"codeList"`
+	CodeLists []CodeList `json:"codeList" proto:"1"`
 }
 
 // CodeList presents GDSN code list
 type CodeList struct {
 	// Code list name
-	CodeListName string `json:"codeListName"`
+	CodeListName string `json:"codeListName" proto:"1"`
 	// Whether the code list is an external code list.
-	IsExternalCodeList bool             `json:"isExternalCodeList"`
-	CodeListRecords    []CodeListRecord `json:"codeListRecord"`
+	IsExternalCodeList bool             `json:"isExternalCodeList" proto:"2"`
+	CodeListRecords    []CodeListRecord `json:"codeListRecord" proto:"3"`
 	// The name of the agency that manages a code list.
-	ExternalAgencyName string `json:"externalAgencyName,omitempty"`
+	ExternalAgencyName string `json:"externalAgencyName,omitempty" proto:"4"`
 	// The name of the code list maintained by an external agency.
-	ExternalCodeListName string `json:"externalCodeListName,omitempty"`
+	ExternalCodeListName string `json:"externalCodeListName,omitempty" proto:"5"`
 	// The version of the code list maintained by an external agency.
-	ExternalCodeListVersion string `json:"externalCodeListVersion,omitempty"`
+	ExternalCodeListVersion string `json:"externalCodeListVersion,omitempty" proto:"6"`
 }
 
 // CodeListRecord presents single record of code list
 type CodeListRecord struct {
-	Code        string                `json:"code"`
-	Name        []CodeListRecordField `json:"name"`
-	Description []CodeListRecordField `json:"description"`
-	Label       []CodeListRecordField `json:"label"`
+	Code        string                `json:"code" proto:"1"`
+	Name        []CodeListRecordField `json:"name" proto:"2"`
+	Description []CodeListRecordField `json:"description" proto:"3"`
+	Label       []CodeListRecordField `json:"label" proto:"4"`
 }
 
 // CodeListRecordField presents code list record value
 type CodeListRecordField struct {
-	Value        string `json:"$"`
-	LanguegeCode string `json:"@languegeCode"`
+	Value        string `json:"$" proto:"1"`
+	LanguageCode string `json:"@languageCode" proto:"2"`
+}
+
+// LocalizedText returns t as a LocalizedText.
+func (t CodeListRecordField) LocalizedText() LocalizedText {
+	return LocalizedText{Value: t.Value, LanguageCode: t.LanguageCode}
+}
+
+// CodeListRecordFieldTexts returns the texts of s in order.
+func CodeListRecordFieldTexts(s []CodeListRecordField) []LocalizedText {
+	texts := make([]LocalizedText, len(s))
+	for i, t := range s {
+		texts[i] = t.LocalizedText()
+	}
+	return texts
 }
 
 // DGMediaModule contains product media properties
 type DGMediaModule struct {
 	// Media files associated with the product.
-	Media []GDSNMedia `json:"media"`
+	Media []GDSNMedia `json:"media" proto:"1"`
 }
 
 // GDSNMedia presents media files associated with the product.
 type GDSNMedia struct {
-	MediaSequence          int                     `json:"mediaSequence"`
-	MediaLanguageCodes     []string                `json:"mediaLanguageCode"`
-	MediaNames             []GDSNMediaName         `json:"mediaName"`
-	MediaStorageKey        string                  `json:"mediaStorageKey"`
-	MediaMimeType          string                  `json:"mediaMimeType"`
-	MediaDimensionWidth    int                     `json:"mediaDimensionWidth"`
-	MediaDimensionHeight   int                     `json:"mediaDimensionHeight"`
-	MediaFileName          string                  `json:"mediaFileName"`
-	MediaTypeCode          string                  `json:"mediaTypeCode"`
-	MediaTypeVariantCode   string                  `json:"mediaTypeVariantCode"`
-	IsReadyForPublishing   bool                    `json:"isReadyForPublishing"`
-	MediaStateDescriptions []MediaStateDescription `json:"mediaStateDescription"`
-	MediaProvider          MediaProvider           `json:"mediaProvider"`
+	MediaSequence          int                     `json:"mediaSequence" proto:"1"`
+	MediaLanguageCodes     []string                `json:"mediaLanguageCode" proto:"2"`
+	MediaNames             []GDSNMediaName         `json:"mediaName" proto:"3"`
+	MediaStorageKey        string                  `json:"mediaStorageKey" proto:"4"`
+	MediaMimeType          string                  `json:"mediaMimeType" proto:"5"`
+	MediaDimensionWidth    int                     `json:"mediaDimensionWidth" proto:"6"`
+	MediaDimensionHeight   int                     `json:"mediaDimensionHeight" proto:"7"`
+	MediaFileName          string                  `json:"mediaFileName" proto:"8"`
+	MediaTypeCode          string                  `json:"mediaTypeCode" proto:"9"`
+	MediaTypeVariantCode   string                  `json:"mediaTypeVariantCode" proto:"10"`
+	IsReadyForPublishing   bool                    `json:"isReadyForPublishing" proto:"11"`
+	MediaStateDescriptions []MediaStateDescription `json:"mediaStateDescription" proto:"12"`
+	MediaProvider          MediaProvider           `json:"mediaProvider" proto:"13"`
 }
 
 // GDSNMediaName presents name of media
 type GDSNMediaName struct {
-	Name         string `json:"$"`
-	LanguageCode string `json:"@languageCode"`
+	Name         string `json:"$" proto:"1"`
+	LanguageCode string `json:"@languageCode" proto:"2"`
+}
+
+// LocalizedText returns t as a LocalizedText.
+func (t GDSNMediaName) LocalizedText() LocalizedText {
+	return LocalizedText{Value: t.Name, LanguageCode: t.LanguageCode}
+}
+
+// GDSNMediaNameTexts returns the texts of s in order.
+func GDSNMediaNameTexts(s []GDSNMediaName) []LocalizedText {
+	texts := make([]LocalizedText, len(s))
+	for i, t := range s {
+		texts[i] = t.LocalizedText()
+	}
+	return texts
 }
 
 // MediaStateDescription contains description of media
 type MediaStateDescription struct {
-	Description  string `json:"$"`
-	LanguageCode string `json:"@languageCode"`
+	Description  string `json:"$" proto:"1"`
+	LanguageCode string `json:"@languageCode" proto:"2"`
+}
+
+// LocalizedText returns t as a LocalizedText.
+func (t MediaStateDescription) LocalizedText() LocalizedText {
+	return LocalizedText{Value: t.Description, LanguageCode: t.LanguageCode}
+}
+
+// MediaStateDescriptionTexts returns the texts of s in order.
+func MediaStateDescriptionTexts(s []MediaStateDescription) []LocalizedText {
+	texts := make([]LocalizedText, len(s))
+	for i, t := range s {
+		texts[i] = t.LocalizedText()
+	}
+	return texts
 }
 
 // MediaProvider is the identification of a party, by GLN, in a specific party role.
@@ -1521,97 +1988,139 @@ type MediaProvider struct {
 	// The Global Location Number (GLN) is a structured Identification of a physical
 	// location, legal or functional entity within an enterprise. The GLN is the primary
 	// party identifier. Each party identified in the trading relationship must have a
-	//  primary party Identification.
-	Gln string `json:"gln"`
+	// primary party Identification.
+	Gln string `json:"gln" proto:"1"`
 	// The name of the party expressed in text.
-	PartyName string `json:"partyName"`
+	PartyName string `json:"partyName" proto:"2"`
 	// The address associated with the party. This could be the full company address.
-	PartyAddress string `json:"partyAddress"`
+	PartyAddress string `json:"partyAddress" proto:"3"`
 }
 
 // DGPresentationModule contains product presentation properties
 type DGPresentationModule struct {
 	// Limits product visibility to given periods. Presentation
 	// categories may further limit product visibility. Periods can be open-ended.
-	ProductConsumerVisibilities []ProductConsumerVisibility `json:"productConsumerVisibility"`
+	ProductConsumerVisibilities []ProductConsumerVisibility `json:"productConsumerVisibility" proto:"1"`
 	// Sets the visibility of product. Overrides visibility given by productConsumerVisibility.
-	ProductAbsoluteConsumerVisibility bool `json:"productAbsoluteConsumerVisibility"`
+	ProductAbsoluteConsumerVisibility bool `json:"productAbsoluteConsumerVisibility" proto:"2"`
 	// Categories the product is associated with.
-	PresentationCategories []PresentationCategory `json:"presentationCategory"`
+	PresentationCategories []PresentationCategory `json:"presentationCategory" proto:"3"`
 }
 
 // ProductConsumerVisibility limits product visibility to given periods. Presentation
 // categories may further limit product visibility. Periods can be open-ended.
 type ProductConsumerVisibility struct {
-	StartDateTime time.Time `json:"startDateTime"`
-	EndDateTime   time.Time `json:"endDateTime"`
+	StartDateTime time.Time `json:"startDateTime" proto:"1"`
+	EndDateTime   time.Time `json:"endDateTime" proto:"2"`
 }
 
 // PresentationCategory presents a category
 type PresentationCategory struct {
 	// Category tree name
-	TreeName string `json:"treeName"`
+	TreeName string `json:"treeName" proto:"1"`
 	// Category external ID
-	ExtID string `json:"extId"`
+	ExtID string `json:"extId" proto:"2"`
 	// Restricts category association to given periods. Periods can be open-ended.
-	ValidityPeriods ValidityPeriod `json:"validityPeriod"`
+	ValidityPeriods ValidityPeriod `json:"validityPeriod" proto:"3"`
 }
 
 // ValidityPeriod restricts category association to given periods. Periods can be open-ended.
 type ValidityPeriod struct {
-	StartDateTime time.Time `json:"startDateTime"`
-	EndDateTime   time.Time `json:"endDateTime"`
+	StartDateTime time.Time `json:"startDateTime" proto:"1"`
+	EndDateTime   time.Time `json:"endDateTime" proto:"2"`
 }
 
 // DGProductAttributeModule is a module containing freely defined product attributes.
 type DGProductAttributeModule struct {
 	// Product attribute groups used to collect attributes into meaningful sets.
-	ProductAttributeGroups []ProductAttributeGroup `json:"productAttributeGroup"`
+	ProductAttributeGroups []ProductAttributeGroup `json:"productAttributeGroup" proto:"1"`
 }
 
 // ProductAttributeGroup describes product attribute group used to collect attributes into meaningful sets.
 type ProductAttributeGroup struct {
 	// Product-unique data provider assigned identifer.
-	ProductAttributeGroupExtID string `json:"productAttributeGroupExtId"`
+	ProductAttributeGroupExtID string `json:"productAttributeGroupExtId" proto:"1"`
 	// Value indicating the group order.
-	ProductAttributeGroupSequence string `json:"productAttributeGroupSequence"`
+	ProductAttributeGroupSequence string `json:"productAttributeGroupSequence" proto:"2"`
 	// Attribute group name used for presentation.
-	ProductAttributeGroupNames []ProductAttributeGroupName `json:"productAttributeGroupName"`
+	ProductAttributeGroupNames []ProductAttributeGroupName `json:"productAttributeGroupName" proto:"3"`
 	// Product attributes.
-	ProductAttributes []ProductAttribute `json:"productAttribute"`
+	ProductAttributes []ProductAttribute `json:"productAttribute" proto:"4"`
 }
 
 // ProductAttributeGroupName is attribute group name used for presentation.
 type ProductAttributeGroupName struct {
-	Name         string `json:"$"`
-	LanguageCode string `json:"@languageCode"`
+	Name         string `json:"$" proto:"1"`
+	LanguageCode string `json:"@languageCode" proto:"2"`
+}
+
+// LocalizedText returns t as a LocalizedText.
+func (t ProductAttributeGroupName) LocalizedText() LocalizedText {
+	return LocalizedText{Value: t.Name, LanguageCode: t.LanguageCode}
+}
+
+// ProductAttributeGroupNameTexts returns the texts of s in order.
+func ProductAttributeGroupNameTexts(s []ProductAttributeGroupName) []LocalizedText {
+	texts := make([]LocalizedText, len(s))
+	for i, t := range s {
+		texts[i] = t.LocalizedText()
+	}
+	return texts
 }
 
 // ProductAttribute describes attribute declaration. Note that while neither
-//  productAttributeValueString, productAttributeValueNumeric, nor
-//  productAttributeValueBoolean is required, exactly one of these must be provided.
+// productAttributeValueString, productAttributeValueNumeric, nor
+// productAttributeValueBoolean is required, exactly one of these must be provided.
 type ProductAttribute struct {
 	// Group-unique data provider assigned identifier.
-	ProductAttributeExtID string `json:"productAttributeExtId"`
+	ProductAttributeExtID string `json:"productAttributeExtId" proto:"1"`
 	// Value indicating the attribute order.
-	ProductAttributeSequence string `json:"productAttributeSequence"`
+	ProductAttributeSequence string `json:"productAttributeSequence" proto:"2"`
 	// Code specifying the attribute type. Uses code list productAttributeTypeCode.
-	ProductAttributeTypeCode string `json:"productAttributeTypeCode"`
+	ProductAttributeTypeCode string `json:"productAttributeTypeCode" proto:"3"`
 	// An indicator whether or not the attribute is and can be used as a facet attribute.
-	IsFacetAttribute             bool                          `json:"isFacetAttribute"`
-	ProductAttributeNames        []ProductAttributeName        `json:"productAttributeName"`
-	ProductAttributeValueStrings []ProductAttributeValueString `json:"productAttributeValueString"`
-	ProductAttributeValueNumeric float64                       `json:"productAttributeValueNumeric"`
-	ProductAttributeValueBoolean bool                          `json:"productAttributeValueBoolean"`
+	IsFacetAttribute             bool                          `json:"isFacetAttribute" proto:"4"`
+	ProductAttributeNames        []ProductAttributeName        `json:"productAttributeName" proto:"5"`
+	ProductAttributeValueStrings []ProductAttributeValueString `json:"productAttributeValueString" proto:"6"`
+	ProductAttributeValueNumeric NullFloat64                   `json:"productAttributeValueNumeric" proto:"7"`
+	ProductAttributeValueBoolean NullBool                      `json:"productAttributeValueBoolean" proto:"8"`
 }
 
 // ProductAttributeName presents attribute name
 type ProductAttributeName struct {
-	Name         string `json:"$"`
-	LanguageCode string `json:"@languageCode"`
+	Name         string `json:"$" proto:"1"`
+	LanguageCode string `json:"@languageCode" proto:"2"`
+}
+
+// LocalizedText returns t as a LocalizedText.
+func (t ProductAttributeName) LocalizedText() LocalizedText {
+	return LocalizedText{Value: t.Name, LanguageCode: t.LanguageCode}
+}
+
+// ProductAttributeNameTexts returns the texts of s in order.
+func ProductAttributeNameTexts(s []ProductAttributeName) []LocalizedText {
+	texts := make([]LocalizedText, len(s))
+	for i, t := range s {
+		texts[i] = t.LocalizedText()
+	}
+	return texts
 }
 
 type ProductAttributeValueString struct {
-	Value        string `json:"$"`
-	LanguageCode string `json:"@languageCode"`
+	Value        string `json:"$" proto:"1"`
+	LanguageCode string `json:"@languageCode" proto:"2"`
+}
+
+// LocalizedText returns t as a LocalizedText.
+func (t ProductAttributeValueString) LocalizedText() LocalizedText {
+	return LocalizedText{Value: t.Value, LanguageCode: t.LanguageCode}
+}
+
+// ProductAttributeValueStringTexts returns the texts of s in order.
+func ProductAttributeValueStringTexts(s []ProductAttributeValueString) []LocalizedText {
+	texts := make([]LocalizedText, len(s))
+	for i, t := range s {
+		texts[i] = t.LocalizedText()
+	}
+	return texts
 }
@@ -0,0 +1,389 @@
+package structs
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrUnknownUnit is returned for measurement unit codes which are not
+	// known UN/ECE Recommendation 20 codes.
+	ErrUnknownUnit = errors.New("unknown measurement unit code")
+	// ErrIncompatibleUnits is returned when quantities of different dimensions,
+	// for example mass and volume, are converted or compared.
+	ErrIncompatibleUnits = errors.New("incompatible measurement units")
+)
+
+// Dimension is the physical dimension of a unit of measure.
+type Dimension int
+
+// Dimensions of units of measure.
+const (
+	DimensionMass Dimension = iota + 1
+	DimensionVolume
+	DimensionLength
+	DimensionArea
+	DimensionCount
+	DimensionTemperature
+	DimensionEnergy
+)
+
+// String returns the lower case name of the dimension.
+func (d Dimension) String() string {
+	switch d {
+	case DimensionMass:
+		return "mass"
+	case DimensionVolume:
+		return "volume"
+	case DimensionLength:
+		return "length"
+	case DimensionArea:
+		return "area"
+	case DimensionCount:
+		return "count"
+	case DimensionTemperature:
+		return "temperature"
+	case DimensionEnergy:
+		return "energy"
+	}
+	return "dimension(" + strconv.Itoa(int(d)) + ")"
+}
+
+// Unit is a unit of measure identified by UN/ECE Recommendation 20 code.
+type Unit struct {
+	// UN/ECE Recommendation 20 code, for example GRM.
+	Code string
+	// Physical dimension of the unit.
+	Dimension Dimension
+	// factor is the value of one unit in the base unit of the dimension and
+	// offset is added after scaling by factor. See Factor and Offset.
+	factor, offset *big.Rat
+}
+
+// Factor returns the exact value of one unit in the base unit of the
+// dimension: kilogram, litre, metre, square metre, piece, kelvin or kilojoule.
+func (u Unit) Factor() *big.Rat {
+	if u.factor == nil {
+		return new(big.Rat)
+	}
+	return new(big.Rat).Set(u.factor)
+}
+
+// Offset returns the exact value added after scaling by Factor to get the base
+// unit. Only temperature units have an offset.
+func (u Unit) Offset() *big.Rat {
+	if u.offset == nil {
+		return new(big.Rat)
+	}
+	return new(big.Rat).Set(u.offset)
+}
+
+// Common UN/ECE Recommendation 20 unit codes.
+const (
+	UnitKilogram      = "KGM"
+	UnitLitre         = "LTR"
+	UnitMetre         = "MTR"
+	UnitSquareMetre   = "MTK"
+	UnitPiece         = "H87"
+	UnitKelvin        = "KEL"
+	UnitGram          = "GRM"
+	UnitMillilitre    = "MLT"
+	UnitMillimetre    = "MMT"
+	UnitCentimetre    = "CMT"
+	UnitCubicMetre    = "MTQ"
+	UnitDegreeCelsius = "CEL"
+	UnitFahrenheit    = "FAH"
+	UnitKilojoule     = "KJO"
+	UnitKilocalorie   = "E14"
+)
+
+var units = map[string]Unit{}
+
+func init() {
+	for _, u := range []struct {
+		code           string
+		dimension      Dimension
+		factor, offset string
+	}{
+		{"MC", DimensionMass, "1e-9", "0"},
+		{"MGM", DimensionMass, "1e-6", "0"},
+		{"GRM", DimensionMass, "1e-3", "0"},
+		{"KGM", DimensionMass, "1", "0"},
+		{"TNE", DimensionMass, "1000", "0"},
+		{"LBR", DimensionMass, "0.45359237", "0"},
+		{"ONZ", DimensionMass, "0.028349523125", "0"},
+
+		{"MLT", DimensionVolume, "1e-3", "0"},
+		{"CLT", DimensionVolume, "1e-2", "0"},
+		{"DLT", DimensionVolume, "1e-1", "0"},
+		{"LTR", DimensionVolume, "1", "0"},
+		{"HLT", DimensionVolume, "100", "0"},
+		{"MMQ", DimensionVolume, "1e-6", "0"},
+		{"CMQ", DimensionVolume, "1e-3", "0"},
+		{"DMQ", DimensionVolume, "1", "0"},
+		{"MTQ", DimensionVolume, "1000", "0"},
+		{"GLL", DimensionVolume, "3.785411784", "0"},
+		{"GLI", DimensionVolume, "4.54609", "0"},
+		{"OZA", DimensionVolume, "0.0295735295625", "0"},
+		{"OZI", DimensionVolume, "0.0284130625", "0"},
+
+		{"MMT", DimensionLength, "1e-3", "0"},
+		{"CMT", DimensionLength, "1e-2", "0"},
+		{"DMT", DimensionLength, "1e-1", "0"},
+		{"MTR", DimensionLength, "1", "0"},
+		{"KMT", DimensionLength, "1000", "0"},
+		{"INH", DimensionLength, "0.0254", "0"},
+		{"FOT", DimensionLength, "0.3048", "0"},
+		{"YRD", DimensionLength, "0.9144", "0"},
+
+		{"MMK", DimensionArea, "1e-6", "0"},
+		{"CMK", DimensionArea, "1e-4", "0"},
+		{"DMK", DimensionArea, "1e-2", "0"},
+		{"MTK", DimensionArea, "1", "0"},
+
+		{"H87", DimensionCount, "1", "0"},
+		{"C62", DimensionCount, "1", "0"},
+		{"EA", DimensionCount, "1", "0"},
+		{"PR", DimensionCount, "2", "0"},
+		{"DZN", DimensionCount, "12", "0"},
+
+		{"KEL", DimensionTemperature, "1", "0"},
+		{"CEL", DimensionTemperature, "1", "273.15"},
+		{"FAH", DimensionTemperature, "5/9", "45967/180"},
+
+		{"JOU", DimensionEnergy, "1e-3", "0"},
+		{"KJO", DimensionEnergy, "1", "0"},
+		// E14 is the International Table kilocalorie, not the thermochemical
+		// one of 4.184 kJ.
+		{"E14", DimensionEnergy, "4.1868", "0"},
+	} {
+		factor, _ := new(big.Rat).SetString(u.factor)
+		offset, _ := new(big.Rat).SetString(u.offset)
+		units[u.code] = Unit{Code: u.code, Dimension: u.dimension, factor: factor, offset: offset}
+	}
+}
+
+// LookupUnit returns the unit of measure for a UN/ECE Recommendation 20 code.
+// The code is case insensitive.
+func LookupUnit(code string) (Unit, bool) {
+	u, ok := units[strings.ToUpper(strings.TrimSpace(code))]
+	return u, ok
+}
+
+// Quantity is a measurement value with its unit of measure. All measurement
+// structs, for example GDSNNetContent and GDSNDepth, return their value as
+// Quantity. Conversions and arithmetic are exact, see DecimalFromRat for the
+// results which have no finite decimal representation.
+type Quantity struct {
+	Value Decimal
+	// UN/ECE Recommendation 20 code. Uses code list measurementUnitCode.
+	UnitCode string
+}
+
+// NewQuantity returns a quantity of value in the given unit.
+func NewQuantity(value Decimal, unitCode string) Quantity {
+	return Quantity{Value: value, UnitCode: unitCode}
+}
+
+// String returns the quantity as value followed by unit code, for example 750 MLT.
+func (q Quantity) String() string {
+	return q.Value.String() + " " + q.UnitCode
+}
+
+// Unit returns the unit of measure of the quantity.
+func (q Quantity) Unit() (Unit, error) {
+	u, ok := LookupUnit(q.UnitCode)
+	if !ok {
+		return Unit{}, fmt.Errorf("%q: %w", q.UnitCode, ErrUnknownUnit)
+	}
+	return u, nil
+}
+
+// Dimension returns the physical dimension of the quantity.
+func (q Quantity) Dimension() (Dimension, error) {
+	u, err := q.Unit()
+	return u.Dimension, err
+}
+
+// convert returns the exact value of q in the unit to, which must be of the
+// same dimension.
+func (q Quantity) convert(to Unit) (*big.Rat, error) {
+	from, err := q.Unit()
+	if err != nil {
+		return nil, err
+	}
+	if from.Dimension != to.Dimension {
+		return nil, fmt.Errorf("%s to %s: %w", q.UnitCode, to.Code, ErrIncompatibleUnits)
+	}
+	v := q.Value.Rat()
+	if from.Code == to.Code {
+		return v, nil
+	}
+	v.Mul(v, from.factor)
+	v.Add(v, from.offset)
+	v.Sub(v, to.offset)
+	return v.Quo(v, to.factor), nil
+}
+
+// ConvertTo converts the quantity to the given unit of the same dimension.
+func (q Quantity) ConvertTo(unitCode string) (Quantity, error) {
+	to, ok := LookupUnit(unitCode)
+	if !ok {
+		return Quantity{}, fmt.Errorf("%q: %w", unitCode, ErrUnknownUnit)
+	}
+	if from, err := q.Unit(); err == nil && from.Code == to.Code {
+		return Quantity{Value: q.Value, UnitCode: to.Code}, nil
+	}
+	v, err := q.convert(to)
+	if err != nil {
+		return Quantity{}, err
+	}
+	return Quantity{Value: DecimalFromRat(v), UnitCode: to.Code}, nil
+}
+
+// Compare compares two quantities of the same dimension given in any units
+// and returns -1, 0 or +1. The comparison is exact.
+func (q Quantity) Compare(other Quantity) (int, error) {
+	u, err := q.Unit()
+	if err != nil {
+		return 0, err
+	}
+	o, err := other.convert(u)
+	if err != nil {
+		return 0, err
+	}
+	return q.Value.Rat().Cmp(o), nil
+}
+
+// Add returns the sum of two quantities of the same dimension in the unit of q.
+// Temperatures can not be added.
+func (q Quantity) Add(other Quantity) (Quantity, error) {
+	u, err := q.Unit()
+	if err != nil {
+		return Quantity{}, err
+	}
+	if u.Dimension == DimensionTemperature {
+		return Quantity{}, fmt.Errorf("adding temperatures: %w", ErrIncompatibleUnits)
+	}
+	o, err := other.convert(u)
+	if err != nil {
+		return Quantity{}, err
+	}
+	return Quantity{Value: DecimalFromRat(o.Add(o, q.Value.Rat())), UnitCode: q.UnitCode}, nil
+}
+
+// Scale returns the quantity multiplied by f.
+func (q Quantity) Scale(f *big.Rat) Quantity {
+	v := q.Value.Rat()
+	return Quantity{Value: DecimalFromRat(v.Mul(v, f)), UnitCode: q.UnitCode}
+}
+
+// Quantity returns the measurement as Quantity.
+func (m AlcoholicBeverageSugarContent) Quantity() Quantity {
+	return NewQuantity(m.Measurement, m.MeasurementUnitCode)
+}
+
+// Quantity returns the measurement as Quantity.
+func (m ProductYieldMeasurement) Quantity() Quantity {
+	return NewQuantity(m.Measurement, m.MeasurementUnitCode)
+}
+
+// Quantity returns the measurement as Quantity.
+func (m PhysiochemicalCharacteristicValue) Quantity() Quantity {
+	return NewQuantity(m.Measurement, m.MeasurementUnitCode)
+}
+
+// Quantity returns the measurement as Quantity.
+func (m NutrientBasisQuantity) Quantity() Quantity {
+	return NewQuantity(m.Measurement, m.MeasurementUnitCode)
+}
+
+// Quantity returns the measurement as Quantity.
+func (m ServingSize) Quantity() Quantity {
+	return NewQuantity(m.Measurement, m.MeasurementUnitCode)
+}
+
+// Quantity returns the measurement as Quantity.
+func (m QuantityContained) Quantity() Quantity {
+	return NewQuantity(m.Measurement, m.MeasurementUnitCode)
+}
+
+// Quantity returns the measurement as Quantity.
+func (m PriceComparisonMeasurement) Quantity() Quantity {
+	return NewQuantity(m.Measurement, m.MeasurementUnitCode)
+}
+
+// Quantity returns the measurement as Quantity.
+func (m GDSNDepth) Quantity() Quantity {
+	return NewQuantity(m.Value, m.MeasurementUnitCode)
+}
+
+// Quantity returns the measurement as Quantity.
+func (m GDSNHeight) Quantity() Quantity {
+	return NewQuantity(m.Value, m.MeasurementUnitCode)
+}
+
+// Quantity returns the measurement as Quantity.
+func (m GDSNWidth) Quantity() Quantity {
+	return NewQuantity(m.Value, m.MeasurementUnitCode)
+}
+
+// Quantity returns the measurement as Quantity.
+func (m GDSNNetContent) Quantity() Quantity {
+	return NewQuantity(m.Measurement, m.MeasurementUnitCode)
+}
+
+// Quantity returns the measurement as Quantity.
+func (m GDSNDrainedWeight) Quantity() Quantity {
+	return NewQuantity(m.Measurement, m.MeasurementUnitCode)
+}
+
+// Quantity returns the measurement as Quantity.
+func (m GDSNGrossWeight) Quantity() Quantity {
+	return NewQuantity(m.Measurement, m.MeasurementUnitCode)
+}
+
+// Quantity returns the measurement as Quantity.
+func (m GDSNNetWeight) Quantity() Quantity {
+	return NewQuantity(m.Measurement, m.MeasurementUnitCode)
+}
+
+// Quantity returns the temperature as Quantity.
+func (m GDSNTemperature) Quantity() Quantity {
+	return NewQuantity(m.Temperature, m.TemperatureMeasurementUnitCode)
+}
+
+// Quantity returns the temperature as Quantity.
+func (m FlashPointTemperature) Quantity() Quantity {
+	return NewQuantity(m.Temperature, m.TemperatureMeasurementUnitCode)
+}
+
+// Volume returns the volume of the trade item calculated from depth, height
+// and width, in the given volume unit.
+func (t TradeItemMeasurements) Volume(unitCode string) (Quantity, error) {
+	return product(UnitCubicMetre, unitCode, t.Depth.Quantity(), t.Height.Quantity(), t.Width.Quantity())
+}
+
+// FrontArea returns the area of the front face of the trade item, width times
+// height, in the given area unit. It is the shelf space the trade item takes
+// when facing the consumer.
+func (t TradeItemMeasurements) FrontArea(unitCode string) (Quantity, error) {
+	return product(UnitSquareMetre, unitCode, t.Height.Quantity(), t.Width.Quantity())
+}
+
+// product returns the product of lengths, which is in unit baseCode, converted
+// to unitCode.
+func product(baseCode, unitCode string, lengths ...Quantity) (Quantity, error) {
+	v := big.NewRat(1, 1)
+	for _, q := range lengths {
+		m, err := q.convert(units[UnitMetre])
+		if err != nil {
+			return Quantity{}, err
+		}
+		v.Mul(v, m)
+	}
+	return NewQuantity(DecimalFromRat(v), baseCode).ConvertTo(unitCode)
+}
@@ -0,0 +1,120 @@
+package structs
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+)
+
+func quantity(value, unitCode string) Quantity {
+	return NewQuantity(mustDecimal(value), unitCode)
+}
+
+func TestLookupUnit(t *testing.T) {
+	u, ok := LookupUnit(" grm ")
+	if !ok || u.Code != UnitGram || u.Dimension != DimensionMass {
+		t.Fatalf("LookupUnit(grm) = %+v, %v", u, ok)
+	}
+	if u.Factor().Cmp(big.NewRat(1, 1000)) != 0 || u.Offset().Sign() != 0 {
+		t.Errorf("GRM factor %s, offset %s", u.Factor(), u.Offset())
+	}
+	u.Factor().SetInt64(5)
+	if u, _ := LookupUnit(UnitGram); u.Factor().Cmp(big.NewRat(1, 1000)) != 0 {
+		t.Error("Factor() returned the factor of the unit, not a copy")
+	}
+	if _, ok := LookupUnit("XYZ"); ok {
+		t.Error("LookupUnit(XYZ) found a unit")
+	}
+	if got := DimensionEnergy.String(); got != "energy" {
+		t.Errorf("DimensionEnergy = %s", got)
+	}
+}
+
+func TestQuantityConvertTo(t *testing.T) {
+	for _, tt := range []struct {
+		q    Quantity
+		to   string
+		want string
+	}{
+		{quantity("1", "LBR"), UnitGram, "453.59237 GRM"},
+		{quantity("0.3", UnitKilogram), UnitGram, "300 GRM"},
+		{quantity("750", UnitMillilitre), UnitLitre, "0.75 LTR"},
+		{quantity("1.50", UnitLitre), UnitLitre, "1.50 LTR"},
+		{quantity("1", "OZA"), UnitMillilitre, "29.5735295625 MLT"},
+		{quantity("212", UnitFahrenheit), UnitDegreeCelsius, "100 CEL"},
+		{quantity("40", UnitFahrenheit), UnitDegreeCelsius, "4.444444444444444444 CEL"},
+		{quantity("-40", UnitDegreeCelsius), UnitFahrenheit, "-40 FAH"},
+		{quantity("0", UnitDegreeCelsius), UnitKelvin, "273.15 KEL"},
+		{quantity("100", UnitKilocalorie), UnitKilojoule, "418.68 KJO"},
+		{quantity("1", "DZN"), UnitPiece, "12 H87"},
+	} {
+		got, err := tt.q.ConvertTo(tt.to)
+		if err != nil {
+			t.Errorf("%s.ConvertTo(%s): %v", tt.q, tt.to, err)
+			continue
+		}
+		if got.String() != tt.want {
+			t.Errorf("%s.ConvertTo(%s) = %s, want %s", tt.q, tt.to, got, tt.want)
+		}
+	}
+	if _, err := quantity("1", UnitGram).ConvertTo(UnitLitre); !errors.Is(err, ErrIncompatibleUnits) {
+		t.Errorf("GRM to LTR error = %v", err)
+	}
+	if _, err := quantity("1", "XYZ").ConvertTo(UnitGram); !errors.Is(err, ErrUnknownUnit) {
+		t.Errorf("XYZ to GRM error = %v", err)
+	}
+	if _, err := quantity("1", UnitGram).ConvertTo("XYZ"); !errors.Is(err, ErrUnknownUnit) {
+		t.Errorf("GRM to XYZ error = %v", err)
+	}
+}
+
+func TestQuantityArithmetic(t *testing.T) {
+	for _, tt := range []struct {
+		a, b Quantity
+		want int
+	}{
+		{quantity("1", "LBR"), quantity("453.59237", UnitGram), 0},
+		{quantity("1", UnitKilogram), quantity("1000.000000001", UnitGram), -1},
+		{quantity("5", UnitDegreeCelsius), quantity("41", UnitFahrenheit), 0},
+		{quantity("1", UnitLitre), quantity("999", UnitMillilitre), 1},
+	} {
+		got, err := tt.a.Compare(tt.b)
+		if err != nil || got != tt.want {
+			t.Errorf("%s.Compare(%s) = %d, %v, want %d", tt.a, tt.b, got, err, tt.want)
+		}
+	}
+
+	sum, err := quantity("0.1", UnitKilogram).Add(quantity("200", UnitGram))
+	if err != nil || sum.String() != "0.3 KGM" {
+		t.Errorf("0.1 KGM + 200 GRM = %s, %v", sum, err)
+	}
+	if _, err := quantity("1", UnitDegreeCelsius).Add(quantity("1", UnitKelvin)); !errors.Is(err, ErrIncompatibleUnits) {
+		t.Errorf("adding temperatures error = %v", err)
+	}
+	if got := quantity("10", UnitGram).Scale(big.NewRat(3, 4)); got.String() != "7.5 GRM" {
+		t.Errorf("10 GRM * 3/4 = %s", got)
+	}
+	if got := quantity("1", UnitGram).Scale(big.NewRat(1, 3)); got.String() != "0.333333333333333333 GRM" {
+		t.Errorf("1 GRM * 1/3 = %s", got)
+	}
+}
+
+func TestTradeItemMeasurementsVolume(t *testing.T) {
+	m := TradeItemMeasurements{
+		Depth:  GDSNDepth{Value: mustDecimal("70"), MeasurementUnitCode: UnitMillimetre},
+		Height: GDSNHeight{Value: mustDecimal("19.5"), MeasurementUnitCode: UnitCentimetre},
+		Width:  GDSNWidth{Value: mustDecimal("0.07"), MeasurementUnitCode: UnitMetre},
+	}
+	v, err := m.Volume(UnitMillilitre)
+	if err != nil || v.String() != "955.5 MLT" {
+		t.Errorf("Volume(MLT) = %s, %v", v, err)
+	}
+	a, err := m.FrontArea("CMK")
+	if err != nil || a.String() != "136.5 CMK" {
+		t.Errorf("FrontArea(CMK) = %s, %v", a, err)
+	}
+	m.Width.MeasurementUnitCode = UnitGram
+	if _, err := m.Volume(UnitLitre); !errors.Is(err, ErrIncompatibleUnits) {
+		t.Errorf("Volume with a mass error = %v", err)
+	}
+}
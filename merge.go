@@ -0,0 +1,327 @@
+package structs
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// MergeStrategy tells how a merge resolves conflicting changes.
+type MergeStrategy int
+
+const (
+	// MergeReport leaves the conflict unresolved. Ours is kept in the result.
+	MergeReport MergeStrategy = iota
+	// MergeOurs resolves the conflict by taking our change.
+	MergeOurs
+	// MergeTheirs resolves the conflict by taking their change.
+	MergeTheirs
+)
+
+// String returns the lower case name of the strategy.
+func (s MergeStrategy) String() string {
+	switch s {
+	case MergeReport:
+		return "report"
+	case MergeOurs:
+		return "ours"
+	case MergeTheirs:
+		return "theirs"
+	}
+	return fmt.Sprintf("strategy(%d)", int(s))
+}
+
+// MergePolicy assigns merge strategies to parts of the product.
+type MergePolicy struct {
+	// Strategy used where no path pattern matches.
+	Default MergeStrategy
+	// Strategies by path pattern. A pattern is a JSON pointer in which the
+	// token "*" matches any member or index, for example
+	// /tradeItem/tradeItemInformation/extensions/marketingInformationModule
+	// or /tradeItem/tradeItemInformation/extensions/dgMediaModule/media/*/uri.
+	// A pattern applies to the location and everything below it. The
+	// longest matching pattern wins.
+	Paths map[string]MergeStrategy
+}
+
+// strategy returns the strategy for a JSON pointer.
+func (p MergePolicy) strategy(path string) MergeStrategy {
+	tokens, _ := SplitPointer(path)
+	best, strategy := -1, p.Default
+	for pattern, s := range p.Paths {
+		pt, ok := SplitPointer(pattern)
+		if !ok || len(pt) > len(tokens) || len(pt) <= best {
+			continue
+		}
+		match := true
+		for i, t := range pt {
+			if t != "*" && t != tokens[i] {
+				match = false
+				break
+			}
+		}
+		if match {
+			best, strategy = len(pt), s
+		}
+	}
+	return strategy
+}
+
+// MergeConflict is a location changed differently by both sides.
+type MergeConflict struct {
+	// JSON pointer to the location in the merged product.
+	Path string
+	// Values as decoded JSON. A value absent from a side is nil.
+	Base, Ours, Theirs interface{}
+	// Strategy applied. MergeReport means the conflict is unresolved.
+	Strategy MergeStrategy
+}
+
+// Resolved reports whether the conflict was resolved by the policy.
+func (c MergeConflict) Resolved() bool {
+	return c.Strategy != MergeReport
+}
+
+// String returns the conflict in human readable form.
+func (c MergeConflict) String() string {
+	b, _ := json.Marshal(c.Base)
+	o, _ := json.Marshal(c.Ours)
+	t, _ := json.Marshal(c.Theirs)
+	return fmt.Sprintf("%s: base %s, ours %s, theirs %s (%s)", c.Path, b, o, t, c.Strategy)
+}
+
+// missingJSON marks a value absent from one side of a merge.
+type missingJSON struct{}
+
+var missing = missingJSON{}
+
+// Merge does a three-way merge of two products edited from a common base,
+// for example supplier data as ours and own enrichment as theirs. Changes
+// made by one side only are taken as such. Changes made by both sides
+// differently are conflicts resolved by the policy. All conflicts are
+// returned, resolved ones included, so that they can be audited.
+//
+// Lists are merged element by element: keyed lists, as described in Diff,
+// by key, which covers localized texts, attribute groups and media. Lists of
+// codes and other scalars are merged as sets, keeping elements added by
+// either side and dropping elements removed by either side. Other lists are
+// merged as a whole.
+func Merge(base, ours, theirs MasterProductData, policy MergePolicy) (MasterProductData, []MergeConflict, error) {
+	var trees [3]interface{}
+	for i, v := range []MasterProductData{base, ours, theirs} {
+		t, err := jsonTree(v)
+		if err != nil {
+			return MasterProductData{}, nil, err
+		}
+		trees[i] = t
+	}
+	m := merger{policy: policy}
+	merged := m.merge("", trees[0], trees[1], trees[2])
+	data, err := json.Marshal(merged)
+	if err != nil {
+		return MasterProductData{}, nil, err
+	}
+	var res MasterProductData
+	if err := json.Unmarshal(data, &res); err != nil {
+		return MasterProductData{}, nil, err
+	}
+	return res, m.conflicts, nil
+}
+
+type merger struct {
+	policy    MergePolicy
+	conflicts []MergeConflict
+}
+
+func (m *merger) merge(path string, base, ours, theirs interface{}) interface{} {
+	switch {
+	case jsonEqual(ours, theirs), jsonEqual(base, theirs):
+		return ours
+	case jsonEqual(base, ours):
+		return theirs
+	}
+	if o, ok := ours.(map[string]interface{}); ok {
+		if t, ok := theirs.(map[string]interface{}); ok {
+			b, _ := base.(map[string]interface{})
+			return m.mergeObject(path, b, o, t)
+		}
+	}
+	if o, ok := asList(ours); ok {
+		if t, ok := asList(theirs); ok {
+			if b, ok := asList(base); ok || base == missing {
+				if res, ok := m.mergeList(path, b, o, t); ok {
+					if len(res) == 0 && ours == nil {
+						return nil
+					}
+					return res
+				}
+			}
+		}
+	}
+	return m.conflict(path, base, ours, theirs)
+}
+
+// asList returns lists and null, which nil slices encode to, as lists.
+func asList(v interface{}) ([]interface{}, bool) {
+	if v == nil {
+		return nil, true
+	}
+	l, ok := v.([]interface{})
+	return l, ok
+}
+
+func (m *merger) conflict(path string, base, ours, theirs interface{}) interface{} {
+	s := m.policy.strategy(path)
+	value := func(v interface{}) interface{} {
+		if v == missing {
+			return nil
+		}
+		return v
+	}
+	m.conflicts = append(m.conflicts, MergeConflict{
+		Path:     path,
+		Base:     value(base),
+		Ours:     value(ours),
+		Theirs:   value(theirs),
+		Strategy: s,
+	})
+	if s == MergeTheirs {
+		return theirs
+	}
+	return ours
+}
+
+func (m *merger) mergeObject(path string, base, ours, theirs map[string]interface{}) interface{} {
+	res := make(map[string]interface{}, len(ours))
+	get := func(obj map[string]interface{}, k string) interface{} {
+		if v, ok := obj[k]; ok {
+			return v
+		}
+		return missing
+	}
+	keys := sortedKeys(ours)
+	for _, k := range sortedKeys(theirs) {
+		if _, ok := ours[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	for _, k := range keys {
+		if v := m.merge(JoinPointer(path, k), get(base, k), get(ours, k), get(theirs, k)); v != missing {
+			res[k] = v
+		}
+	}
+	return res
+}
+
+// mergeList merges keyed lists by key and scalar lists as sets. False is
+// returned for other lists.
+func (m *merger) mergeList(path string, base, ours, theirs []interface{}) ([]interface{}, bool) {
+	if key, ok := mergeListKey(base, ours, theirs); ok {
+		return m.mergeKeyed(path, key, base, ours, theirs), true
+	}
+	for _, l := range [][]interface{}{base, ours, theirs} {
+		for _, e := range l {
+			switch e.(type) {
+			case map[string]interface{}, []interface{}:
+				return nil, false
+			}
+		}
+	}
+	count := func(l []interface{}) map[string]int {
+		c := map[string]int{}
+		for _, e := range l {
+			data, _ := json.Marshal(e)
+			c[string(data)]++
+		}
+		return c
+	}
+	b, o, t := count(base), count(ours), count(theirs)
+	var res []interface{}
+	// Each element is kept as many times as the side which changed its count
+	// has it. When both changed it, the smaller count wins: removals win.
+	add := func(e interface{}, k string, n int) {
+		for ; n > 0; n-- {
+			res = append(res, e)
+		}
+		b[k], o[k], t[k] = -1, -1, -1
+	}
+	keep := func(k string) int {
+		switch {
+		case o[k] == b[k]:
+			return t[k]
+		case t[k] == b[k]:
+			return o[k]
+		case o[k] < t[k]:
+			return o[k]
+		}
+		return t[k]
+	}
+	for _, l := range [][]interface{}{ours, theirs} {
+		for _, e := range l {
+			data, _ := json.Marshal(e)
+			if k := string(data); o[k] >= 0 {
+				add(e, k, keep(k))
+			}
+		}
+	}
+	return res, true
+}
+
+// mergeListKey returns the key member of all non-empty lists.
+func mergeListKey(lists ...[]interface{}) (string, bool) {
+	var nonEmpty [][]interface{}
+	for _, l := range lists {
+		if len(l) > 0 {
+			nonEmpty = append(nonEmpty, l)
+		}
+	}
+	if len(nonEmpty) == 0 {
+		return "", false
+	}
+next:
+	for _, key := range listKeys {
+		for _, l := range nonEmpty {
+			if !keyedBy(l, key) {
+				continue next
+			}
+		}
+		return key, true
+	}
+	return "", false
+}
+
+// mergeKeyed merges keyed lists. The order of ours is kept, and elements
+// only in theirs are appended in their order.
+func (m *merger) mergeKeyed(path, key string, base, ours, theirs []interface{}) []interface{} {
+	index := func(l []interface{}) map[string]interface{} {
+		res := make(map[string]interface{}, len(l))
+		for _, e := range l {
+			res[keyValue(e, key)] = e
+		}
+		return res
+	}
+	b, o, t := index(base), index(ours), index(theirs)
+	get := func(idx map[string]interface{}, k string) interface{} {
+		if v, ok := idx[k]; ok {
+			return v
+		}
+		return missing
+	}
+	var keys []string
+	seen := map[string]bool{}
+	for _, l := range [][]interface{}{ours, theirs, base} {
+		for _, e := range l {
+			if k := keyValue(e, key); !seen[k] {
+				seen[k] = true
+				keys = append(keys, k)
+			}
+		}
+	}
+	res := []interface{}{}
+	for _, k := range keys {
+		if v := m.merge(JoinPointer(path, strconv.Itoa(len(res))), get(b, k), get(o, k), get(t, k)); v != missing {
+			res = append(res, v)
+		}
+	}
+	return res
+}
@@ -0,0 +1,129 @@
+package structs
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func masterProduct(t *testing.T, data string) MasterProductData {
+	t.Helper()
+	var d MasterProductData
+	if err := json.Unmarshal([]byte(data), &d); err != nil {
+		t.Fatal(err)
+	}
+	return d
+}
+
+const mergeBase = `{
+	"gtin": "4006381333931",
+	"name": "Milk",
+	"tradeItem": {
+		"gdsnTradeItemClassification": {"gpcCategoryCode": "10000025"},
+		"tradeItemInformation": {"extensions": {
+			"tradeItemDescriptionModule": {"tradeItemDescriptionInformation": {"tradeItemDescription": [
+				{"$": "Maito", "@languageCode": "fi"},
+				{"$": "Mjölk", "@languageCode": "sv"}
+			]}},
+			"marketingInformationModule": {"marketingInformation": {"tradeItemKeyWord": [{"$": "milk", "@languageCode": "en"}]}}
+		}}
+	}
+}`
+
+func TestMerge(t *testing.T) {
+	base := masterProduct(t, mergeBase)
+	ours := masterProduct(t, mergeBase)
+	theirs := masterProduct(t, mergeBase)
+
+	ours.Name = "Milk 1 l"
+	oursDesc := &ours.TradeItem.TradeItemInformation.Extension.TradeItemDescriptionModule.TradeItemDescriptionInformation.TradeItemDescriptions
+	(*oursDesc)[1].Description = "Mjölk 1 l"
+	*oursDesc = append(*oursDesc, TradeItemDescription{Description: "Milk", LanguageCode: "en"})
+
+	theirs.Gtin = "4006381333948"
+	theirs.Name = "Whole milk"
+	theirsDesc := &theirs.TradeItem.TradeItemInformation.Extension.TradeItemDescriptionModule.TradeItemDescriptionInformation.TradeItemDescriptions
+	*theirsDesc = []TradeItemDescription{{Description: "Täysmaito", LanguageCode: "fi"}, (*theirsDesc)[1]}
+	theirs.TradeItem.TradeItemInformation.Extension.MarketingInformationModule.MarketingInformation.TradeItemKeyWords = nil
+
+	merged, conflicts, err := Merge(base, ours, theirs, MergePolicy{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if merged.Gtin != "4006381333948" || merged.Name != "Milk 1 l" {
+		t.Errorf("Merge() gtin %s, name %s", merged.Gtin, merged.Name)
+	}
+	desc := merged.TradeItem.TradeItemInformation.Extension.TradeItemDescriptionModule.TradeItemDescriptionInformation.TradeItemDescriptions
+	want := []TradeItemDescription{
+		{Description: "Täysmaito", LanguageCode: "fi"},
+		{Description: "Mjölk 1 l", LanguageCode: "sv"},
+		{Description: "Milk", LanguageCode: "en"},
+	}
+	if !reflect.DeepEqual(desc, want) {
+		t.Errorf("Merge() descriptions %+v, want %+v", desc, want)
+	}
+	if kw := merged.TradeItem.TradeItemInformation.Extension.MarketingInformationModule.MarketingInformation.TradeItemKeyWords; len(kw) != 0 {
+		t.Errorf("Merge() kept removed keywords %+v", kw)
+	}
+	if len(conflicts) != 1 || conflicts[0].Path != "/name" || conflicts[0].Resolved() ||
+		conflicts[0].String() != `/name: base "Milk", ours "Milk 1 l", theirs "Whole milk" (report)` {
+		t.Errorf("Merge() conflicts %v", conflicts)
+	}
+}
+
+func TestMergePolicy(t *testing.T) {
+	base := masterProduct(t, mergeBase)
+	ours := masterProduct(t, mergeBase)
+	theirs := masterProduct(t, mergeBase)
+	ours.Name, theirs.Name = "ours", "theirs"
+	ours.TradeItem.GdsnTradeItemClassification.GpcCategoryCode = "10000026"
+	theirs.TradeItem.GdsnTradeItemClassification.GpcCategoryCode = "10000027"
+	oursDesc := ours.TradeItem.TradeItemInformation.Extension.TradeItemDescriptionModule.TradeItemDescriptionInformation.TradeItemDescriptions
+	theirsDesc := theirs.TradeItem.TradeItemInformation.Extension.TradeItemDescriptionModule.TradeItemDescriptionInformation.TradeItemDescriptions
+	oursDesc[0].Description, theirsDesc[0].Description = "Maito A", "Maito B"
+
+	policy := MergePolicy{
+		Default: MergeTheirs,
+		Paths: map[string]MergeStrategy{
+			"/tradeItem": MergeOurs,
+			"/tradeItem/*/extensions/tradeItemDescriptionModule/tradeItemDescriptionInformation/tradeItemDescription/*/$": MergeReport,
+		},
+	}
+	merged, conflicts, err := Merge(base, ours, theirs, policy)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if merged.Name != "theirs" || merged.TradeItem.GdsnTradeItemClassification.GpcCategoryCode != "10000026" {
+		t.Errorf("Merge() name %s, category %s", merged.Name, merged.TradeItem.GdsnTradeItemClassification.GpcCategoryCode)
+	}
+	var got []string
+	for _, c := range conflicts {
+		got = append(got, c.Path+" "+c.Strategy.String())
+	}
+	want := []string{
+		"/name theirs",
+		"/tradeItem/gdsnTradeItemClassification/gpcCategoryCode ours",
+		"/tradeItem/tradeItemInformation/extensions/tradeItemDescriptionModule/tradeItemDescriptionInformation/tradeItemDescription/0/$ report",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Merge() conflicts %q, want %q", got, want)
+	}
+	if desc := merged.TradeItem.TradeItemInformation.Extension.TradeItemDescriptionModule.TradeItemDescriptionInformation.TradeItemDescriptions; desc[0].Description != "Maito A" {
+		t.Errorf("unresolved conflict kept %q, want ours", desc[0].Description)
+	}
+}
+
+func TestMergeScalarLists(t *testing.T) {
+	m := merger{}
+	list := func(s ...interface{}) []interface{} { return s }
+	got, ok := m.mergeList("", list("a", "b", "b", "c"), list("a", "b", "c", "d"), list("b", "b", "c", "e"))
+	if want := list("b", "c", "d", "e"); !ok || !reflect.DeepEqual(got, want) {
+		t.Errorf("mergeList() = %v, %v, want %v", got, ok, want)
+	}
+	if _, ok := m.mergeList("", nil, list(list(1)), list(list(2))); ok {
+		t.Error("mergeList() merged lists of lists as sets")
+	}
+	if got := MergeStrategy(7).String(); got != "strategy(7)" {
+		t.Errorf("MergeStrategy(7) = %s", got)
+	}
+}
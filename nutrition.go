@@ -0,0 +1,234 @@
+package structs
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// Preparation state codes. Uses code list preparationStateCode.
+const (
+	PreparationStateUnprepared = "UNPREPARED"
+	PreparationStatePrepared   = "PREPARED"
+)
+
+// Measurement precision codes. Uses code list measurementPrecisionCode.
+const (
+	MeasurementPrecisionApproximately = "APPROXIMATELY"
+	MeasurementPrecisionLessThan      = "LESS_THAN"
+)
+
+// NutrientTypeEnergy is the nutrient type code of energy. Uses code list nutrientTypeCode.
+const NutrientTypeEnergy = "ENER-"
+
+var (
+	// ErrNoNutrientHeader is returned when a product has no nutrient
+	// information for the requested preparation state.
+	ErrNoNutrientHeader = errors.New("no nutrient header")
+	// ErrNoServingSize is returned when a nutrition table can't be computed
+	// because the serving size is missing.
+	ErrNoServingSize = errors.New("no serving size")
+)
+
+// NutrientValue is the amount of one nutrient in a nutrition table.
+type NutrientValue struct {
+	// Nutrient type code. Uses code list nutrientTypeCode.
+	NutrientTypeCode string
+	// Amount of the nutrient.
+	Quantity Quantity
+	// Measurement precision code, for example APPROXIMATELY or LESS_THAN.
+	MeasurementPrecisionCode string
+	// Percentage of the recommended daily intake, scaled like the quantity.
+	DailyValueIntakePercent NullFloat64
+	// Derived is true when the value was not given but computed, for example
+	// energy in kcal from energy in kJ.
+	Derived bool
+}
+
+// LessThan reports whether the quantity is an upper limit rather than an amount.
+func (n NutrientValue) LessThan() bool {
+	return strings.EqualFold(n.MeasurementPrecisionCode, MeasurementPrecisionLessThan)
+}
+
+// NutritionTable is the nutrient content of a product computed for a basis
+// quantity, for example 100 GRM or one serving of 30 GRM.
+type NutritionTable struct {
+	// Preparation state code, for example UNPREPARED.
+	PreparationStateCode string
+	// Quantity of the product the nutrient values refer to.
+	Basis Quantity
+	// Nutrient values in the order of the nutrient header. Energy is given
+	// both in kJ and in kcal.
+	Nutrients []NutrientValue
+}
+
+// Nutrient returns the first value of the nutrient type code.
+func (t NutritionTable) Nutrient(typeCode string) (NutrientValue, bool) {
+	for _, n := range t.Nutrients {
+		if strings.EqualFold(n.NutrientTypeCode, typeCode) {
+			return n, true
+		}
+	}
+	return NutrientValue{}, false
+}
+
+// Energy returns the energy in the given unit, UnitKilojoule or UnitKilocalorie.
+func (t NutritionTable) Energy(unitCode string) (NutrientValue, bool) {
+	for _, n := range t.Nutrients {
+		if strings.EqualFold(n.NutrientTypeCode, NutrientTypeEnergy) && strings.EqualFold(n.Quantity.UnitCode, unitCode) {
+			return n, true
+		}
+	}
+	return NutrientValue{}, false
+}
+
+// stated returns the nutrient values of the header as stated, with energy
+// reconciled between kJ and kcal.
+func (h NutrientHeader) stated() []NutrientValue {
+	var values []NutrientValue
+	for _, d := range h.NutrientDetails {
+		var energy []Quantity
+		for _, q := range d.QuantityContaineds {
+			values = append(values, NutrientValue{
+				NutrientTypeCode:         d.NutrientTypeCode,
+				Quantity:                 q.Quantity(),
+				MeasurementPrecisionCode: d.MeasurementPrecisionCode,
+				DailyValueIntakePercent:  d.DailyValueIntakePercent,
+			})
+			if strings.EqualFold(d.NutrientTypeCode, NutrientTypeEnergy) {
+				energy = append(energy, q.Quantity())
+			}
+		}
+		if len(energy) == 0 {
+			continue
+		}
+		has := func(unitCode string) bool {
+			for _, q := range energy {
+				if strings.EqualFold(q.UnitCode, unitCode) {
+					return true
+				}
+			}
+			return false
+		}
+		for _, unitCode := range []string{UnitKilojoule, UnitKilocalorie} {
+			if has(unitCode) {
+				continue
+			}
+			q, err := energy[0].ConvertTo(unitCode)
+			if err != nil {
+				continue
+			}
+			values = append(values, NutrientValue{
+				NutrientTypeCode:         d.NutrientTypeCode,
+				Quantity:                 q,
+				MeasurementPrecisionCode: d.MeasurementPrecisionCode,
+				Derived:                  true,
+			})
+		}
+	}
+	return values
+}
+
+// servingSize returns the first serving size with a measurable unit.
+func (h NutrientHeader) servingSize() (Quantity, bool) {
+	for _, s := range h.ServingSizes {
+		q := s.Quantity()
+		if d, err := q.Dimension(); err == nil && (d == DimensionMass || d == DimensionVolume) && q.Value.Sign() > 0 {
+			return q, true
+		}
+	}
+	return Quantity{}, false
+}
+
+// basis returns the quantity of product the stated values refer to. When the
+// nutrient basis quantity is not a mass or volume, for example 1 H87, the
+// values are stated per serving and the serving size is returned.
+func (h NutrientHeader) basis() (Quantity, error) {
+	b := h.NutrientBasisQuantity.Quantity()
+	if d, err := b.Dimension(); err == nil && (d == DimensionMass || d == DimensionVolume) && b.Value.Sign() > 0 {
+		return b, nil
+	}
+	if s, ok := h.servingSize(); ok {
+		return s, nil
+	}
+	return Quantity{}, fmt.Errorf("nutrient basis quantity %s: %w", b, ErrNoServingSize)
+}
+
+// table returns the nutrition table scaled from the stated basis to the given basis.
+func (h NutrientHeader) table(basis Quantity) (NutritionTable, error) {
+	stated, err := h.basis()
+	if err != nil {
+		return NutritionTable{}, err
+	}
+	b, err := basis.ConvertTo(stated.UnitCode)
+	if err != nil {
+		return NutritionTable{}, err
+	}
+	f := new(big.Rat).Quo(b.Value.Rat(), stated.Value.Rat())
+	t := NutritionTable{PreparationStateCode: h.PreparationStateCode, Basis: basis}
+	for _, n := range h.stated() {
+		n.Quantity = n.Quantity.Scale(f)
+		if n.DailyValueIntakePercent.Valid {
+			p := DecimalFromFloat(n.DailyValueIntakePercent.Float64).Rat()
+			n.DailyValueIntakePercent.Float64, _ = p.Mul(p, f).Float64()
+		}
+		t.Nutrients = append(t.Nutrients, n)
+	}
+	return t, nil
+}
+
+// Per100 returns the nutrition table per 100 GRM or 100 MLT, depending on
+// whether the nutrient basis quantity or serving size is a mass or a volume.
+// Values stated as less than a limit remain upper limits after scaling.
+func (h NutrientHeader) Per100() (NutritionTable, error) {
+	stated, err := h.basis()
+	if err != nil {
+		return NutritionTable{}, err
+	}
+	basis := NewQuantity(DecimalFromInt(100), UnitGram)
+	if d, _ := stated.Dimension(); d == DimensionVolume {
+		basis = NewQuantity(DecimalFromInt(100), UnitMillilitre)
+	}
+	return h.table(basis)
+}
+
+// PerServing returns the nutrition table per serving. ErrNoServingSize is
+// returned when the header has no serving size given as a mass or a volume.
+func (h NutrientHeader) PerServing() (NutritionTable, error) {
+	s, ok := h.servingSize()
+	if !ok {
+		return NutritionTable{}, ErrNoServingSize
+	}
+	return h.table(s)
+}
+
+// Header returns the nutrient header of the preparation state, for example
+// PreparationStateUnprepared. ErrNoNutrientHeader is returned when the module
+// has no header for the preparation state.
+func (m NutritionalInformationModule) Header(preparationState string) (NutrientHeader, error) {
+	for _, h := range m.NutrientHeaders {
+		if strings.EqualFold(h.PreparationStateCode, preparationState) {
+			return h, nil
+		}
+	}
+	return NutrientHeader{}, fmt.Errorf("%s: %w", preparationState, ErrNoNutrientHeader)
+}
+
+// Per100 returns the nutrition table of the preparation state per 100 GRM or 100 MLT.
+func (m NutritionalInformationModule) Per100(preparationState string) (NutritionTable, error) {
+	h, err := m.Header(preparationState)
+	if err != nil {
+		return NutritionTable{}, err
+	}
+	return h.Per100()
+}
+
+// PerServing returns the nutrition table of the preparation state per serving.
+func (m NutritionalInformationModule) PerServing(preparationState string) (NutritionTable, error) {
+	h, err := m.Header(preparationState)
+	if err != nil {
+		return NutritionTable{}, err
+	}
+	return h.PerServing()
+}
@@ -0,0 +1,87 @@
+package structs
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func nutritionModule(t *testing.T, data string) NutritionalInformationModule {
+	t.Helper()
+	var m NutritionalInformationModule
+	if err := json.Unmarshal([]byte(data), &m); err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+func TestNutritionTables(t *testing.T) {
+	m := nutritionModule(t, `{"nutrientHeader": [{
+		"preparationStateCode": "UNPREPARED",
+		"nutrientBasisQuantity": {"$": 1, "@measurementUnitCode": "H87"},
+		"servingSize": [{"$": 1, "@measurementUnitCode": "H87"}, {"$": 30, "@measurementUnitCode": "GRM"}],
+		"nutrientDetail": [
+			{"nutrientTypeCode": "ENER-", "quantityContained": [{"$": 100, "@measurementUnitCode": "E14"}]},
+			{"nutrientTypeCode": "FAT", "measurementPrecisionCode": "LESS_THAN", "dailyValueIntakePercent": 6,
+			 "quantityContained": [{"$": 3, "@measurementUnitCode": "GRM"}]}
+		]
+	}]}`)
+
+	per100, err := m.Per100("unprepared")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if per100.Basis.String() != "100 GRM" || per100.PreparationStateCode != PreparationStateUnprepared {
+		t.Errorf("Per100() basis %s, state %s", per100.Basis, per100.PreparationStateCode)
+	}
+	kcal, ok := per100.Energy(UnitKilocalorie)
+	if !ok || kcal.Derived || kcal.Quantity.String() != "333.333333333333333333 E14" {
+		t.Errorf("Energy(E14) = %+v, %v", kcal, ok)
+	}
+	kj, ok := per100.Energy(UnitKilojoule)
+	if !ok || !kj.Derived || kj.Quantity.String() != "1395.6 KJO" {
+		t.Errorf("Energy(KJO) = %+v, %v", kj, ok)
+	}
+	fat, ok := per100.Nutrient("fat")
+	if !ok || fat.Quantity.String() != "10 GRM" || !fat.LessThan() || fat.DailyValueIntakePercent != ValidFloat64(20) {
+		t.Errorf("Nutrient(fat) = %+v, %v", fat, ok)
+	}
+	if _, ok := per100.Nutrient("PRO-"); ok {
+		t.Error("Nutrient(PRO-) found a nutrient that is not stated")
+	}
+
+	serving, err := m.PerServing(PreparationStateUnprepared)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fat, _ := serving.Nutrient("FAT"); serving.Basis.String() != "30 GRM" || fat.Quantity.String() != "3 GRM" {
+		t.Errorf("PerServing() basis %s, fat %s", serving.Basis, fat.Quantity)
+	}
+
+	if _, err := m.Per100(PreparationStatePrepared); !errors.Is(err, ErrNoNutrientHeader) {
+		t.Errorf("Per100(PREPARED) error = %v", err)
+	}
+}
+
+func TestNutritionTableVolume(t *testing.T) {
+	m := nutritionModule(t, `{"nutrientHeader": [{
+		"preparationStateCode": "PREPARED",
+		"nutrientBasisQuantity": {"$": 0.25, "@measurementUnitCode": "LTR"},
+		"nutrientDetail": [{"nutrientTypeCode": "SUGAR-", "quantityContained": [{"$": 12.5, "@measurementUnitCode": "GRM"}]}]
+	}]}`)
+	per100, err := m.Per100(PreparationStatePrepared)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sugar, _ := per100.Nutrient("SUGAR-"); per100.Basis.String() != "100 MLT" || sugar.Quantity.String() != "5 GRM" {
+		t.Errorf("Per100() basis %s, sugar %s", per100.Basis, sugar.Quantity)
+	}
+	if _, err := m.PerServing(PreparationStatePrepared); !errors.Is(err, ErrNoServingSize) {
+		t.Errorf("PerServing() error = %v", err)
+	}
+
+	m.NutrientHeaders[0].NutrientBasisQuantity.MeasurementUnitCode = "H87"
+	if _, err := m.Per100(PreparationStatePrepared); !errors.Is(err, ErrNoServingSize) {
+		t.Errorf("Per100() without a measurable basis error = %v", err)
+	}
+}
@@ -0,0 +1,242 @@
+package structs
+
+import (
+	"encoding/json"
+	"strconv"
+)
+
+// The Null types represent optional attributes, which may be absent from the
+// data. They follow the conventions of database/sql: Valid is false when the
+// attribute is absent or null. Absent attributes are encoded as JSON null and
+// omitted from XML.
+
+var jsonNull = []byte("null")
+
+// NullFloat64 is an optional float64 attribute.
+type NullFloat64 struct {
+	Float64 float64
+	// Valid is true if Float64 is given.
+	Valid bool
+}
+
+// ValidFloat64 returns a given NullFloat64.
+func ValidFloat64(f float64) NullFloat64 {
+	return NullFloat64{Float64: f, Valid: true}
+}
+
+// Get returns the value and whether it is given.
+func (n NullFloat64) Get() (float64, bool) {
+	return n.Float64, n.Valid
+}
+
+// Or returns the value if it is given, otherwise def.
+func (n NullFloat64) Or(def float64) float64 {
+	if !n.Valid {
+		return def
+	}
+	return n.Float64
+}
+
+// IsZero reports whether the value is absent.
+func (n NullFloat64) IsZero() bool {
+	return !n.Valid
+}
+
+// MarshalJSON implements json.Marshaler interface.
+func (n NullFloat64) MarshalJSON() ([]byte, error) {
+	if !n.Valid {
+		return jsonNull, nil
+	}
+	return json.Marshal(n.Float64)
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface.
+func (n *NullFloat64) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*n = NullFloat64{}
+		return nil
+	}
+	if err := json.Unmarshal(data, &n.Float64); err != nil {
+		return err
+	}
+	n.Valid = true
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler interface.
+func (n NullFloat64) MarshalText() ([]byte, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return []byte(strconv.FormatFloat(n.Float64, 'f', -1, 64)), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler interface.
+func (n *NullFloat64) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*n = NullFloat64{}
+		return nil
+	}
+	f, err := strconv.ParseFloat(string(text), 64)
+	if err != nil {
+		return err
+	}
+	*n = ValidFloat64(f)
+	return nil
+}
+
+// NullInt is an optional int attribute.
+type NullInt struct {
+	Int int
+	// Valid is true if Int is given.
+	Valid bool
+}
+
+// ValidInt returns a given NullInt.
+func ValidInt(i int) NullInt {
+	return NullInt{Int: i, Valid: true}
+}
+
+// Get returns the value and whether it is given.
+func (n NullInt) Get() (int, bool) {
+	return n.Int, n.Valid
+}
+
+// Or returns the value if it is given, otherwise def.
+func (n NullInt) Or(def int) int {
+	if !n.Valid {
+		return def
+	}
+	return n.Int
+}
+
+// IsZero reports whether the value is absent.
+func (n NullInt) IsZero() bool {
+	return !n.Valid
+}
+
+// MarshalJSON implements json.Marshaler interface.
+func (n NullInt) MarshalJSON() ([]byte, error) {
+	if !n.Valid {
+		return jsonNull, nil
+	}
+	return json.Marshal(n.Int)
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface.
+func (n *NullInt) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*n = NullInt{}
+		return nil
+	}
+	if err := json.Unmarshal(data, &n.Int); err != nil {
+		return err
+	}
+	n.Valid = true
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler interface.
+func (n NullInt) MarshalText() ([]byte, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return []byte(strconv.Itoa(n.Int)), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler interface.
+func (n *NullInt) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*n = NullInt{}
+		return nil
+	}
+	i, err := strconv.Atoi(string(text))
+	if err != nil {
+		return err
+	}
+	*n = ValidInt(i)
+	return nil
+}
+
+// NullBool is an optional bool attribute.
+type NullBool struct {
+	Bool bool
+	// Valid is true if Bool is given.
+	Valid bool
+}
+
+// ValidBool returns a given NullBool.
+func ValidBool(b bool) NullBool {
+	return NullBool{Bool: b, Valid: true}
+}
+
+// Get returns the value and whether it is given.
+func (n NullBool) Get() (bool, bool) {
+	return n.Bool, n.Valid
+}
+
+// Or returns the value if it is given, otherwise def.
+func (n NullBool) Or(def bool) bool {
+	if !n.Valid {
+		return def
+	}
+	return n.Bool
+}
+
+// IsTrue reports whether the value is given and true.
+func (n NullBool) IsTrue() bool {
+	return n.Valid && n.Bool
+}
+
+// IsFalse reports whether the value is given and false.
+func (n NullBool) IsFalse() bool {
+	return n.Valid && !n.Bool
+}
+
+// IsZero reports whether the value is absent.
+func (n NullBool) IsZero() bool {
+	return !n.Valid
+}
+
+// MarshalJSON implements json.Marshaler interface.
+func (n NullBool) MarshalJSON() ([]byte, error) {
+	if !n.Valid {
+		return jsonNull, nil
+	}
+	return json.Marshal(n.Bool)
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface.
+func (n *NullBool) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*n = NullBool{}
+		return nil
+	}
+	if err := json.Unmarshal(data, &n.Bool); err != nil {
+		return err
+	}
+	n.Valid = true
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler interface.
+func (n NullBool) MarshalText() ([]byte, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return []byte(strconv.FormatBool(n.Bool)), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler interface.
+func (n *NullBool) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*n = NullBool{}
+		return nil
+	}
+	b, err := strconv.ParseBool(string(text))
+	if err != nil {
+		return err
+	}
+	*n = ValidBool(b)
+	return nil
+}
@@ -0,0 +1,98 @@
+package structs
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestNullJSON(t *testing.T) {
+	var v struct {
+		F NullFloat64 `json:"f"`
+		I NullInt     `json:"i"`
+		B NullBool    `json:"b"`
+	}
+	for _, tt := range []struct {
+		data string
+		f    NullFloat64
+		i    NullInt
+		b    NullBool
+	}{
+		{data: `{"f":null,"i":null,"b":null}`},
+		{data: `{"f":0,"i":0,"b":false}`, f: ValidFloat64(0), i: ValidInt(0), b: ValidBool(false)},
+		{data: `{"f":1.5,"i":-3,"b":true}`, f: ValidFloat64(1.5), i: ValidInt(-3), b: ValidBool(true)},
+	} {
+		v.F, v.I, v.B = ValidFloat64(9), ValidInt(9), ValidBool(true)
+		if err := json.Unmarshal([]byte(tt.data), &v); err != nil {
+			t.Fatalf("Unmarshal(%s): %v", tt.data, err)
+		}
+		if v.F != tt.f || v.I != tt.i || v.B != tt.b {
+			t.Errorf("Unmarshal(%s) = %+v", tt.data, v)
+		}
+		data, err := json.Marshal(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != tt.data {
+			t.Errorf("Marshal(%+v) = %s, want %s", v, data, tt.data)
+		}
+	}
+	if err := json.Unmarshal([]byte(`{"i":1.5}`), &v); err == nil {
+		t.Error("Unmarshal accepted 1.5 as int")
+	}
+}
+
+func TestNullText(t *testing.T) {
+	var f NullFloat64
+	if err := f.UnmarshalText([]byte("2.25")); err != nil || f != ValidFloat64(2.25) {
+		t.Errorf("NullFloat64.UnmarshalText(2.25) = %+v, %v", f, err)
+	}
+	if err := f.UnmarshalText(nil); err != nil || f.Valid {
+		t.Errorf("NullFloat64.UnmarshalText(empty) = %+v, %v", f, err)
+	}
+	var i NullInt
+	if err := i.UnmarshalText([]byte("x")); err == nil {
+		t.Error("NullInt.UnmarshalText(x) accepted")
+	}
+	var b NullBool
+	if err := b.UnmarshalText([]byte("false")); err != nil || b != ValidBool(false) {
+		t.Errorf("NullBool.UnmarshalText(false) = %+v, %v", b, err)
+	}
+	for _, m := range []interface{ MarshalText() ([]byte, error) }{NullFloat64{}, NullInt{}, NullBool{}} {
+		if text, err := m.MarshalText(); err != nil || len(text) != 0 {
+			t.Errorf("%T.MarshalText() = %q, %v, want empty", m, text, err)
+		}
+	}
+	if text, _ := ValidInt(0).MarshalText(); string(text) != "0" {
+		t.Errorf("ValidInt(0).MarshalText() = %q", text)
+	}
+}
+
+func TestNullAccessors(t *testing.T) {
+	if got := (NullFloat64{}).Or(1); got != 1 {
+		t.Errorf("NullFloat64{}.Or(1) = %v", got)
+	}
+	if got := ValidFloat64(0).Or(1); got != 0 {
+		t.Errorf("ValidFloat64(0).Or(1) = %v", got)
+	}
+	if i, ok := ValidInt(4).Get(); i != 4 || !ok {
+		t.Errorf("ValidInt(4).Get() = %v, %v", i, ok)
+	}
+	if _, ok := (NullInt{Int: 4}).Get(); ok {
+		t.Error("NullInt{Int: 4}.Get() is valid")
+	}
+	for _, tt := range []struct {
+		b             NullBool
+		isTrue, isFal bool
+	}{
+		{NullBool{}, false, false},
+		{ValidBool(true), true, false},
+		{ValidBool(false), false, true},
+	} {
+		if tt.b.IsTrue() != tt.isTrue || tt.b.IsFalse() != tt.isFal {
+			t.Errorf("%+v: IsTrue() = %v, IsFalse() = %v", tt.b, tt.b.IsTrue(), tt.b.IsFalse())
+		}
+		if tt.b.IsZero() != !tt.b.Valid {
+			t.Errorf("%+v: IsZero() = %v", tt.b, tt.b.IsZero())
+		}
+	}
+}
@@ -0,0 +1,414 @@
+package structs
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The Protocol Buffers encoding follows master-product.proto, which is
+// generated from the same spec as the types. Field numbers are given by the
+// proto tags. Optional fields keep the presence of the Null types, decimals
+// and times are encoded as text and json.RawMessage as bytes, so that values
+// read back as they were. Lists keep their elements, and localized texts
+// their language codes, in order. Repeated fields have no presence in
+// Protocol Buffers, so an empty list reads back as nil.
+
+// ErrInvalidProto is returned when Protocol Buffers data does not match the
+// messages of master-product.proto.
+var ErrInvalidProto = errors.New("invalid protocol buffers data")
+
+var (
+	decimalType   = reflect.TypeOf(Decimal{})
+	rawMessagePtr = reflect.TypeOf((*json.RawMessage)(nil))
+)
+
+// Protocol Buffers wire types.
+const (
+	wireVarint  = 0
+	wireFixed64 = 1
+	wireBytes   = 2
+	wireFixed32 = 5
+)
+
+// MarshalProto returns the Protocol Buffers encoding of v, which is
+// MasterProductData or any other type of the product with a message of the
+// same name in master-product.proto, for example MasterProductTradeItem or
+// NutritionalInformationModule. The data can be decoded with code generated
+// from master-product.proto:
+//
+//	data, err := structs.MarshalProto(product)
+//	var msg pb.MasterProductData
+//	err = proto.Unmarshal(data, &msg)
+func MarshalProto(v interface{}) ([]byte, error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Ptr {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("structs: cannot encode %T as Protocol Buffers", v)
+	}
+	return appendProtoMessage(nil, rv)
+}
+
+// UnmarshalProto decodes the Protocol Buffers encoding of the message of v,
+// which must be a pointer to a type of the product. See MarshalProto. Fields
+// unknown to the type are skipped. Errors for malformed data wrap
+// ErrInvalidProto and tell the JSON pointer of the field.
+func UnmarshalProto(data []byte, v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("structs: cannot decode Protocol Buffers into %T", v)
+	}
+	res := reflect.New(rv.Elem().Type()).Elem()
+	if err := unmarshalProtoMessage(data, res, ""); err != nil {
+		return err
+	}
+	rv.Elem().Set(res)
+	return nil
+}
+
+type protoField struct {
+	index  int
+	number int
+	// JSON name, used in error paths.
+	name string
+}
+
+// protoFields returns the fields of a struct having a field number, in the
+// order of the numbers.
+func protoFields(t reflect.Type) []protoField {
+	var fields []protoField
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		n, err := strconv.Atoi(f.Tag.Get("proto"))
+		if f.PkgPath != "" || err != nil || n <= 0 {
+			continue
+		}
+		fields = append(fields, protoField{i, n, strings.Split(f.Tag.Get("json"), ",")[0]})
+	}
+	sort.Slice(fields, func(i, j int) bool { return fields[i].number < fields[j].number })
+	return fields
+}
+
+func appendProtoMessage(b []byte, v reflect.Value) ([]byte, error) {
+	for _, f := range protoFields(v.Type()) {
+		var err error
+		if b, err = appendProtoField(b, f.number, v.Field(f.index)); err != nil {
+			return nil, err
+		}
+	}
+	return b, nil
+}
+
+// appendProtoField appends a field unless it has the default value of
+// Protocol Buffers, which reads back as the zero value.
+func appendProtoField(b []byte, num int, v reflect.Value) ([]byte, error) {
+	switch v.Type() {
+	case decimalType:
+		if text := v.Interface().(Decimal).text; text != "" {
+			b = appendProtoBytes(b, num, []byte(text))
+		}
+		return b, nil
+	case nullFloat64Type:
+		if n := v.Interface().(NullFloat64); n.Valid {
+			b = appendProtoFixed64(b, num, math.Float64bits(n.Float64))
+		}
+		return b, nil
+	case nullIntType:
+		if n := v.Interface().(NullInt); n.Valid {
+			b = appendProtoVarint(b, num, uint64(n.Int))
+		}
+		return b, nil
+	case nullBoolType:
+		if n := v.Interface().(NullBool); n.Valid {
+			b = appendProtoVarint(b, num, protoBool(n.Bool))
+		}
+		return b, nil
+	case timeType:
+		t := v.Interface().(time.Time)
+		if t.IsZero() {
+			return b, nil
+		}
+		text, err := t.MarshalText()
+		if err != nil {
+			return nil, err
+		}
+		return appendProtoBytes(b, num, text), nil
+	case rawMessagePtr:
+		if !v.IsNil() {
+			b = appendProtoBytes(b, num, v.Elem().Bytes())
+		}
+		return b, nil
+	}
+	switch v.Kind() {
+	case reflect.String:
+		if v.Len() > 0 {
+			b = appendProtoBytes(b, num, []byte(v.String()))
+		}
+	case reflect.Bool:
+		if v.Bool() {
+			b = appendProtoVarint(b, num, 1)
+		}
+	case reflect.Int:
+		if v.Int() != 0 {
+			b = appendProtoVarint(b, num, uint64(v.Int()))
+		}
+	case reflect.Float64:
+		if bits := math.Float64bits(v.Float()); bits != 0 {
+			b = appendProtoFixed64(b, num, bits)
+		}
+	case reflect.Struct:
+		msg, err := appendProtoMessage(nil, v)
+		if err != nil {
+			return nil, err
+		}
+		if len(msg) > 0 {
+			b = appendProtoBytes(b, num, msg)
+		}
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			var err error
+			if b, err = appendProtoElement(b, num, v.Index(i)); err != nil {
+				return nil, err
+			}
+		}
+	default:
+		return nil, fmt.Errorf("structs: cannot encode %s as Protocol Buffers", v.Type())
+	}
+	return b, nil
+}
+
+// appendProtoElement appends an element of a repeated field. A list element
+// is a message with the list as field 1.
+func appendProtoElement(b []byte, num int, v reflect.Value) ([]byte, error) {
+	if isProtoScalar(v.Type()) {
+		if v.Kind() != reflect.String {
+			return nil, fmt.Errorf("structs: cannot encode list of %s as Protocol Buffers", v.Type())
+		}
+		return appendProtoBytes(b, num, []byte(v.String())), nil
+	}
+	var msg []byte
+	var err error
+	switch v.Kind() {
+	case reflect.Struct:
+		msg, err = appendProtoMessage(nil, v)
+	case reflect.Slice:
+		msg, err = appendProtoField(nil, 1, v)
+	default:
+		err = fmt.Errorf("structs: cannot encode list of %s as Protocol Buffers", v.Type())
+	}
+	if err != nil {
+		return nil, err
+	}
+	return appendProtoBytes(b, num, msg), nil
+}
+
+// isProtoScalar reports whether values of type t are not messages.
+func isProtoScalar(t reflect.Type) bool {
+	switch t {
+	case decimalType, nullFloat64Type, nullIntType, nullBoolType, timeType, rawMessagePtr:
+		return true
+	}
+	return t.Kind() != reflect.Struct && t.Kind() != reflect.Slice
+}
+
+func protoBool(b bool) uint64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func appendProtoVarint(b []byte, num int, v uint64) []byte {
+	b = appendVarint(b, uint64(num)<<3|wireVarint)
+	return appendVarint(b, v)
+}
+
+func appendProtoFixed64(b []byte, num int, v uint64) []byte {
+	b = appendVarint(b, uint64(num)<<3|wireFixed64)
+	var buf [8]byte
+	binary.LittleEndian.PutUint64(buf[:], v)
+	return append(b, buf[:]...)
+}
+
+func appendProtoBytes(b []byte, num int, v []byte) []byte {
+	b = appendVarint(b, uint64(num)<<3|wireBytes)
+	b = appendVarint(b, uint64(len(v)))
+	return append(b, v...)
+}
+
+func appendVarint(b []byte, v uint64) []byte {
+	var buf [binary.MaxVarintLen64]byte
+	return append(b, buf[:binary.PutUvarint(buf[:], v)]...)
+}
+
+// unmarshalProtoMessage decodes the fields of a message into the struct v.
+// Fields given more than once are merged as Protocol Buffers does: the last
+// scalar wins, messages are merged and repeated fields appended.
+func unmarshalProtoMessage(data []byte, v reflect.Value, path string) error {
+	fields := map[int]protoField{}
+	for _, f := range protoFields(v.Type()) {
+		fields[f.number] = f
+	}
+	for len(data) > 0 {
+		var (
+			num, wire int
+			x         uint64
+			raw       []byte
+			err       error
+		)
+		if num, wire, x, raw, data, err = readProtoField(data, path); err != nil {
+			return err
+		}
+		if f, ok := fields[num]; ok {
+			if err := setProtoField(v.Field(f.index), wire, x, raw, JoinPointer(path, f.name)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// unmarshalProtoList decodes a list message, which has the elements as field
+// 1, into the slice v.
+func unmarshalProtoList(data []byte, v reflect.Value, path string) error {
+	for len(data) > 0 {
+		var (
+			num, wire int
+			x         uint64
+			raw       []byte
+			err       error
+		)
+		if num, wire, x, raw, data, err = readProtoField(data, path); err != nil {
+			return err
+		}
+		if num == 1 {
+			if err := setProtoField(v, wire, x, raw, path); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// readProtoField reads the first field of data. The value is x for numeric
+// wire types and raw for bytes.
+func readProtoField(data []byte, path string) (num, wire int, x uint64, raw, rest []byte, err error) {
+	key, n := binary.Uvarint(data)
+	if n <= 0 || key>>3 == 0 || key>>3 > math.MaxInt32 {
+		return 0, 0, 0, nil, nil, fmt.Errorf("%q: malformed field key: %w", path, ErrInvalidProto)
+	}
+	data = data[n:]
+	num, wire = int(key>>3), int(key&7)
+	switch wire {
+	case wireVarint:
+		if x, n = binary.Uvarint(data); n <= 0 {
+			return 0, 0, 0, nil, nil, fmt.Errorf("%q: malformed varint: %w", path, ErrInvalidProto)
+		}
+		data = data[n:]
+	case wireFixed64:
+		if len(data) < 8 {
+			return 0, 0, 0, nil, nil, fmt.Errorf("%q: truncated fixed64: %w", path, ErrInvalidProto)
+		}
+		x, data = binary.LittleEndian.Uint64(data), data[8:]
+	case wireFixed32:
+		if len(data) < 4 {
+			return 0, 0, 0, nil, nil, fmt.Errorf("%q: truncated fixed32: %w", path, ErrInvalidProto)
+		}
+		x, data = uint64(binary.LittleEndian.Uint32(data)), data[4:]
+	case wireBytes:
+		l, n := binary.Uvarint(data)
+		if n <= 0 || l > uint64(len(data)-n) {
+			return 0, 0, 0, nil, nil, fmt.Errorf("%q: truncated bytes: %w", path, ErrInvalidProto)
+		}
+		raw, data = data[n:n+int(l)], data[n+int(l):]
+	default:
+		return 0, 0, 0, nil, nil, fmt.Errorf("%q: unsupported wire type %d: %w", path, wire, ErrInvalidProto)
+	}
+	return num, wire, x, raw, data, nil
+}
+
+func setProtoField(v reflect.Value, wire int, x uint64, raw []byte, path string) error {
+	want := wireBytes
+	switch {
+	case v.Type() == nullFloat64Type || v.Kind() == reflect.Float64:
+		want = wireFixed64
+	case v.Type() == nullIntType || v.Type() == nullBoolType || v.Kind() == reflect.Int || v.Kind() == reflect.Bool:
+		want = wireVarint
+	}
+	if wire != want {
+		return fmt.Errorf("%q: wire type %d instead of %d: %w", path, wire, want, ErrInvalidProto)
+	}
+
+	switch v.Type() {
+	case decimalType:
+		d, err := ParseDecimal(string(raw))
+		if err != nil {
+			return fmt.Errorf("%q: %v: %w", path, err, ErrInvalidProto)
+		}
+		v.Set(reflect.ValueOf(d))
+		return nil
+	case nullFloat64Type:
+		v.Set(reflect.ValueOf(ValidFloat64(math.Float64frombits(x))))
+		return nil
+	case nullIntType:
+		v.Set(reflect.ValueOf(NullInt{Int: int(int64(x)), Valid: true}))
+		return nil
+	case nullBoolType:
+		v.Set(reflect.ValueOf(NullBool{Bool: x != 0, Valid: true}))
+		return nil
+	case timeType:
+		var t time.Time
+		if err := t.UnmarshalText(raw); err != nil {
+			return fmt.Errorf("%q: %v: %w", path, err, ErrInvalidProto)
+		}
+		v.Set(reflect.ValueOf(t))
+		return nil
+	case rawMessagePtr:
+		msg := json.RawMessage(append([]byte(nil), raw...))
+		v.Set(reflect.ValueOf(&msg))
+		return nil
+	}
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(string(raw))
+	case reflect.Bool:
+		v.SetBool(x != 0)
+	case reflect.Int:
+		v.SetInt(int64(x))
+	case reflect.Float64:
+		v.SetFloat(math.Float64frombits(x))
+	case reflect.Struct:
+		return unmarshalProtoMessage(raw, v, path)
+	case reflect.Slice:
+		elem := reflect.New(v.Type().Elem()).Elem()
+		p := JoinPointer(path, strconv.Itoa(v.Len()))
+		switch {
+		case isProtoScalar(elem.Type()):
+			if elem.Kind() != reflect.String {
+				return fmt.Errorf("structs: cannot decode Protocol Buffers into list of %s", elem.Type())
+			}
+			elem.SetString(string(raw))
+		case elem.Kind() == reflect.Struct:
+			if err := unmarshalProtoMessage(raw, elem, p); err != nil {
+				return err
+			}
+		default:
+			if err := unmarshalProtoList(raw, elem, p); err != nil {
+				return err
+			}
+		}
+		v.Set(reflect.Append(v, elem))
+	default:
+		return fmt.Errorf("structs: cannot decode Protocol Buffers into %s", v.Type())
+	}
+	return nil
+}
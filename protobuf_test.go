@@ -0,0 +1,158 @@
+package structs
+
+import (
+	"encoding/json"
+	"errors"
+	"math/rand"
+	"reflect"
+	"strconv"
+	"testing"
+	"testing/quick"
+	"time"
+)
+
+// randomProduct is a MasterProductData with random values for testing/quick.
+// Lists are nil or have elements, as empty lists read back as nil.
+type randomProduct struct {
+	MasterProductData
+}
+
+func (randomProduct) Generate(r *rand.Rand, size int) reflect.Value {
+	var p randomProduct
+	randomValue(r, reflect.ValueOf(&p.MasterProductData).Elem())
+	return reflect.ValueOf(p)
+}
+
+var randomRunes = []rune("aZ09 -.,/\"\\<&>äöÅé€\n\t😀")
+
+// randomValue sets v to a random value. About half of the fields of structs
+// are set.
+func randomValue(r *rand.Rand, v reflect.Value) {
+	switch v.Type() {
+	case decimalType:
+		v.Set(reflect.ValueOf(mustDecimal(strconv.Itoa(r.Intn(2000)-1000) + []string{"", ".0", ".5", ".125", "e2"}[r.Intn(5)])))
+		return
+	case nullFloat64Type:
+		v.Set(reflect.ValueOf(ValidFloat64(r.NormFloat64())))
+		return
+	case nullIntType:
+		v.Set(reflect.ValueOf(ValidInt(r.Intn(200) - 100)))
+		return
+	case nullBoolType:
+		v.Set(reflect.ValueOf(ValidBool(r.Intn(2) == 0)))
+		return
+	case timeType:
+		v.Set(reflect.ValueOf(time.Unix(r.Int63n(4e9), int64(r.Intn(1e9))).UTC()))
+		return
+	case rawMessagePtr:
+		msg := json.RawMessage(`{"a":[1,"b",null]}`)
+		v.Set(reflect.ValueOf(&msg))
+		return
+	}
+	switch v.Kind() {
+	case reflect.String:
+		s := make([]rune, r.Intn(8))
+		for i := range s {
+			s[i] = randomRunes[r.Intn(len(randomRunes))]
+		}
+		v.SetString(string(s))
+	case reflect.Bool:
+		v.SetBool(r.Intn(2) == 0)
+	case reflect.Int:
+		v.SetInt(r.Int63n(2000) - 1000)
+	case reflect.Float64:
+		v.SetFloat(r.NormFloat64())
+	case reflect.Struct:
+		for _, f := range protoFields(v.Type()) {
+			if r.Intn(2) == 0 {
+				randomValue(r, v.Field(f.index))
+			}
+		}
+	case reflect.Slice:
+		n := r.Intn(3)
+		if n == 0 {
+			return
+		}
+		s := reflect.MakeSlice(v.Type(), n, n)
+		for i := 0; i < n; i++ {
+			randomValue(r, s.Index(i))
+		}
+		v.Set(s)
+	}
+}
+
+func TestProtoRoundTrip(t *testing.T) {
+	// JSON -> Go -> Protocol Buffers -> Go
+	roundTrip := func(p randomProduct) bool {
+		data, err := json.Marshal(p.MasterProductData)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var d MasterProductData
+		if err := json.Unmarshal(data, &d); err != nil {
+			t.Fatal(err)
+		}
+		msg, err := MarshalProto(d)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got MasterProductData
+		if err := UnmarshalProto(msg, &got); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, d) {
+			back, _ := json.Marshal(got)
+			t.Logf("JSON  %s\nproto %s", data, back)
+			return false
+		}
+		return true
+	}
+	if err := quick.Check(roundTrip, &quick.Config{MaxCount: 200, Rand: rand.New(rand.NewSource(1))}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestProtoEncoding(t *testing.T) {
+	var d GDSNNetContent
+	d.Measurement = mustDecimal("1.50")
+	d.MeasurementUnitCode = "LTR"
+	data, err := MarshalProto(&d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Field 1 "1.50", field 2 "LTR".
+	if want := "\x0a\x041.50\x12\x03LTR"; string(data) != want {
+		t.Errorf("MarshalProto() = %q, want %q", data, want)
+	}
+
+	var empty MasterProductData
+	if data, err := MarshalProto(empty); err != nil || len(data) != 0 {
+		t.Errorf("MarshalProto(zero) = %q, %v", data, err)
+	}
+	if _, err := MarshalProto("x"); err == nil {
+		t.Error("MarshalProto accepted a string")
+	}
+	if err := UnmarshalProto(data, d); err == nil {
+		t.Error("UnmarshalProto accepted a non-pointer")
+	}
+}
+
+func TestUnmarshalProtoErrors(t *testing.T) {
+	for _, data := range []string{
+		"\x0a",         // truncated length
+		"\x0a\x05gtin", // length beyond the data
+		"\x08\x01",     // varint for the string id
+		"\x3a\x01\x0a", // truncated field of tradeItem
+		"\x3a\x80",     // truncated varint length
+	} {
+		var d MasterProductData
+		if err := UnmarshalProto([]byte(data), &d); !errors.Is(err, ErrInvalidProto) {
+			t.Errorf("UnmarshalProto(%q) error = %v", data, err)
+		}
+	}
+	// Unknown fields are skipped.
+	var d MasterProductData
+	if err := UnmarshalProto([]byte("\xf8\x07\x01\x22\x0896385074"), &d); err != nil || d.Gtin != "96385074" {
+		t.Errorf("UnmarshalProto(unknown field) = %+v, %v", d.Gtin, err)
+	}
+}
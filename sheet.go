@@ -0,0 +1,683 @@
+package structs
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrSheetColumn is returned for invalid column mappings.
+	ErrSheetColumn = errors.New("invalid sheet column")
+	// ErrUnknownColumn is reported for headers which match no column of the
+	// mapping.
+	ErrUnknownColumn = errors.New("unknown column")
+	// ErrUnknownProduct is reported for rows which match no product.
+	ErrUnknownProduct = errors.New("unknown product")
+)
+
+// SheetColumn maps a spreadsheet column to a value of the product.
+//
+// Path is a JSON pointer to a text, number, boolean or date time value, in
+// which list elements are selected by index or by a selector. The selector
+// [member=value,...] selects the first element having the given member
+// values, for example [@languageCode=fi]. Members below the element are
+// separated by dots, as in [nutrientBasisQuantity.$=100]. One selector value
+// of a path may be the wildcard *. Such a column is repeated for every value
+// found in the products, and the * of the header is replaced by the value.
+type SheetColumn struct {
+	// Header of the column, for example "brandName" or
+	// "tradeItemDescription[*]".
+	Header string
+	// Path of the value, for example
+	// /tradeItem/tradeItemInformation/extensions/tradeItemDescriptionModule/tradeItemDescriptionInformation/tradeItemDescription/[@languageCode=*]/$.
+	Path string
+}
+
+// SheetMapping is the column mapping of a spreadsheet of products. The first
+// column identifies the products: on import, rows are matched to products by
+// it. It can't have a wildcard.
+type SheetMapping []SheetColumn
+
+const (
+	sheetDescription = "/tradeItem/tradeItemInformation/extensions/tradeItemDescriptionModule/tradeItemDescriptionInformation"
+	sheetNutrient    = "/tradeItem/tradeItemInformation/extensions/nutritionalInformationModule/nutrientHeader/" +
+		"[preparationStateCode=UNPREPARED,nutrientBasisQuantity.$=100,nutrientBasisQuantity.@measurementUnitCode=GRM]/" +
+		"nutrientDetail/[nutrientTypeCode=*]"
+)
+
+// DefaultSheetMapping has the names, descriptions and the nutrients per
+// 100 g of unprepared products, one column per language and nutrient, for
+// example tradeItemDescription[fi] and nutrient[FAT].per100g.
+var DefaultSheetMapping = SheetMapping{
+	{Header: "gtin", Path: "/gtin"},
+	{Header: "name", Path: "/name"},
+	{Header: "brandName", Path: sheetDescription + "/brandNameInformation/brandName"},
+	{Header: "functionalName[*]", Path: sheetDescription + "/functionalName/[@languageCode=*]/$"},
+	{Header: "tradeItemDescription[*]", Path: sheetDescription + "/tradeItemDescription/[@languageCode=*]/$"},
+	{Header: "nutrient[*].per100g", Path: sheetNutrient + "/quantityContained/0/$"},
+	{Header: "nutrient[*].unit", Path: sheetNutrient + "/quantityContained/0/@measurementUnitCode"},
+}
+
+// CellError is an error of a spreadsheet cell found on import.
+type CellError struct {
+	// Zero based indexes of the cell. Row 0 is the header row.
+	Row, Column int
+	// Header of the column.
+	Header string
+	// Reason: ErrUnknownColumn, ErrUnknownProduct, an invalid value or a
+	// ValidationError of the imported value.
+	Err error
+}
+
+// Error implements error interface.
+func (e CellError) Error() string {
+	return fmt.Sprintf("%s (%s): %v", e.Cell(), e.Header, e.Err)
+}
+
+// Unwrap returns the reason.
+func (e CellError) Unwrap() error {
+	return e.Err
+}
+
+// Cell returns the spreadsheet reference of the cell, for example C2 for row
+// 1 and column 2.
+func (e CellError) Cell() string {
+	var col []byte
+	for n := e.Column + 1; n > 0; n = (n - 1) / 26 {
+		col = append([]byte{byte('A' + (n-1)%26)}, col...)
+	}
+	return string(col) + strconv.Itoa(e.Row+1)
+}
+
+// Flatten returns a header row and a row for each product. A column having a
+// wildcard is expanded to a column for each value found in the products, in
+// sorted order. Absent values are empty cells.
+func (m SheetMapping) Flatten(products []MasterProductData) ([][]string, error) {
+	cols, err := m.parse()
+	if err != nil {
+		return nil, err
+	}
+	trees := make([]interface{}, len(products))
+	for i, p := range products {
+		if trees[i], err = jsonTree(p); err != nil {
+			return nil, err
+		}
+	}
+	var expanded []*sheetColumn
+	for _, c := range cols {
+		if c.wildcard < 0 {
+			expanded = append(expanded, c)
+			continue
+		}
+		keys := map[string]interface{}{}
+		for _, t := range trees {
+			for _, k := range c.keys(t) {
+				if text := sheetText(c.keyType, k); text != "" {
+					keys[text] = k
+				}
+			}
+		}
+		for _, text := range sortedKeys(keys) {
+			expanded = append(expanded, c.expand(text, keys[text]))
+		}
+	}
+	header := make([]string, len(expanded))
+	for i, c := range expanded {
+		header[i] = c.Header
+	}
+	rows := [][]string{header}
+	for _, t := range trees {
+		row := make([]string, len(expanded))
+		for i, c := range expanded {
+			row[i] = sheetText(c.leaf, sheetGet(t, c.tokens))
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// WriteCSV writes the products flattened as CSV. See Flatten.
+func (m SheetMapping) WriteCSV(w io.Writer, products []MasterProductData) error {
+	rows, err := m.Flatten(products)
+	if err != nil {
+		return err
+	}
+	return csv.NewWriter(w).WriteAll(rows)
+}
+
+// Import applies rows edited in a spreadsheet to the products and returns the
+// changed products. The first row is the header, and columns are recognized
+// by it, so columns may be left out or reordered. Rows are matched to
+// products by the first column of the mapping. When several products have
+// the same value, the first one is matched. Products with an empty value
+// are never matched.
+//
+// Only cells whose value differs from the product are applied. An empty cell
+// removes the value. List elements on the path of the value are removed as
+// well when nothing but the members of their selector is left, so clearing
+// all the cells of nutrient[FAT] removes the nutrient. Missing list elements
+// are added with the members of their selector.
+//
+// Problems are returned per cell: unknown columns and products, values which
+// can't be read and validation errors the changed values cause, see
+// MasterProductData.Validate. Values which can't be read are left unchanged,
+// while values failing validation are applied. The other cells of the row
+// are applied in both cases.
+func (m SheetMapping) Import(products []MasterProductData, rows [][]string) ([]MasterProductData, []CellError, error) {
+	cols, err := m.parse()
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(rows) == 0 {
+		return nil, nil, errors.New("structs: header row missing")
+	}
+	header := rows[0]
+	var errs []CellError
+	cells := make([]*sheetColumn, len(header))
+	keyColumn := -1
+	for i, h := range header {
+		c, err := sheetColumnOf(cols, h)
+		if err != nil {
+			errs = append(errs, CellError{Row: 0, Column: i, Header: h, Err: err})
+			continue
+		}
+		if c == cols[0] && keyColumn < 0 {
+			keyColumn = i
+		}
+		cells[i] = c
+	}
+	if keyColumn < 0 {
+		return nil, nil, fmt.Errorf("structs: column %q missing", cols[0].Header)
+	}
+
+	res := append([]MasterProductData(nil), products...)
+	trees := make([]interface{}, len(products))
+	index := map[string]int{}
+	for i, p := range products {
+		if trees[i], err = jsonTree(p); err != nil {
+			return nil, nil, err
+		}
+		key := sheetText(cols[0].leaf, sheetGet(trees[i], cols[0].tokens))
+		if _, ok := index[key]; !ok && key != "" {
+			index[key] = i
+		}
+	}
+	cellError := func(row, col int, err error) {
+		errs = append(errs, CellError{Row: row, Column: col, Header: header[col], Err: err})
+	}
+	for r, row := range rows[1:] {
+		r++
+		value := func(i int) string {
+			if i < len(row) {
+				return row[i]
+			}
+			return ""
+		}
+		p, ok := index[value(keyColumn)]
+		if !ok {
+			cellError(r, keyColumn, ErrUnknownProduct)
+			continue
+		}
+		tree := copyJSON(trees[p])
+		var changed []int
+		var paths []string
+		for i, c := range cells {
+			text := value(i)
+			if c == nil || i == keyColumn || text == sheetText(c.leaf, sheetGet(tree, c.tokens)) {
+				continue
+			}
+			v, err := sheetValue(c.leaf, text)
+			if err != nil {
+				cellError(r, i, err)
+				continue
+			}
+			t, path, err := sheetSet(tree, c.tokens, v, "")
+			if err != nil {
+				cellError(r, i, err)
+				continue
+			}
+			tree = t
+			changed = append(changed, i)
+			paths = append(paths, path)
+		}
+		if len(changed) == 0 {
+			continue
+		}
+		data, err := json.Marshal(tree)
+		if err != nil {
+			return nil, nil, err
+		}
+		var d MasterProductData
+		if err := json.Unmarshal(data, &d); err != nil {
+			cellError(r, keyColumn, err)
+			continue
+		}
+		trees[p], res[p] = tree, d
+		verrs, _ := d.Validate().(ValidationErrors)
+		for _, e := range verrs.Errors() {
+			for j, path := range paths {
+				if pointersOverlap(e.Path, path) {
+					cellError(r, changed[j], e)
+					break
+				}
+			}
+		}
+	}
+	return res, errs, nil
+}
+
+// ReadCSV imports products edited as CSV. See Import.
+func (m SheetMapping) ReadCSV(r io.Reader, products []MasterProductData) ([]MasterProductData, []CellError, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	rows, err := cr.ReadAll()
+	if err != nil {
+		return nil, nil, err
+	}
+	return m.Import(products, rows)
+}
+
+// pointersOverlap reports whether one of the JSON pointers refers to a value
+// inside the other.
+func pointersOverlap(a, b string) bool {
+	return a != "" && b != "" && (a == b || strings.HasPrefix(a, b+"/") || strings.HasPrefix(b, a+"/"))
+}
+
+// sheetColumn is a parsed SheetColumn.
+type sheetColumn struct {
+	SheetColumn
+	tokens []sheetToken
+	// Type of the value.
+	leaf reflect.Type
+	// Index of the token having the wildcard, or -1, the index of the
+	// condition in the selector and the type of the member.
+	wildcard, wildcardCond int
+	keyType                reflect.Type
+}
+
+// sheetToken is a member name, list index or selector.
+type sheetToken struct {
+	name string
+	// List index, -1 for members and selectors.
+	index    int
+	selector []sheetCondition
+}
+
+type sheetCondition struct {
+	path []string
+	// JSON value, nil for the wildcard.
+	value interface{}
+}
+
+func (m SheetMapping) parse() ([]*sheetColumn, error) {
+	if len(m) == 0 {
+		return nil, fmt.Errorf("no columns: %w", ErrSheetColumn)
+	}
+	cols := make([]*sheetColumn, len(m))
+	for i, c := range m {
+		col, err := parseSheetColumn(c)
+		if err != nil {
+			return nil, err
+		}
+		cols[i] = col
+	}
+	if cols[0].wildcard >= 0 {
+		return nil, fmt.Errorf("%q: first column has a wildcard: %w", m[0].Header, ErrSheetColumn)
+	}
+	return cols, nil
+}
+
+func parseSheetColumn(c SheetColumn) (*sheetColumn, error) {
+	invalid := func(format string, args ...interface{}) error {
+		return fmt.Errorf("%q: %s: %w", c.Header, fmt.Sprintf(format, args...), ErrSheetColumn)
+	}
+	parts, ok := SplitPointer(c.Path)
+	if !ok || len(parts) == 0 {
+		return nil, invalid("invalid path %q", c.Path)
+	}
+	col := &sheetColumn{SheetColumn: c, wildcard: -1}
+	t := reflect.TypeOf(MasterProductData{})
+	for i, p := range parts {
+		switch {
+		case t.Kind() == reflect.Slice && strings.HasPrefix(p, "[") && strings.HasSuffix(p, "]"):
+			tok := sheetToken{index: -1, selector: []sheetCondition{}}
+			for j, cond := range strings.Split(p[1:len(p)-1], ",") {
+				eq := strings.IndexByte(cond, '=')
+				if eq <= 0 {
+					return nil, invalid("invalid selector %s", p)
+				}
+				path := strings.Split(cond[:eq], ".")
+				ct, ok := sheetMemberType(t.Elem(), path)
+				if !ok {
+					return nil, invalid("unknown member %s", cond[:eq])
+				}
+				if cond[eq+1:] == "*" {
+					if col.wildcard >= 0 {
+						return nil, invalid("more than one wildcard")
+					}
+					col.wildcard, col.wildcardCond, col.keyType = i, j, ct
+					tok.selector = append(tok.selector, sheetCondition{path: path})
+					continue
+				}
+				v, err := sheetValue(ct, cond[eq+1:])
+				if err != nil || v == nil {
+					return nil, invalid("invalid selector value %q", cond[eq+1:])
+				}
+				tok.selector = append(tok.selector, sheetCondition{path, v})
+			}
+			col.tokens = append(col.tokens, tok)
+			t = t.Elem()
+		case t.Kind() == reflect.Slice:
+			n, err := strconv.Atoi(p)
+			if err != nil || n < 0 {
+				return nil, invalid("invalid list index %q", p)
+			}
+			col.tokens = append(col.tokens, sheetToken{index: n})
+			t = t.Elem()
+		default:
+			ft, ok := sheetMemberType(t, []string{p})
+			if !ok {
+				return nil, invalid("unknown member %s", strings.Join(parts[:i+1], "/"))
+			}
+			col.tokens = append(col.tokens, sheetToken{name: p, index: -1})
+			t = ft
+		}
+	}
+	if !isSheetValue(t) {
+		return nil, invalid("%s is not a text, number, boolean or date time", t)
+	}
+	if (col.wildcard >= 0) != strings.Contains(c.Header, "*") {
+		return nil, invalid("header and path must both have a wildcard or neither")
+	}
+	col.leaf = t
+	return col, nil
+}
+
+// sheetMemberType returns the type of the member of t at the path of JSON
+// names.
+func sheetMemberType(t reflect.Type, path []string) (reflect.Type, bool) {
+next:
+	for _, name := range path {
+		if t.Kind() != reflect.Struct || isSheetValue(t) {
+			return nil, false
+		}
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if f.PkgPath == "" && strings.Split(f.Tag.Get("json"), ",")[0] == name {
+				t = f.Type
+				continue next
+			}
+		}
+		return nil, false
+	}
+	return t, true
+}
+
+// isSheetValue reports whether values of type t fit in a cell.
+func isSheetValue(t reflect.Type) bool {
+	switch t {
+	case decimalType, nullFloat64Type, nullIntType, nullBoolType, timeType:
+		return true
+	}
+	switch t.Kind() {
+	case reflect.String, reflect.Bool, reflect.Int, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// sheetValue returns the JSON value of a cell for a value of type t. An
+// empty cell is nil.
+func sheetValue(t reflect.Type, s string) (interface{}, error) {
+	if s == "" {
+		return nil, nil
+	}
+	switch {
+	case t == decimalType || t == nullFloat64Type || t.Kind() == reflect.Float64:
+		d, err := ParseDecimal(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q", s)
+		}
+		return json.Number(d.String()), nil
+	case t == nullIntType || t.Kind() == reflect.Int:
+		i, err := strconv.Atoi(strings.TrimSpace(s))
+		if err != nil {
+			return nil, fmt.Errorf("invalid integer %q", s)
+		}
+		return json.Number(strconv.Itoa(i)), nil
+	case t == nullBoolType || t.Kind() == reflect.Bool:
+		b, err := strconv.ParseBool(strings.TrimSpace(s))
+		if err != nil {
+			return nil, fmt.Errorf("invalid boolean %q", s)
+		}
+		return b, nil
+	case t == timeType:
+		if _, err := time.Parse(time.RFC3339Nano, strings.TrimSpace(s)); err != nil {
+			return nil, fmt.Errorf("invalid date time %q", s)
+		}
+		return strings.TrimSpace(s), nil
+	}
+	return s, nil
+}
+
+// sheetText returns the cell of a JSON value of type t.
+func sheetText(t reflect.Type, v interface{}) string {
+	switch v := v.(type) {
+	case string:
+		if t == timeType && v == zeroTime {
+			return ""
+		}
+		return v
+	case json.Number:
+		return string(v)
+	case bool:
+		return strconv.FormatBool(v)
+	}
+	return ""
+}
+
+// sheetColumnOf returns the column of a header. The wildcard of a column is
+// replaced by the value given in the header.
+func sheetColumnOf(cols []*sheetColumn, header string) (*sheetColumn, error) {
+	for _, c := range cols {
+		if c.wildcard < 0 {
+			if c.Header == header {
+				return c, nil
+			}
+			continue
+		}
+		star := strings.IndexByte(c.Header, '*')
+		prefix, suffix := c.Header[:star], c.Header[star+1:]
+		if len(header) <= len(prefix)+len(suffix) || !strings.HasPrefix(header, prefix) || !strings.HasSuffix(header, suffix) {
+			continue
+		}
+		text := header[len(prefix) : len(header)-len(suffix)]
+		key, err := sheetValue(c.keyType, text)
+		if err != nil {
+			return nil, err
+		}
+		return c.expand(text, key), nil
+	}
+	return nil, ErrUnknownColumn
+}
+
+// expand returns the column with the wildcard replaced by key, which is
+// text in the header.
+func (c *sheetColumn) expand(text string, key interface{}) *sheetColumn {
+	res := *c
+	res.Header = strings.Replace(c.Header, "*", text, 1)
+	res.tokens = append([]sheetToken(nil), c.tokens...)
+	tok := &res.tokens[c.wildcard]
+	tok.selector = append([]sheetCondition(nil), tok.selector...)
+	tok.selector[c.wildcardCond].value = key
+	res.wildcard = -1
+	return &res
+}
+
+// keys returns the values of the wildcard member in a product.
+func (c *sheetColumn) keys(tree interface{}) []interface{} {
+	list, _ := sheetGet(tree, c.tokens[:c.wildcard]).([]interface{})
+	tok := c.tokens[c.wildcard]
+	others := sheetToken{index: -1}
+	for i, cond := range tok.selector {
+		if i != c.wildcardCond {
+			others.selector = append(others.selector, cond)
+		}
+	}
+	var keys []interface{}
+	for _, e := range list {
+		if others.matches(e) {
+			if k := sheetMember(e, tok.selector[c.wildcardCond].path); k != nil {
+				keys = append(keys, k)
+			}
+		}
+	}
+	return keys
+}
+
+// find returns the index of the element selected by the token, or -1.
+func (t sheetToken) find(list []interface{}) int {
+	if t.selector == nil {
+		if t.index < len(list) {
+			return t.index
+		}
+		return -1
+	}
+	for i, e := range list {
+		if t.matches(e) {
+			return i
+		}
+	}
+	return -1
+}
+
+func (t sheetToken) matches(e interface{}) bool {
+	for _, c := range t.selector {
+		if !jsonEqual(sheetMember(e, c.path), c.value) {
+			return false
+		}
+	}
+	return true
+}
+
+// newElement returns a list element having the members of the selector.
+func (t sheetToken) newElement() interface{} {
+	if t.selector == nil {
+		return nil
+	}
+	e := map[string]interface{}{}
+	for _, c := range t.selector {
+		obj := e
+		for _, name := range c.path[:len(c.path)-1] {
+			child, ok := obj[name].(map[string]interface{})
+			if !ok {
+				child = map[string]interface{}{}
+				obj[name] = child
+			}
+			obj = child
+		}
+		obj[c.path[len(c.path)-1]] = c.value
+	}
+	return e
+}
+
+// bare reports whether the element has no other values than the members of
+// the selector, if any.
+func (t sheetToken) bare(e interface{}) bool {
+	rest := copyJSON(e)
+	for _, c := range t.selector {
+		obj, _ := rest.(map[string]interface{})
+		for _, name := range c.path[:len(c.path)-1] {
+			obj, _ = obj[name].(map[string]interface{})
+		}
+		delete(obj, c.path[len(c.path)-1])
+	}
+	return isZeroJSON(rest)
+}
+
+func sheetMember(v interface{}, path []string) interface{} {
+	for _, name := range path {
+		obj, _ := v.(map[string]interface{})
+		v = obj[name]
+	}
+	return v
+}
+
+// sheetGet returns the value at tokens below node, or nil.
+func sheetGet(node interface{}, tokens []sheetToken) interface{} {
+	for _, t := range tokens {
+		if t.index < 0 && t.selector == nil {
+			obj, _ := node.(map[string]interface{})
+			node = obj[t.name]
+			continue
+		}
+		list, _ := node.([]interface{})
+		i := t.find(list)
+		if i < 0 {
+			return nil
+		}
+		node = list[i]
+	}
+	return node
+}
+
+// sheetSet sets the value at tokens below node, creating missing objects,
+// lists and list elements. A nil value removes the value, and the list
+// elements on the path which are left bare. The changed node and the JSON
+// pointer of the value are returned.
+func sheetSet(node interface{}, tokens []sheetToken, value interface{}, path string) (interface{}, string, error) {
+	if len(tokens) == 0 {
+		return value, path, nil
+	}
+	t, rest := tokens[0], tokens[1:]
+	if t.index < 0 && t.selector == nil {
+		obj, _ := node.(map[string]interface{})
+		child, ok := obj[t.name]
+		path = JoinPointer(path, t.name)
+		if value == nil && (!ok || len(rest) == 0) {
+			delete(obj, t.name)
+			return node, path, nil
+		}
+		if obj == nil {
+			obj = map[string]interface{}{}
+		}
+		child, path, err := sheetSet(child, rest, value, path)
+		if err != nil {
+			return nil, "", err
+		}
+		obj[t.name] = child
+		return obj, path, nil
+	}
+
+	list, _ := node.([]interface{})
+	i := t.find(list)
+	if i < 0 {
+		if value == nil {
+			return node, path, nil
+		}
+		if t.selector == nil && t.index != len(list) {
+			return nil, "", fmt.Errorf("list index %d out of range", t.index)
+		}
+		list = append(list, t.newElement())
+		i = len(list) - 1
+	}
+	if value == nil && len(rest) == 0 {
+		return append(list[:i:i], list[i+1:]...), JoinPointer(path, strconv.Itoa(i)), nil
+	}
+	child, path, err := sheetSet(list[i], rest, value, JoinPointer(path, strconv.Itoa(i)))
+	if err != nil {
+		return nil, "", err
+	}
+	list[i] = child
+	if value == nil && t.bare(child) {
+		list = append(list[:i:i], list[i+1:]...)
+	}
+	return list, path, nil
+}
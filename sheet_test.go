@@ -0,0 +1,213 @@
+package structs
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+const sheetProducts = `[{
+	"gtin": "4006381333931",
+	"name": "Milk",
+	"tradeItem": {"tradeItemInformation": {"extensions": {
+		"tradeItemDescriptionModule": {"tradeItemDescriptionInformation": {
+			"brandNameInformation": {"brandName": "Valio"},
+			"tradeItemDescription": [{"$": "Maito", "@languageCode": "fi"}, {"$": "Mjölk", "@languageCode": "sv"}]
+		}},
+		"nutritionalInformationModule": {"nutrientHeader": [{
+			"preparationStateCode": "UNPREPARED",
+			"nutrientBasisQuantity": {"$": 100, "@measurementUnitCode": "GRM"},
+			"nutrientDetail": [
+				{"nutrientTypeCode": "FAT", "quantityContained": [{"$": 1.5, "@measurementUnitCode": "GRM"}]},
+				{"nutrientTypeCode": "PRO-", "quantityContained": [{"$": 3.4, "@measurementUnitCode": "GRM"}]}
+			]
+		}]}
+	}}}
+}, {
+	"gtin": "96385074",
+	"name": "Bread",
+	"tradeItem": {"tradeItemInformation": {"extensions": {
+		"tradeItemDescriptionModule": {"tradeItemDescriptionInformation": {
+			"tradeItemDescription": [{"$": "Bread", "@languageCode": "en"}]
+		}}
+	}}}
+}]`
+
+func sheetTestProducts(t *testing.T) []MasterProductData {
+	t.Helper()
+	var products []MasterProductData
+	if err := json.Unmarshal([]byte(sheetProducts), &products); err != nil {
+		t.Fatal(err)
+	}
+	return products
+}
+
+func TestSheetFlatten(t *testing.T) {
+	rows, err := DefaultSheetMapping.Flatten(sheetTestProducts(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := [][]string{
+		{"gtin", "name", "brandName", "tradeItemDescription[en]", "tradeItemDescription[fi]", "tradeItemDescription[sv]",
+			"nutrient[FAT].per100g", "nutrient[PRO-].per100g", "nutrient[FAT].unit", "nutrient[PRO-].unit"},
+		{"4006381333931", "Milk", "Valio", "", "Maito", "Mjölk", "1.5", "3.4", "GRM", "GRM"},
+		{"96385074", "Bread", "", "Bread", "", "", "", "", "", ""},
+	}
+	if !reflect.DeepEqual(rows, want) {
+		t.Errorf("Flatten() = %q\nwant %q", rows, want)
+	}
+}
+
+func TestSheetImport(t *testing.T) {
+	products := sheetTestProducts(t)
+	rows, err := DefaultSheetMapping.Flatten(products)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Unchanged rows leave the products as they are.
+	got, errs, err := DefaultSheetMapping.Import(products, rows)
+	if err != nil || len(errs) != 0 || !reflect.DeepEqual(got, products) {
+		t.Fatalf("Import(unchanged) = %+v, %v, %v", got, errs, err)
+	}
+
+	// Columns are recognized by the header, the rows by the GTIN.
+	edited := [][]string{
+		{"nutrient[FAT].unit", "nutrient[FAT].per100g", "tradeItemDescription[fi]", "gtin", "tradeItemDescription[en]", "nutrient[SUGAR-].per100g", "nutrient[SUGAR-].unit"},
+		{"", "", "Leipä", "96385074", "Bread", "2", "GRM"},
+		{"", "", "Kevytmaito", "4006381333931", "", "", ""},
+	}
+	got, errs, err = DefaultSheetMapping.Import(products, edited)
+	if err != nil || len(errs) != 0 {
+		t.Fatalf("Import() errors %v, %v", errs, err)
+	}
+	if !reflect.DeepEqual(products, sheetTestProducts(t)) {
+		t.Error("Import() changed its input")
+	}
+	rows, err = DefaultSheetMapping.Flatten(got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := [][]string{
+		{"gtin", "name", "brandName", "tradeItemDescription[en]", "tradeItemDescription[fi]", "tradeItemDescription[sv]",
+			"nutrient[PRO-].per100g", "nutrient[SUGAR-].per100g", "nutrient[PRO-].unit", "nutrient[SUGAR-].unit"},
+		{"4006381333931", "Milk", "Valio", "", "Kevytmaito", "Mjölk", "3.4", "", "GRM", ""},
+		{"96385074", "Bread", "", "Bread", "Leipä", "", "", "2", "", "GRM"},
+	}
+	if !reflect.DeepEqual(rows, want) {
+		t.Errorf("Flatten(Import()) = %q\nwant %q", rows, want)
+	}
+	// The added nutrient has the members of the selectors.
+	header := got[1].TradeItem.TradeItemInformation.Extension.NutritionalInformationModule.NutrientHeaders
+	if len(header) != 1 || header[0].PreparationStateCode != "UNPREPARED" || header[0].NutrientBasisQuantity.MeasurementUnitCode != "GRM" ||
+		len(header[0].NutrientDetails) != 1 || header[0].NutrientDetails[0].NutrientTypeCode != "SUGAR-" {
+		t.Errorf("Import() added nutrient header %+v", header)
+	}
+}
+
+func TestSheetImportErrors(t *testing.T) {
+	products := sheetTestProducts(t)
+	rows := [][]string{
+		{"gtin", "name", "color", "nutrient[FAT].per100g", "nutrient[PRO-].per100g"},
+		{"4006381333931", "Whole milk", "white", "x", "-1"},
+		{"12345670", "Unknown"},
+		{"", "Blank"},
+	}
+	// Products without a GTIN are not matched by a blank key cell.
+	products = append(products, MasterProductData{Name: "No GTIN"})
+	got, errs, err := DefaultSheetMapping.Import(products, rows)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var cells []string
+	for _, e := range errs {
+		cells = append(cells, e.Cell()+" "+e.Header)
+	}
+	if want := []string{"C1 color", "D2 nutrient[FAT].per100g", "E2 nutrient[PRO-].per100g", "A3 gtin", "A4 gtin"}; !reflect.DeepEqual(cells, want) {
+		t.Fatalf("Import() errors %v, want cells %q", errs, want)
+	}
+	if !errors.Is(errs[0], ErrUnknownColumn) || !errors.Is(errs[3], ErrUnknownProduct) || !errors.Is(errs[4], ErrUnknownProduct) {
+		t.Errorf("Import() errors %v", errs)
+	}
+	var verr ValidationError
+	if !errors.As(errs[2], &verr) || verr.Rule != RuleRange {
+		t.Errorf("Import() error %v, want a range validation error", errs[2])
+	}
+	if got[2].Name != "No GTIN" {
+		t.Errorf("Import() changed the product without GTIN to %+v", got[2])
+	}
+	// The other cells are applied, and values failing validation as well.
+	rows, err = DefaultSheetMapping.Flatten(got[:1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if name, fat, protein := rows[1][1], rows[1][5], rows[1][6]; name != "Whole milk" || fat != "1.5" || protein != "-1" {
+		t.Errorf("Import() name %s, fat %s, protein %s", name, fat, protein)
+	}
+
+	if _, _, err := DefaultSheetMapping.Import(products, [][]string{{"name"}}); err == nil {
+		t.Error("Import() accepted rows without the gtin column")
+	}
+	if _, _, err := DefaultSheetMapping.Import(products, nil); err == nil {
+		t.Error("Import() accepted no rows")
+	}
+}
+
+func TestSheetCSV(t *testing.T) {
+	products := sheetTestProducts(t)
+	var buf bytes.Buffer
+	if err := DefaultSheetMapping.WriteCSV(&buf, products); err != nil {
+		t.Fatal(err)
+	}
+	got, errs, err := DefaultSheetMapping.ReadCSV(&buf, products)
+	if err != nil || len(errs) != 0 || !reflect.DeepEqual(got, products) {
+		t.Errorf("ReadCSV(WriteCSV()) = %+v, %v, %v", got, errs, err)
+	}
+
+	// The mapping of the README.
+	mapping := SheetMapping{
+		{Header: "gtin", Path: "/gtin"},
+		{Header: "tradeItemDescription[*]", Path: "/tradeItem/tradeItemInformation/extensions/" +
+			"tradeItemDescriptionModule/tradeItemDescriptionInformation/tradeItemDescription/[@languageCode=*]/$"},
+	}
+	buf.Reset()
+	if err := mapping.WriteCSV(&buf, products); err != nil {
+		t.Fatal(err)
+	}
+	want := "gtin,tradeItemDescription[en],tradeItemDescription[fi],tradeItemDescription[sv]\n" +
+		"4006381333931,,Maito,Mjölk\n96385074,Bread,,\n"
+	if buf.String() != want {
+		t.Errorf("WriteCSV() = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestSheetMappingErrors(t *testing.T) {
+	for _, m := range []SheetMapping{
+		nil,
+		{{Header: "d[*]", Path: "/tradeItem/tradeItemInformation/extensions/tradeItemDescriptionModule/tradeItemDescriptionInformation/tradeItemDescription/[@languageCode=*]/$"}},
+		{{Header: "gtin", Path: "gtin"}},
+		{{Header: "gtin", Path: "/ean"}},
+		{{Header: "gtin", Path: "/tradeItem"}},
+		{{Header: "gtin", Path: "/gtin"}, {Header: "d", Path: "/tradeItem/tradeItemInformation/extensions/tradeItemDescriptionModule/tradeItemDescriptionInformation/tradeItemDescription/[@languageCode=*]/$"}},
+		{{Header: "gtin", Path: "/gtin"}, {Header: "d", Path: "/tradeItem/tradeItemInformation/extensions/tradeItemDescriptionModule/tradeItemDescriptionInformation/tradeItemDescription/x/$"}},
+		{{Header: "gtin", Path: "/gtin"}, {Header: "d", Path: "/tradeItem/tradeItemInformation/extensions/tradeItemDescriptionModule/tradeItemDescriptionInformation/tradeItemDescription/[language=fi]/$"}},
+	} {
+		if _, err := m.Flatten(nil); !errors.Is(err, ErrSheetColumn) {
+			t.Errorf("%v: Flatten() error = %v", m, err)
+		}
+	}
+}
+
+func TestCellErrorCell(t *testing.T) {
+	for _, tt := range []struct {
+		row, col int
+		want     string
+	}{
+		{0, 0, "A1"}, {1, 2, "C2"}, {9, 25, "Z10"}, {0, 26, "AA1"}, {0, 701, "ZZ1"}, {0, 702, "AAA1"},
+	} {
+		if got := (CellError{Row: tt.row, Column: tt.col}).Cell(); got != tt.want {
+			t.Errorf("Cell(%d, %d) = %s, want %s", tt.row, tt.col, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,197 @@
+package structs
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Temperature qualifier codes. Uses code list temperatureQualifierCode.
+const (
+	TemperatureQualifierStorageHandling = "STORAGE_HANDLING"
+	TemperatureQualifierTransportation  = "TRANSPORTATION"
+)
+
+// Temperature limits used by Classify, in degrees Celsius.
+const (
+	// Products which must be kept at or below this temperature are frozen.
+	FrozenMaximumCelsius = -12.0
+	// Products which must be kept at or below this temperature are chilled.
+	ChilledMaximumCelsius = 8.0
+)
+
+// ErrNoTemperatureRange is returned when a product has no temperature
+// information for the requested qualifier.
+var ErrNoTemperatureRange = errors.New("no temperature range")
+
+// TemperatureClass is the temperature zone of a product for logistics.
+type TemperatureClass string
+
+// Temperature classes.
+const (
+	TemperatureClassUnknown TemperatureClass = ""
+	TemperatureClassFrozen  TemperatureClass = "FROZEN"
+	TemperatureClassChilled TemperatureClass = "CHILLED"
+	TemperatureClassAmbient TemperatureClass = "AMBIENT"
+)
+
+// IsGiven reports whether the temperature is given. A temperature without
+// unit code is considered absent since zero degrees can't be told apart from
+// a missing value otherwise.
+func (t GDSNTemperature) IsGiven() bool {
+	return t.TemperatureMeasurementUnitCode != ""
+}
+
+// MinimumTolerance returns the minimum tolerance temperature. It is provided
+// because the field name MinumumToleranceTemperature is misspelled.
+func (i TradeItemTemperatureInformation) MinimumTolerance() GDSNTemperature {
+	return i.MinumumToleranceTemperature
+}
+
+// TemperatureRange is a permissible temperature range converted to a single unit.
+// A nil limit is open. Limits are exact, see Quantity.ConvertTo.
+type TemperatureRange struct {
+	// Qualifier of the range, for example STORAGE_HANDLING.
+	Qualifier string
+	// Unit code of the values, for example CEL.
+	UnitCode string
+	// Minimum temperature.
+	Minimum *Decimal
+	// Maximum temperature.
+	Maximum *Decimal
+	// Minimum temperature tolerated for a short time.
+	MinimumTolerance *Decimal
+	// Maximum temperature tolerated for a short time.
+	MaximumTolerance *Decimal
+}
+
+// Range returns the temperature range in the given unit, for example CEL.
+func (i TradeItemTemperatureInformation) Range(unitCode string) (TemperatureRange, error) {
+	r := TemperatureRange{Qualifier: i.TemperatureQualifierCode, UnitCode: unitCode}
+	for _, f := range []struct {
+		temp GDSNTemperature
+		dst  **Decimal
+	}{
+		{i.MinimumTemperature, &r.Minimum},
+		{i.MaximumTemperature, &r.Maximum},
+		{i.MinimumTolerance(), &r.MinimumTolerance},
+		{i.MaximumToleranceTemperature, &r.MaximumTolerance},
+	} {
+		if !f.temp.IsGiven() {
+			continue
+		}
+		q, err := f.temp.Quantity().ConvertTo(unitCode)
+		if err != nil {
+			return TemperatureRange{}, err
+		}
+		*f.dst = &q.Value
+	}
+	return r, nil
+}
+
+// Inverted reports whether the minimum is greater than the maximum, either
+// for the range or for the tolerances.
+func (r TemperatureRange) Inverted() bool {
+	inverted := func(min, max *Decimal) bool {
+		return min != nil && max != nil && min.Cmp(*max) > 0
+	}
+	return inverted(r.Minimum, r.Maximum) || inverted(r.MinimumTolerance, r.MaximumTolerance)
+}
+
+// IsOpen reports whether the range has no limits at all.
+func (r TemperatureRange) IsOpen() bool {
+	return r.Minimum == nil && r.Maximum == nil
+}
+
+// Contains reports whether the temperature is within the minimum and maximum
+// of the range. The limits are inclusive.
+func (r TemperatureRange) Contains(t Quantity) (bool, error) {
+	return r.contains(t, r.Minimum, r.Maximum)
+}
+
+// Tolerates reports whether the temperature is within the tolerance limits of
+// the range. When a tolerance limit is not given, the corresponding range
+// limit is used.
+func (r TemperatureRange) Tolerates(t Quantity) (bool, error) {
+	min, max := r.MinimumTolerance, r.MaximumTolerance
+	if min == nil {
+		min = r.Minimum
+	}
+	if max == nil {
+		max = r.Maximum
+	}
+	return r.contains(t, min, max)
+}
+
+func (r TemperatureRange) contains(t Quantity, min, max *Decimal) (bool, error) {
+	if r.Inverted() {
+		return false, fmt.Errorf("%s range is inverted", r.Qualifier)
+	}
+	q, err := t.ConvertTo(r.UnitCode)
+	if err != nil {
+		return false, err
+	}
+	return (min == nil || q.Value.Cmp(*min) >= 0) && (max == nil || q.Value.Cmp(*max) <= 0), nil
+}
+
+// Range returns the temperature range for the given qualifier, for example
+// TemperatureQualifierTransportation, in the given unit. ErrNoTemperatureRange
+// is returned when the module has no information for the qualifier.
+func (m TradeItemTemperatureInformationModule) Range(qualifier, unitCode string) (TemperatureRange, error) {
+	for _, i := range m.TradeItemTemperatureInformations {
+		if strings.EqualFold(i.TemperatureQualifierCode, qualifier) {
+			return i.Range(unitCode)
+		}
+	}
+	return TemperatureRange{}, fmt.Errorf("%s: %w", qualifier, ErrNoTemperatureRange)
+}
+
+// StorageRange returns the storage and handling temperature range in the given unit.
+func (m TradeItemTemperatureInformationModule) StorageRange(unitCode string) (TemperatureRange, error) {
+	return m.Range(TemperatureQualifierStorageHandling, unitCode)
+}
+
+// TransportRange returns the transportation temperature range in the given
+// unit. The storage range is returned when no transportation range is given.
+func (m TradeItemTemperatureInformationModule) TransportRange(unitCode string) (TemperatureRange, error) {
+	r, err := m.Range(TemperatureQualifierTransportation, unitCode)
+	if errors.Is(err, ErrNoTemperatureRange) {
+		return m.StorageRange(unitCode)
+	}
+	return r, err
+}
+
+// Classify returns the temperature class of the product. When the temperature
+// condition type code is FROZEN, CHILLED or AMBIENT, it is used as such.
+// Otherwise the class is derived from the maximum storage temperature using
+// FrozenMaximumCelsius and ChilledMaximumCelsius. A storage range with only a
+// minimum temperature above ChilledMaximumCelsius is ambient. When the class
+// can't be determined, TemperatureClassUnknown is returned.
+func (m TradeItemTemperatureInformationModule) Classify() (TemperatureClass, error) {
+	switch c := TemperatureClass(strings.ToUpper(m.TradeItemTemperatureConditionTypeCode)); c {
+	case TemperatureClassFrozen, TemperatureClassChilled, TemperatureClassAmbient:
+		return c, nil
+	}
+	r, err := m.StorageRange(UnitDegreeCelsius)
+	if errors.Is(err, ErrNoTemperatureRange) {
+		return TemperatureClassUnknown, nil
+	}
+	if err != nil {
+		return TemperatureClassUnknown, err
+	}
+	if r.Inverted() {
+		return TemperatureClassUnknown, fmt.Errorf("%s range is inverted", r.Qualifier)
+	}
+	frozen, chilled := DecimalFromFloat(FrozenMaximumCelsius), DecimalFromFloat(ChilledMaximumCelsius)
+	switch {
+	case r.Maximum != nil && r.Maximum.Cmp(frozen) <= 0:
+		return TemperatureClassFrozen, nil
+	case r.Maximum != nil && r.Maximum.Cmp(chilled) <= 0:
+		return TemperatureClassChilled, nil
+	case r.Maximum != nil:
+		return TemperatureClassAmbient, nil
+	case r.Minimum != nil && r.Minimum.Cmp(chilled) > 0:
+		return TemperatureClassAmbient, nil
+	}
+	return TemperatureClassUnknown, nil
+}
@@ -0,0 +1,156 @@
+package structs
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func temperatureModule(t *testing.T, data string) TradeItemTemperatureInformationModule {
+	t.Helper()
+	var m TradeItemTemperatureInformationModule
+	if err := json.Unmarshal([]byte(data), &m); err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+// limits returns the limits of the range, "open" for those not given.
+func limits(r TemperatureRange) string {
+	var s []string
+	for _, l := range []*Decimal{r.Minimum, r.Maximum, r.MinimumTolerance, r.MaximumTolerance} {
+		if l == nil {
+			s = append(s, "open")
+		} else {
+			s = append(s, l.String())
+		}
+	}
+	return strings.Join(s, " ")
+}
+
+func TestTemperatureRange(t *testing.T) {
+	m := temperatureModule(t, `{"tradeItemTemperatureInformation": [
+		{"temperatureQualifierCode": "STORAGE_HANDLING",
+		 "minimumTemperature": {"$": 35.6, "@temperatureMeasurementUnitCode": "FAH"},
+		 "maximumTemperature": {"$": 6, "@temperatureMeasurementUnitCode": "CEL"},
+		 "minumumToleranceTemperature": {"$": 0, "@temperatureMeasurementUnitCode": "CEL"},
+		 "maximumToleranceTemperature": {"$": 0}}
+	]}`)
+	r, err := m.StorageRange(UnitDegreeCelsius)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := limits(r); got != "2 6 0 open" {
+		t.Errorf("StorageRange(CEL) limits %s", got)
+	}
+	if r.IsOpen() || r.Inverted() {
+		t.Errorf("IsOpen() = %v, Inverted() = %v", r.IsOpen(), r.Inverted())
+	}
+	for _, tt := range []struct {
+		q                  Quantity
+		contains, tolerate bool
+	}{
+		{quantity("2", UnitDegreeCelsius), true, true},
+		{quantity("1", UnitDegreeCelsius), false, true},
+		{quantity("42.8", UnitFahrenheit), true, true},
+		{quantity("278", UnitKelvin), true, true},
+		{quantity("6.5", UnitDegreeCelsius), false, false},
+		{quantity("-1", UnitDegreeCelsius), false, false},
+	} {
+		if got, err := r.Contains(tt.q); err != nil || got != tt.contains {
+			t.Errorf("Contains(%s) = %v, %v", tt.q, got, err)
+		}
+		if got, err := r.Tolerates(tt.q); err != nil || got != tt.tolerate {
+			t.Errorf("Tolerates(%s) = %v, %v", tt.q, got, err)
+		}
+	}
+	if _, err := r.Contains(quantity("1", UnitGram)); !errors.Is(err, ErrIncompatibleUnits) {
+		t.Errorf("Contains(1 GRM) error = %v", err)
+	}
+
+	transport, err := m.TransportRange(UnitFahrenheit)
+	if err != nil || transport.Qualifier != TemperatureQualifierStorageHandling || limits(transport) != "35.6 42.8 32 open" {
+		t.Errorf("TransportRange(FAH) = %s, %v", limits(transport), err)
+	}
+	if _, err := m.Range(TemperatureQualifierTransportation, UnitDegreeCelsius); !errors.Is(err, ErrNoTemperatureRange) {
+		t.Errorf("Range(TRANSPORTATION) error = %v", err)
+	}
+}
+
+func TestTemperatureRangeLimits(t *testing.T) {
+	// Limits converted between units compare exactly.
+	m := temperatureModule(t, `{"tradeItemTemperatureInformation": [
+		{"temperatureQualifierCode": "STORAGE_HANDLING",
+		 "minimumTemperature": {"$": 0.1, "@temperatureMeasurementUnitCode": "CEL"},
+		 "maximumTemperature": {"$": 33.8, "@temperatureMeasurementUnitCode": "FAH"}}
+	]}`)
+	r, err := m.StorageRange(UnitDegreeCelsius)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range []struct {
+		q    Quantity
+		want bool
+	}{
+		{quantity("0.1", UnitDegreeCelsius), true},
+		{quantity("0.0999999999999999999", UnitDegreeCelsius), false},
+		{quantity("1", UnitDegreeCelsius), true},
+		{quantity("274.15", UnitKelvin), true},
+		{quantity("1.0000000000000000001", UnitDegreeCelsius), false},
+	} {
+		if got, err := r.Contains(tt.q); err != nil || got != tt.want {
+			t.Errorf("Contains(%s) = %v, %v, want %v", tt.q, got, err, tt.want)
+		}
+	}
+}
+
+func TestTemperatureRangeInverted(t *testing.T) {
+	min, max := DecimalFromInt(8), DecimalFromInt(2)
+	r := TemperatureRange{Qualifier: TemperatureQualifierStorageHandling, UnitCode: UnitDegreeCelsius, Minimum: &min, Maximum: &max}
+	if !r.Inverted() {
+		t.Fatal("Inverted() = false")
+	}
+	if _, err := r.Contains(quantity("4", UnitDegreeCelsius)); err == nil {
+		t.Error("Contains accepted an inverted range")
+	}
+	if !(TemperatureRange{}).IsOpen() {
+		t.Error("zero range is not open")
+	}
+}
+
+func TestTemperatureClassify(t *testing.T) {
+	for _, tt := range []struct {
+		name, data string
+		want       TemperatureClass
+		err        bool
+	}{
+		{"condition code", `{"tradeItemTemperatureConditionTypeCode": "frozen"}`, TemperatureClassFrozen, false},
+		{"no information", `{}`, TemperatureClassUnknown, false},
+		{"frozen", `{"tradeItemTemperatureInformation": [{"temperatureQualifierCode": "STORAGE_HANDLING",
+			"maximumTemperature": {"$": -18, "@temperatureMeasurementUnitCode": "CEL"}}]}`, TemperatureClassFrozen, false},
+		{"chilled in fahrenheit", `{"tradeItemTemperatureInformation": [{"temperatureQualifierCode": "STORAGE_HANDLING",
+			"maximumTemperature": {"$": 46.4, "@temperatureMeasurementUnitCode": "FAH"}}]}`, TemperatureClassChilled, false},
+		{"ambient", `{"tradeItemTemperatureInformation": [{"temperatureQualifierCode": "STORAGE_HANDLING",
+			"maximumTemperature": {"$": 25, "@temperatureMeasurementUnitCode": "CEL"}}]}`, TemperatureClassAmbient, false},
+		{"only minimum", `{"tradeItemTemperatureInformation": [{"temperatureQualifierCode": "STORAGE_HANDLING",
+			"minimumTemperature": {"$": 10, "@temperatureMeasurementUnitCode": "CEL"}}]}`, TemperatureClassAmbient, false},
+		{"transport only", `{"tradeItemTemperatureInformation": [{"temperatureQualifierCode": "TRANSPORTATION",
+			"maximumTemperature": {"$": 4, "@temperatureMeasurementUnitCode": "CEL"}}]}`, TemperatureClassUnknown, false},
+		{"inverted", `{"tradeItemTemperatureInformation": [{"temperatureQualifierCode": "STORAGE_HANDLING",
+			"minimumTemperature": {"$": 8, "@temperatureMeasurementUnitCode": "CEL"},
+			"maximumTemperature": {"$": 2, "@temperatureMeasurementUnitCode": "CEL"}}]}`, TemperatureClassUnknown, true},
+		{"unknown unit", `{"tradeItemTemperatureInformation": [{"temperatureQualifierCode": "STORAGE_HANDLING",
+			"maximumTemperature": {"$": 4, "@temperatureMeasurementUnitCode": "XYZ"}}]}`, TemperatureClassUnknown, true},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := temperatureModule(t, tt.data).Classify()
+			if (err != nil) != tt.err {
+				t.Errorf("Classify() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Classify() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
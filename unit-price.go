@@ -0,0 +1,174 @@
+package structs
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// Price by measure type codes. Uses code list priceByMeasureTypeCode.
+const (
+	PriceByMeasureDrainedWeight = "DRAINED_WEIGHT"
+	PriceByMeasureGrossWeight   = "GROSS_WEIGHT"
+	PriceByMeasureNetWeight     = "NET_WEIGHT"
+	PriceByMeasureNetContent    = "NET_CONTENT"
+	PriceByMeasureByCount       = "BY_COUNT"
+)
+
+// ErrNoComparisonQuantity is returned when the quantity a comparison price is
+// based on can't be determined.
+var ErrNoComparisonQuantity = errors.New("no comparison quantity")
+
+// comparisonUnits are the units comparison prices are given in per dimension.
+var comparisonUnits = map[Dimension]string{
+	DimensionMass:   UnitKilogram,
+	DimensionVolume: UnitLitre,
+	DimensionLength: UnitMetre,
+	DimensionArea:   UnitSquareMetre,
+	DimensionCount:  UnitPiece,
+}
+
+// ComparisonPrice is a price per kilogram, litre, metre, square metre or piece.
+type ComparisonPrice struct {
+	// Price per one UnitCode. The price is exact when it has a finite
+	// decimal representation and rounded to 18 fractional digits otherwise,
+	// as done by DecimalFromRat. It is not rounded to the precision of the
+	// currency.
+	Price Decimal
+	// Unit code of the comparison price, for example KGM.
+	UnitCode string
+	// Quantity the shelf price is for.
+	Quantity Quantity
+}
+
+// String returns the price per unit, for example 12.5/KGM.
+func (p ComparisonPrice) String() string {
+	return p.Price.String() + "/" + p.UnitCode
+}
+
+// sellingUnit returns the unit a variable unit trade item is sold by, or an
+// empty string for fixed quantity trade items.
+func (d MasterProductData) sellingUnit() string {
+	ext := d.TradeItem.TradeItemInformation.Extension
+	if !ext.VariableTradeItemInformationModule.VariableTradeItemInformation.IsTradeItemAVariableUnit.IsTrue() {
+		return ""
+	}
+	s := ext.SalesInformationModule.SalesInformation
+	for _, code := range []string{s.XSellingUnitOfMeasureCode, s.SellingUnitOfMeasure} {
+		if _, ok := LookupUnit(code); ok {
+			return code
+		}
+	}
+	return ""
+}
+
+// ComparisonQuantity returns the quantity the shelf price of the product is
+// for. Variable unit trade items are priced per selling unit of measure, so
+// one selling unit, for example 1 KGM, is returned for them. Otherwise the
+// first priceComparisonMeasurement with a known unit is used. When none is
+// given, priceByMeasureTypeCode selects the drained, gross or net weight, the
+// net content or, for BY_COUNT, the net content given as a count. Without a type code the drained weight is used when given,
+// as foods in a liquid medium are compared by drained weight, and the net
+// content otherwise, preferring a mass or volume over a count.
+func (d MasterProductData) ComparisonQuantity() (Quantity, error) {
+	if unit := d.sellingUnit(); unit != "" {
+		return NewQuantity(DecimalFromInt(1), unit), nil
+	}
+	ext := d.TradeItem.TradeItemInformation.Extension
+	s := ext.SalesInformationModule.SalesInformation
+	for _, m := range s.PriceComparisonMeasurements {
+		if q := m.Quantity(); q.Value.Sign() > 0 {
+			if _, err := q.Unit(); err == nil {
+				return q, nil
+			}
+		}
+	}
+	measurements := ext.TradeItemMeasurementsModule.TradeItemMeasurements
+	weight := measurements.TradeItemWeight
+	netContent := func() (Quantity, bool) {
+		var best Quantity
+		found := false
+		for _, c := range measurements.NetContent {
+			q := c.Quantity()
+			dim, err := q.Dimension()
+			if err != nil || q.Value.Sign() <= 0 {
+				continue
+			}
+			if dim == DimensionMass || dim == DimensionVolume {
+				return q, true
+			}
+			if !found {
+				best, found = q, true
+			}
+		}
+		return best, found
+	}
+	count := func() (Quantity, bool) {
+		for _, c := range measurements.NetContent {
+			q := c.Quantity()
+			if dim, err := q.Dimension(); err == nil && dim == DimensionCount && q.Value.Sign() > 0 {
+				return q, true
+			}
+		}
+		return Quantity{}, false
+	}
+	given := func(q Quantity) (Quantity, bool) {
+		_, err := q.Unit()
+		return q, err == nil && q.Value.Sign() > 0
+	}
+	var candidates []func() (Quantity, bool)
+	switch code := strings.ToUpper(s.PriceByMeasureTypeCode); code {
+	case PriceByMeasureDrainedWeight:
+		candidates = append(candidates, func() (Quantity, bool) { return given(weight.DrainedWeight.Quantity()) })
+	case PriceByMeasureGrossWeight:
+		candidates = append(candidates, func() (Quantity, bool) { return given(weight.GrossWeight.Quantity()) })
+	case PriceByMeasureNetWeight:
+		candidates = append(candidates, func() (Quantity, bool) { return given(weight.NetWeight.Quantity()) })
+	case PriceByMeasureNetContent:
+		candidates = append(candidates, netContent)
+	case PriceByMeasureByCount:
+		candidates = append(candidates, count)
+	case "":
+		candidates = append(candidates,
+			func() (Quantity, bool) { return given(weight.DrainedWeight.Quantity()) },
+			netContent,
+			func() (Quantity, bool) { return given(weight.NetWeight.Quantity()) })
+	default:
+		return Quantity{}, fmt.Errorf("price by measure type code %q: %w", code, ErrNoComparisonQuantity)
+	}
+	for _, c := range candidates {
+		if q, ok := c(); ok {
+			return q, nil
+		}
+	}
+	return Quantity{}, ErrNoComparisonQuantity
+}
+
+// ComparisonPrice returns the comparison price of the product for the given
+// shelf price. The price is per kilogram, litre, metre, square metre or
+// piece depending on the dimension of ComparisonQuantity. For variable unit
+// trade items the shelf price is the price per selling unit of measure.
+func (d MasterProductData) ComparisonPrice(shelfPrice Decimal) (ComparisonPrice, error) {
+	q, err := d.ComparisonQuantity()
+	if err != nil {
+		return ComparisonPrice{}, err
+	}
+	dim, err := q.Dimension()
+	if err != nil {
+		return ComparisonPrice{}, err
+	}
+	unit, ok := comparisonUnits[dim]
+	if !ok {
+		return ComparisonPrice{}, fmt.Errorf("%s: %w", q, ErrIncompatibleUnits)
+	}
+	base, err := q.ConvertTo(unit)
+	if err != nil {
+		return ComparisonPrice{}, err
+	}
+	if base.Value.Sign() <= 0 {
+		return ComparisonPrice{}, fmt.Errorf("%s: %w", q, ErrNoComparisonQuantity)
+	}
+	price := new(big.Rat).Quo(shelfPrice.Rat(), base.Value.Rat())
+	return ComparisonPrice{Price: DecimalFromRat(price), UnitCode: unit, Quantity: q}, nil
+}
@@ -0,0 +1,93 @@
+package structs
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+// pricedProduct returns a product with the given sales information and
+// trade item measurements.
+func pricedProduct(t *testing.T, sales, measurements string) MasterProductData {
+	t.Helper()
+	var d MasterProductData
+	data := `{"tradeItem": {"tradeItemInformation": {"extensions": {
+		"salesInformationModule": {"salesInformation": ` + sales + `},
+		"tradeItemMeasurementsModule": {"tradeItemMeasurements": ` + measurements + `}
+	}}}}`
+	if err := json.Unmarshal([]byte(data), &d); err != nil {
+		t.Fatal(err)
+	}
+	return d
+}
+
+const pricedMeasurements = `{
+	"netContent": [{"$": 6, "@measurementUnitCode": "H87"}, {"$": 330, "@measurementUnitCode": "GRM"}],
+	"tradeItemWeight": {
+		"drainedWeight": {"$": 200, "@measurementUnitCode": "GRM"},
+		"grossWeight": {"$": 0.4, "@measurementUnitCode": "KGM"},
+		"netWeight": {"$": 330, "@measurementUnitCode": "GRM"}
+	}
+}`
+
+func TestComparisonQuantity(t *testing.T) {
+	for _, tt := range []struct {
+		sales, measurements string
+		want                string
+	}{
+		{`{}`, pricedMeasurements, "200 GRM"},
+		{`{"priceByMeasureTypeCode": "NET_CONTENT"}`, pricedMeasurements, "330 GRM"},
+		{`{"priceByMeasureTypeCode": "by_count"}`, pricedMeasurements, "6 H87"},
+		{`{"priceByMeasureTypeCode": "GROSS_WEIGHT"}`, pricedMeasurements, "0.4 KGM"},
+		{`{"priceByMeasureTypeCode": "NET_WEIGHT"}`, pricedMeasurements, "330 GRM"},
+		{`{"priceComparisonMeasurement": [{"$": 0, "@measurementUnitCode": "KGM"}, {"$": 1.5, "@measurementUnitCode": "LTR"}]}`, pricedMeasurements, "1.5 LTR"},
+		{`{}`, `{"netContent": [{"$": 4, "@measurementUnitCode": "H87"}, {"$": 0.5, "@measurementUnitCode": "LTR"}]}`, "0.5 LTR"},
+		{`{}`, `{"netContent": [{"$": 4, "@measurementUnitCode": "H87"}]}`, "4 H87"},
+	} {
+		q, err := pricedProduct(t, tt.sales, tt.measurements).ComparisonQuantity()
+		if err != nil || q.String() != tt.want {
+			t.Errorf("%s: ComparisonQuantity() = %s, %v, want %s", tt.sales, q, err, tt.want)
+		}
+	}
+
+	for _, tt := range []struct{ sales, measurements string }{
+		{`{"priceByMeasureTypeCode": "BY_COUNT"}`, `{"netContent": [{"$": 330, "@measurementUnitCode": "GRM"}]}`},
+		{`{"priceByMeasureTypeCode": "DRAINED_WEIGHT"}`, `{"netContent": [{"$": 330, "@measurementUnitCode": "GRM"}]}`},
+		{`{"priceByMeasureTypeCode": "PER_BOX"}`, pricedMeasurements},
+		{`{}`, `{}`},
+	} {
+		if q, err := pricedProduct(t, tt.sales, tt.measurements).ComparisonQuantity(); !errors.Is(err, ErrNoComparisonQuantity) {
+			t.Errorf("%s %s: ComparisonQuantity() = %s, %v", tt.sales, tt.measurements, q, err)
+		}
+	}
+}
+
+func TestComparisonPrice(t *testing.T) {
+	for _, tt := range []struct {
+		sales, measurements string
+		shelfPrice, want    string
+	}{
+		{`{"priceByMeasureTypeCode": "NET_CONTENT"}`, pricedMeasurements, "2.49", "7.545454545454545455/KGM"},
+		{`{}`, pricedMeasurements, "2.10", "10.5/KGM"},
+		{`{"priceByMeasureTypeCode": "BY_COUNT"}`, pricedMeasurements, "2.70", "0.45/H87"},
+		{`{}`, `{"netContent": [{"$": 750, "@measurementUnitCode": "MLT"}]}`, "0.30", "0.4/LTR"},
+		{`{}`, `{"netContent": [{"$": 3, "@measurementUnitCode": "DZN"}]}`, "7.20", "0.2/H87"},
+	} {
+		p, err := pricedProduct(t, tt.sales, tt.measurements).ComparisonPrice(mustDecimal(tt.shelfPrice))
+		if err != nil || p.String() != tt.want {
+			t.Errorf("%s %s: ComparisonPrice(%s) = %s, %v, want %s", tt.sales, tt.measurements, tt.shelfPrice, p, err, tt.want)
+		}
+	}
+
+	d := pricedProduct(t, `{"sellingUnitOfMeasure": "KGM"}`, pricedMeasurements)
+	d.TradeItem.TradeItemInformation.Extension.VariableTradeItemInformationModule.VariableTradeItemInformation.IsTradeItemAVariableUnit = ValidBool(true)
+	p, err := d.ComparisonPrice(mustDecimal("12.90"))
+	if err != nil || p.String() != "12.9/KGM" || p.Quantity.String() != "1 KGM" {
+		t.Errorf("variable unit ComparisonPrice() = %+v, %v", p, err)
+	}
+
+	d = pricedProduct(t, `{}`, `{"netContent": [{"$": 25, "@measurementUnitCode": "CEL"}]}`)
+	if _, err := d.ComparisonPrice(mustDecimal("1")); !errors.Is(err, ErrIncompatibleUnits) {
+		t.Errorf("temperature ComparisonPrice() error = %v", err)
+	}
+}
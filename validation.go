@@ -0,0 +1,1047 @@
+package structs
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Severity tells how serious a validation finding is.
+type Severity int
+
+const (
+	// SeverityError marks data that is unusable or violates the format.
+	SeverityError Severity = iota
+	// SeverityWarning marks data that is suspicious but usable.
+	SeverityWarning
+)
+
+// String returns the lower case name of the severity.
+func (s Severity) String() string {
+	switch s {
+	case SeverityError:
+		return "error"
+	case SeverityWarning:
+		return "warning"
+	}
+	return fmt.Sprintf("severity(%d)", int(s))
+}
+
+// Validation rule IDs reported in ValidationError.Rule.
+const (
+	// A required field is missing or empty.
+	RuleRequired = "required"
+	// A numeric value is outside of its allowed range.
+	RuleRange = "range"
+	// A GTIN is invalid.
+	RuleGTIN = "gtin"
+	// The product GTIN fields identify different trade items.
+	RuleGTINMismatch = "gtin-mismatch"
+	// A GLN is invalid.
+	RuleGLN = "gln"
+	// A localized text has no language code.
+	RuleLanguageCode = "language-code"
+	// A measurement value has no unit of measure code.
+	RuleMeasurementUnit = "measurement-unit"
+	// A minimum value is greater than the corresponding maximum value.
+	RuleMinMax = "min-max"
+	// A period ends before it starts.
+	RulePeriod = "period"
+	// A text emphasis is outside of the emphasised text.
+	RuleEmphasis = "emphasis"
+	// A code is not one of the values allowed by its code list.
+	RuleCode = "code"
+	// Related values are inconsistent with each other.
+	RuleConsistency = "consistency"
+)
+
+// ValidationError is a single validation finding.
+type ValidationError struct {
+	// JSON pointer to the offending value, relative to the validated value.
+	Path string
+	// Rule ID, for example RuleRequired.
+	Rule string
+	// Severity of the finding.
+	Severity Severity
+	// Human readable description.
+	Message string
+}
+
+// Error implements error interface.
+func (e ValidationError) Error() string {
+	path := e.Path
+	if path == "" {
+		path = "/"
+	}
+	return fmt.Sprintf("%s: %s: %s (%s)", path, e.Severity, e.Message, e.Rule)
+}
+
+// ValidationErrors is a list of validation findings returned by the Validate
+// methods.
+type ValidationErrors []ValidationError
+
+// Error implements error interface.
+func (v ValidationErrors) Error() string {
+	msgs := make([]string, len(v))
+	for i, e := range v {
+		msgs[i] = e.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Errors returns the findings with SeverityError.
+func (v ValidationErrors) Errors() ValidationErrors {
+	return v.filter(SeverityError)
+}
+
+// Warnings returns the findings with SeverityWarning.
+func (v ValidationErrors) Warnings() ValidationErrors {
+	return v.filter(SeverityWarning)
+}
+
+func (v ValidationErrors) filter(s Severity) ValidationErrors {
+	var res ValidationErrors
+	for _, e := range v {
+		if e.Severity == s {
+			res = append(res, e)
+		}
+	}
+	return res
+}
+
+// validator collects validation findings.
+type validator struct {
+	errs ValidationErrors
+}
+
+func (v *validator) add(path, rule string, severity Severity, format string, args ...interface{}) {
+	v.errs = append(v.errs, ValidationError{
+		Path:     path,
+		Rule:     rule,
+		Severity: severity,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+func (v *validator) err() error {
+	if len(v.errs) == 0 {
+		return nil
+	}
+	return v.errs
+}
+
+func (v *validator) required(path, value string) {
+	if strings.TrimSpace(value) == "" {
+		v.add(path, RuleRequired, SeverityError, "value is required")
+	}
+}
+
+func (v *validator) percentage(path string, value float64) {
+	if value < 0 || value > 100 {
+		v.add(path, RuleRange, SeverityError, "percentage %v is not between 0 and 100", value)
+	}
+}
+
+func (v *validator) nonNegative(path string, value float64) {
+	if value < 0 {
+		v.add(path, RuleRange, SeverityError, "value %v is negative", value)
+	}
+}
+
+func (v *validator) measurement(path string, value Decimal, unitCode string) {
+	if value.Sign() < 0 {
+		v.add(JoinPointer(path, "$"), RuleRange, SeverityError, "value %v is negative", value)
+	}
+	if !value.IsZero() && unitCode == "" {
+		v.add(JoinPointer(path, "@measurementUnitCode"), RuleMeasurementUnit, SeverityError, "measurement unit code is required")
+	}
+}
+
+func (v *validator) localized(path, value, languageCode string) {
+	if languageCode == "" {
+		v.add(JoinPointer(path, "@languageCode"), RuleLanguageCode, SeverityError, "language code is required")
+	}
+	if strings.TrimSpace(value) == "" {
+		v.add(JoinPointer(path, "$"), RuleRequired, SeverityWarning, "text is empty")
+	}
+}
+
+func (v *validator) period(path string, start, end time.Time, startKey, endKey string) {
+	if !start.IsZero() && !end.IsZero() && end.Before(start) {
+		v.add(JoinPointer(path, endKey), RulePeriod, SeverityError, "end %s is before start %s",
+			end.Format(time.RFC3339), start.Format(time.RFC3339))
+	}
+}
+
+func (v *validator) emphasis(path, text string, startAt, length int) {
+	n := utf8.RuneCountInString(text)
+	if startAt < 0 || length < 0 || startAt+length > n {
+		v.add(path, RuleEmphasis, SeverityError, "emphasis [%d, %d) is outside of text with %d characters",
+			startAt, startAt+length, n)
+	}
+}
+
+// Validate checks the product for missing required fields, values out of range
+// and inconsistencies between fields. It returns nil or ValidationErrors with
+// paths relative to the product document.
+func (p MasterProductData) Validate() error {
+	v := &validator{}
+	p.validate(v, "")
+	return v.err()
+}
+
+func (p MasterProductData) validate(v *validator, path string) {
+	gtins := p.CheckGTINs()
+	for _, f := range gtins.Fields {
+		switch {
+		case f.Err == nil:
+		case f.Value == "":
+			v.add(path+f.Path, RuleRequired, SeverityError, "value is required")
+		default:
+			v.add(path+f.Path, RuleGTIN, SeverityError, "%v", f.Err)
+		}
+	}
+	for _, f := range gtins.Mismatches {
+		v.add(path+f.Path, RuleGTINMismatch, SeverityError, "gtin %s does not match product gtin %s", f.Value, gtins.GTIN)
+	}
+	if p.Header.XDataFormatVersion == "" {
+		v.add(JoinPointer(path, "Header", "x_dataFormatVersion"), RuleRequired, SeverityWarning, "data format version is missing")
+	}
+	p.TradeItem.validate(v, JoinPointer(path, "tradeItem"))
+}
+
+func (t MasterProductTradeItem) validate(v *validator, path string) {
+	id := t.XTradeItemIdentification
+	v.period(JoinPointer(path, "x_tradeItemIdentification"), id.StartDateTime, id.EndDateTime, "@startDateTime", "@endDateTime")
+	for i, id := range t.AdditionalTradeItemIdentifications {
+		p := JoinPointer(path, "additionalTradeItemIdentification", strconv.Itoa(i))
+		v.required(JoinPointer(p, "$"), id.ID)
+		v.required(JoinPointer(p, "@additionalTradeItemIdentificationTypeCode"), id.AdditionalTradeItemIdentificationTypeCode)
+		v.period(p, id.StartDateTime, id.EndDateTime, "@startDateTime", "@endDateTime")
+	}
+
+	provider := JoinPointer(path, "informationProviderOfTradeItem", "gln")
+	if err := GLN(t.InformationProviderOfTradeItem.GLN).Validate(); errors.Is(err, ErrGLNEmpty) {
+		v.add(provider, RuleRequired, SeverityError, "value is required")
+	} else if err != nil {
+		v.add(provider, RuleGLN, SeverityError, "%v", err)
+	}
+	for i, m := range t.ManufacturerOfTradeItems {
+		if m.GLN == "" {
+			continue
+		}
+		if err := GLN(m.GLN).Validate(); err != nil {
+			v.add(JoinPointer(path, "manufacturerOfTradeItem", strconv.Itoa(i), "gln"), RuleGLN, SeverityError, "%v", err)
+		}
+	}
+
+	v.required(JoinPointer(path, "gdsnTradeItemClassification", "gpcCategoryCode"), t.GdsnTradeItemClassification.GpcCategoryCode)
+	for i, ref := range t.ReferencedTradeItems {
+		v.required(JoinPointer(path, "referencedTradeItem", strconv.Itoa(i), "referencedTradeItemTypeCode"), ref.ReferencedTradeItemTypeCode)
+	}
+	v.required(JoinPointer(path, "targetMarket", "targetMarketCountryCode"), t.TargetMarkets.TargetMarketCountryCode)
+	for i, c := range t.TradeItemContactInformations {
+		p := JoinPointer(path, "tradeItemContactInformation", strconv.Itoa(i))
+		v.required(JoinPointer(p, "contactTypeCode"), c.ContactTypeCode)
+		for j, d := range c.ContactDescriptions {
+			v.localized(JoinPointer(p, "contactDescription", strconv.Itoa(j)), d.Description, d.LanguageCode)
+		}
+		for j, ch := range c.TargetMarketCommunicationChannels {
+			for k, cc := range ch.CommunicationChannels {
+				cp := JoinPointer(p, "targetMarketCommunicationChannel", strconv.Itoa(j), "communicationChannel", strconv.Itoa(k))
+				v.required(JoinPointer(cp, "communicationChannelCode"), cc.CommunicationChannelCode)
+				v.required(JoinPointer(cp, "communicationValue"), cc.CommunicationValue)
+			}
+		}
+	}
+	t.TradeItemInformation.Extension.validate(v, JoinPointer(path, "tradeItemInformation", "extensions"))
+}
+
+func (e TradeItemExtension) validate(v *validator, path string) {
+	e.AlcoholInformationModule.validate(v, JoinPointer(path, "alcoholInformationModule"))
+	e.AllergenInformationModule.validate(v, JoinPointer(path, "allergenInformationModule"))
+	e.ConsumerInstructionsModule.validate(v, JoinPointer(path, "consumerInstructionsModule"))
+	e.DangerousSubstanceInformationModule.validate(v, JoinPointer(path, "dangerousSubstanceInformationModule"))
+	e.DietInformationModule.validate(v, JoinPointer(path, "dietInformationModule"))
+	e.FarmingAndProcessingInformationModule.validate(v, JoinPointer(path, "farmingAndProcessingInformationModule"))
+	e.FoodAndBeverageIngredientModule.validate(v, JoinPointer(path, "foodAndBeverageIngredientModule"))
+	e.FoodAndBeveragePreparationServingModule.validate(v, JoinPointer(path, "foodAndBeveragePreparationServingModule"))
+	e.FoodAndBeveragePropertiesInformationModule.validate(v, JoinPointer(path, "foodAndBeveragePropertiesInformationModule"))
+	e.MarketingInformationModule.validate(v, JoinPointer(path, "marketingInformationModule"))
+	e.NonfoodIngredientModule.validate(v, JoinPointer(path, "nonfoodIngredientModule"))
+	e.NutritionalInformationModule.validate(v, JoinPointer(path, "nutritionalInformationModule"))
+	e.PackagingInformationModule.validate(v, JoinPointer(path, "packagingInformationModule"))
+	e.PlaceOfItemActivityModule.validate(v, JoinPointer(path, "placeOfItemActivityModule"))
+	e.ProductCharacteristicsModule.validate(v, JoinPointer(path, "productCharacteristicsModule"))
+	e.SafetyDataSheetModule.validate(v, JoinPointer(path, "safetyDataSheetModule"))
+	e.SalesInformationModule.validate(v, JoinPointer(path, "salesInformationModule"))
+	e.TradeItemDescriptionModule.validate(v, JoinPointer(path, "tradeItemDescriptionModule"))
+	e.TradeItemLifespanModule.validate(v, JoinPointer(path, "tradeItemLifespanModule"))
+	e.TradeItemMeasurementsModule.validate(v, JoinPointer(path, "tradeItemMeasurementsModule"))
+	e.TradeItemTemperatureInformationModule.validate(v, JoinPointer(path, "tradeItemTemperatureInformationModule"))
+	e.VariableTradeItemInformationModule.validate(v, JoinPointer(path, "variableTradeItemInformationModule"))
+	e.DGCodeListModule.validate(v, JoinPointer(path, "dgCodeListModule"))
+	e.DGMediaModule.validate(v, JoinPointer(path, "dgMediaModule"))
+	e.DGPresentationModule.validate(v, JoinPointer(path, "dgPresentationModule"))
+	e.DGProductAttributeModule.validate(v, JoinPointer(path, "dgProductAttributeModule"))
+}
+
+// Validate checks the module. Paths in the returned ValidationErrors are relative to the module.
+func (m AlcoholInformationModule) Validate() error {
+	v := &validator{}
+	m.validate(v, "")
+	return v.err()
+}
+
+func (m AlcoholInformationModule) validate(v *validator, path string) {
+	path = JoinPointer(path, "alcoholInformation")
+	v.percentage(JoinPointer(path, "percentageOfAlcoholByVolume"), m.AlcoholInformation.PercentageOfAlcoholByVolume.Float64)
+	for i, s := range m.AlcoholInformation.AlcoholicBeverageSugarContents {
+		v.measurement(JoinPointer(path, "alcoholicBeverageSugarContent", strconv.Itoa(i)), s.Measurement, s.MeasurementUnitCode)
+	}
+}
+
+// Allergen level of containment codes. Uses code list levelOfContainmentCode.
+const (
+	LevelOfContainmentContains    = "CONTAINS"
+	LevelOfContainmentMayContain  = "MAY_CONTAIN"
+	LevelOfContainmentFreeFrom    = "FREE_FROM"
+	LevelOfContainmentUndeclared  = "UNDECLARED"
+	LevelOfContainmentDerivedFrom = "DERIVED_FROM"
+)
+
+func isLevelOfContainmentCode(code string) bool {
+	switch code {
+	case LevelOfContainmentContains, LevelOfContainmentMayContain, LevelOfContainmentFreeFrom,
+		LevelOfContainmentUndeclared, LevelOfContainmentDerivedFrom:
+		return true
+	}
+	return false
+}
+
+// Validate checks the module. Paths in the returned ValidationErrors are relative to the module.
+func (m AllergenInformationModule) Validate() error {
+	v := &validator{}
+	m.validate(v, "")
+	return v.err()
+}
+
+func (m AllergenInformationModule) validate(v *validator, path string) {
+	for i, infos := range m.AllergenRelatedInformations {
+		for j, info := range infos {
+			p := JoinPointer(path, "allergenRelatedInformation", strconv.Itoa(i), strconv.Itoa(j))
+			v.required(JoinPointer(p, "allergenSpecificationAgency"), info.AllergenSpecificationAgency)
+			for k, s := range info.AllergenStatements {
+				sp := JoinPointer(p, "allergenStatement", strconv.Itoa(k))
+				v.localized(sp, s.Name, s.LanguageCode)
+				for l, e := range s.XEmphasis {
+					v.emphasis(JoinPointer(sp, "x_emphasis", strconv.Itoa(l)), s.Name, e.StartAt, e.Length)
+				}
+			}
+			seen := map[string]int{}
+			for k, a := range info.Allergens {
+				ap := JoinPointer(p, "allergen", strconv.Itoa(k))
+				v.required(JoinPointer(ap, "allergenTypeCode"), a.AllergenTypeCode)
+				switch {
+				case a.LevelOfContainmentCode == "":
+					v.add(JoinPointer(ap, "levelOfContainmentCode"), RuleRequired, SeverityError, "value is required")
+				case !isLevelOfContainmentCode(a.LevelOfContainmentCode):
+					v.add(JoinPointer(ap, "levelOfContainmentCode"), RuleCode, SeverityError,
+						"unknown level of containment code %q", a.LevelOfContainmentCode)
+				}
+				if prev, ok := seen[a.AllergenTypeCode]; ok && a.AllergenTypeCode != "" {
+					v.add(JoinPointer(ap, "allergenTypeCode"), RuleConsistency, SeverityWarning,
+						"allergen %s is already declared at index %d", a.AllergenTypeCode, prev)
+				} else {
+					seen[a.AllergenTypeCode] = k
+				}
+			}
+		}
+	}
+}
+
+// Validate checks the module. Paths in the returned ValidationErrors are relative to the module.
+func (m ConsumerInstructionsModule) Validate() error {
+	v := &validator{}
+	m.validate(v, "")
+	return v.err()
+}
+
+func (m ConsumerInstructionsModule) validate(v *validator, path string) {
+	path = JoinPointer(path, "consumerInstructions")
+	for i, s := range m.ConsumerInstructions.ConsumerStorageInstructions {
+		v.localized(JoinPointer(path, "consumerStorageInstructions", strconv.Itoa(i)), s.Instruction, s.LanguageCode)
+	}
+	for i, s := range m.ConsumerInstructions.ConsumerUsageInstructions {
+		v.localized(JoinPointer(path, "consumerUsageInstructions", strconv.Itoa(i)), s.Instruction, s.LanguageCode)
+	}
+}
+
+// Validate checks the module. Paths in the returned ValidationErrors are relative to the module.
+func (m DangerousSubstanceInformationModule) Validate() error {
+	v := &validator{}
+	m.validate(v, "")
+	return v.err()
+}
+
+func (m DangerousSubstanceInformationModule) validate(v *validator, path string) {
+	for i, info := range m.DangerousSubstanceInformations {
+		for j, prop := range info.DangerousSubstanceProperties {
+			p := JoinPointer(path, "dangerousSubstanceInformation", strconv.Itoa(i), "dangerousSubstanceProperties", strconv.Itoa(j))
+			if prop.IsDangerousSubstance.IsTrue() {
+				v.required(JoinPointer(p, "dangerousSubstanceName"), prop.DangerousSubstanceName)
+			}
+			for k, c := range prop.RiskPhraseCodes {
+				v.required(JoinPointer(p, "riskPhraseCode", strconv.Itoa(k), "externalAgencyName"), c.ExternalAgencyName)
+			}
+			for k, c := range prop.SafetyPhraseCodes {
+				v.required(JoinPointer(p, "safetyPhraseCode", strconv.Itoa(k), "externalAgencyName"), c.ExternalAgencyName)
+			}
+		}
+	}
+}
+
+// Validate checks the module. Paths in the returned ValidationErrors are relative to the module.
+func (m DietInformationModule) Validate() error {
+	v := &validator{}
+	m.validate(v, "")
+	return v.err()
+}
+
+func (m DietInformationModule) validate(v *validator, path string) {
+	path = JoinPointer(path, "dietInformation")
+	for i, d := range m.DietInformation.DietTypeDescriptions {
+		v.localized(JoinPointer(path, "dietTypeDescription", strconv.Itoa(i)), d.Description, d.LanguageCode)
+	}
+	for i, d := range m.DietInformation.DietTypeInformations {
+		v.required(JoinPointer(path, "dietTypeInformation", strconv.Itoa(i), "dietTypeCode"), d.DietTypeCode)
+	}
+}
+
+// Validate checks the module. Paths in the returned ValidationErrors are relative to the module.
+func (m FarmingAndProcessingInformationModule) Validate() error {
+	v := &validator{}
+	m.validate(v, "")
+	return v.err()
+}
+
+func (m FarmingAndProcessingInformationModule) validate(v *validator, path string) {
+	for i, c := range m.TradeItemOrganicInformation.OrganicClaims {
+		p := JoinPointer(path, "tradeItemOrganicInformation", "organicClaim", strconv.Itoa(i))
+		v.percentage(JoinPointer(p, "organicPercentClaim"), float64(c.OrganicPercentClaim.Int))
+	}
+	for i, avps := range m.AVPList.StringAVPs {
+		for j, avp := range avps {
+			v.required(JoinPointer(path, "avpList", "stringAVP", strconv.Itoa(i), strconv.Itoa(j), "@attributeName"), avp.AttributeName)
+		}
+	}
+}
+
+// Validate checks the module. Paths in the returned ValidationErrors are relative to the module.
+func (m FoodAndBeverageIngredientModule) Validate() error {
+	v := &validator{}
+	m.validate(v, "")
+	return v.err()
+}
+
+func (m FoodAndBeverageIngredientModule) validate(v *validator, path string) {
+	for i, s := range m.IngredientStatements {
+		v.localized(JoinPointer(path, "ingredientStatement", strconv.Itoa(i)), s.Name, s.LanguageCode)
+	}
+	v.percentage(JoinPointer(path, "juiceContentPercent"), m.JuiceContentPercent.Float64)
+	for i, a := range m.AdditiveInformations {
+		p := JoinPointer(path, "additiveInformation", strconv.Itoa(i))
+		v.required(JoinPointer(p, "additiveName"), a.AdditiveName)
+		if a.LevelOfContainmentCode != "" && !isLevelOfContainmentCode(a.LevelOfContainmentCode) {
+			v.add(JoinPointer(p, "levelOfContainmentCode"), RuleCode, SeverityError,
+				"unknown level of containment code %q", a.LevelOfContainmentCode)
+		}
+	}
+	total := 0.0
+	for i, ing := range m.FoodAndBeverageIngredients {
+		p := JoinPointer(path, "foodAndBeverageIngredient", strconv.Itoa(i))
+		v.percentage(JoinPointer(p, "ingredientContentPercentage"), ing.IngredientContentPercentage.Float64)
+		total += ing.IngredientContentPercentage.Float64
+		for j, names := range ing.IngredientNames {
+			for k, n := range names {
+				np := JoinPointer(p, "ingredientName", strconv.Itoa(j), strconv.Itoa(k))
+				v.localized(np, n.Name, n.LanguageCode)
+				for l, e := range n.XEmphasis {
+					v.emphasis(JoinPointer(np, "x_emphasis", strconv.Itoa(l)), n.Name, e.StartAt, e.Length)
+				}
+			}
+		}
+		for j, o := range ing.IngredientOrganicInformation.OrganicClaim {
+			v.percentage(JoinPointer(p, "ingredientOrganicInformation", "organicClaim", strconv.Itoa(j), "organicPercentClaim"), float64(o.OrganicPercentClaim.Int))
+		}
+	}
+	// Ingredients may be nested into groups, so the sum is only a hint.
+	if total > 100 {
+		v.add(JoinPointer(path, "foodAndBeverageIngredient"), RuleConsistency, SeverityWarning,
+			"ingredient content percentages sum up to %v", total)
+	}
+	for i, stmts := range m.XAdditionalIngredientStatements {
+		for j, s := range stmts {
+			v.localized(JoinPointer(path, "x_additionalIngredientStatement", strconv.Itoa(i), strconv.Itoa(j)), s.Statement, s.LanguageCode)
+		}
+	}
+}
+
+// Validate checks the module. Paths in the returned ValidationErrors are relative to the module.
+func (m FoodAndBeveragePreparationServingModule) Validate() error {
+	v := &validator{}
+	m.validate(v, "")
+	return v.err()
+}
+
+func (m FoodAndBeveragePreparationServingModule) validate(v *validator, path string) {
+	for i, s := range m.PreparationServings {
+		p := JoinPointer(path, "preparationServing", strconv.Itoa(i))
+		v.percentage(JoinPointer(p, "convenienceLevelPercent"), float64(s.ConvenienceLevelPercent.Int))
+		for j, in := range s.PreparationInstructions {
+			v.localized(JoinPointer(p, "preparationInstructions", strconv.Itoa(j)), in.Instruction, in.LanguageCode)
+		}
+		for j, in := range s.ServingSuggestions {
+			v.localized(JoinPointer(p, "servingSuggestion", strconv.Itoa(j)), in.Suggestion, in.LanguageCode)
+		}
+		for j, y := range s.ProductYieldInformations {
+			yp := JoinPointer(p, "productYieldInformation", strconv.Itoa(j))
+			v.measurement(JoinPointer(yp, "productYield"), y.ProductYield.Measurement, y.ProductYield.MeasurementUnitCode)
+			v.required(JoinPointer(yp, "productYieldTypeCode"), y.ProductYieldTypeCode)
+		}
+	}
+}
+
+// Validate checks the module. Paths in the returned ValidationErrors are relative to the module.
+func (m FoodAndBeveragePropertiesInformationModule) Validate() error {
+	v := &validator{}
+	m.validate(v, "")
+	return v.err()
+}
+
+func (m FoodAndBeveragePropertiesInformationModule) validate(v *validator, path string) {
+	for i, c := range m.PhysiochemicalCharacteristics {
+		p := JoinPointer(path, "physiochemicalCharacteristic", strconv.Itoa(i))
+		v.required(JoinPointer(p, "physiochemicalCharacteristicCode"), c.PhysiochemicalCharacteristicCode)
+		for j, val := range c.PhysiochemicalCharacteristicValues {
+			// Physiochemical values such as freezing point may be negative.
+			if val.MeasurementUnitCode == "" {
+				v.add(JoinPointer(p, "physiochemicalCharacteristicValue", strconv.Itoa(j), "@measurementUnitCode"),
+					RuleMeasurementUnit, SeverityError, "measurement unit code is required")
+			}
+		}
+	}
+}
+
+// Validate checks the module. Paths in the returned ValidationErrors are relative to the module.
+func (m MarketingInformationModule) Validate() error {
+	v := &validator{}
+	m.validate(v, "")
+	return v.err()
+}
+
+func (m MarketingInformationModule) validate(v *validator, path string) {
+	path = JoinPointer(path, "marketingInformation")
+	for i, msg := range m.MarketingInformation.TradeItemMarketingMessages {
+		v.localized(JoinPointer(path, "tradeItemMarketingMessage", strconv.Itoa(i)), msg.Message, msg.LanguageCode)
+	}
+	for i, kw := range m.MarketingInformation.TradeItemKeyWords {
+		v.localized(JoinPointer(path, "tradeItemKeyWords", strconv.Itoa(i)), kw.KeyWord, kw.LanguageCode)
+	}
+}
+
+// Validate checks the module. Paths in the returned ValidationErrors are relative to the module.
+func (m NonfoodIngredientModule) Validate() error {
+	v := &validator{}
+	m.validate(v, "")
+	return v.err()
+}
+
+func (m NonfoodIngredientModule) validate(v *validator, path string) {
+	for i, s := range m.NonfoodIngredientStatements {
+		v.localized(JoinPointer(path, "nonfoodIngredientStatement", strconv.Itoa(i)), s.Statement, s.LanguageCode)
+	}
+	for i, a := range m.AdditiveInformations {
+		p := JoinPointer(path, "additiveInformation", strconv.Itoa(i))
+		v.required(JoinPointer(p, "additiveName"), a.AdditiveName)
+		if a.LevelOfContainmentCode != "" && !isLevelOfContainmentCode(a.LevelOfContainmentCode) {
+			v.add(JoinPointer(p, "levelOfContainmentCode"), RuleCode, SeverityError,
+				"unknown level of containment code %q", a.LevelOfContainmentCode)
+		}
+	}
+	for i, ing := range m.NonfoodIngredients {
+		p := JoinPointer(path, "nonfoodIngredient", strconv.Itoa(i))
+		v.required(JoinPointer(p, "ingredientName"), ing.IngredientName)
+		for j, e := range ing.XEmphasis {
+			v.emphasis(JoinPointer(p, "x_emphasis", strconv.Itoa(j)), ing.IngredientName, e.StartAt, e.Length)
+		}
+	}
+}
+
+// Validate checks the module. Paths in the returned ValidationErrors are relative to the module.
+func (m NutritionalInformationModule) Validate() error {
+	v := &validator{}
+	m.validate(v, "")
+	return v.err()
+}
+
+func (m NutritionalInformationModule) validate(v *validator, path string) {
+	for i, c := range m.NutritionalClaims {
+		v.localized(JoinPointer(path, "nutritionalClaim", strconv.Itoa(i)), c.Claim, c.LanguageCode)
+	}
+	for i, c := range m.NutritionalClaimDetails {
+		p := JoinPointer(path, "nutritionalClaimDetail", strconv.Itoa(i))
+		v.required(JoinPointer(p, "nutritionalClaimTypeCode"), c.NutritionalClaimTypeCode)
+		v.required(JoinPointer(p, "nutritionalClaimNutrientElementCode"), c.NutritionalClaimNutrientElementCode)
+	}
+	for i, h := range m.NutrientHeaders {
+		p := JoinPointer(path, "nutrientHeader", strconv.Itoa(i))
+		v.required(JoinPointer(p, "preparationStateCode"), h.PreparationStateCode)
+		basis := h.NutrientBasisQuantity
+		v.measurement(JoinPointer(p, "nutrientBasisQuantity"), basis.Measurement, basis.MeasurementUnitCode)
+		if basis.Measurement.IsZero() && len(h.ServingSizes) == 0 {
+			v.add(JoinPointer(p, "nutrientBasisQuantity"), RuleRequired, SeverityError,
+				"nutrient basis quantity or serving size is required")
+		}
+		for j, s := range h.ServingSizes {
+			v.measurement(JoinPointer(p, "servingSize", strconv.Itoa(j)), s.Measurement, s.MeasurementUnitCode)
+		}
+		for j, d := range h.ServingSizeDescriptions {
+			v.localized(JoinPointer(p, "servingSizeDescription", strconv.Itoa(j)), d.Description, d.LanguageCode)
+		}
+		seen := map[string]int{}
+		for j, d := range h.NutrientDetails {
+			dp := JoinPointer(p, "nutrientDetail", strconv.Itoa(j))
+			v.required(JoinPointer(dp, "nutrientTypeCode"), d.NutrientTypeCode)
+			if prev, ok := seen[d.NutrientTypeCode]; ok && d.NutrientTypeCode != "" {
+				v.add(JoinPointer(dp, "nutrientTypeCode"), RuleConsistency, SeverityWarning,
+					"nutrient %s is already declared at index %d", d.NutrientTypeCode, prev)
+			} else {
+				seen[d.NutrientTypeCode] = j
+			}
+			v.nonNegative(JoinPointer(dp, "dailyValueIntakePercent"), d.DailyValueIntakePercent.Float64)
+			for k, q := range d.QuantityContaineds {
+				v.measurement(JoinPointer(dp, "quantityContained", strconv.Itoa(k)), q.Measurement, q.MeasurementUnitCode)
+			}
+		}
+	}
+}
+
+// Validate checks the module. Paths in the returned ValidationErrors are relative to the module.
+func (m PackagingInformationModule) Validate() error {
+	v := &validator{}
+	m.validate(v, "")
+	return v.err()
+}
+
+func (m PackagingInformationModule) validate(v *validator, path string) {
+	for i, p := range m.Packagings {
+		pp := JoinPointer(path, "packaging", strconv.Itoa(i))
+		v.required(JoinPointer(pp, "packagingTypeCode"), p.PackagingTypeCode)
+		for j, mat := range p.PackagingMaterials {
+			v.required(JoinPointer(pp, "packagingMaterial", strconv.Itoa(j), "packagingMaterialTypeCode"), mat.PackagingMaterialTypeCode)
+		}
+	}
+}
+
+// Validate checks the module. Paths in the returned ValidationErrors are relative to the module.
+func (m PlaceOfItemActivityModule) Validate() error {
+	v := &validator{}
+	m.validate(v, "")
+	return v.err()
+}
+
+func (m PlaceOfItemActivityModule) validate(v *validator, path string) {
+	path = JoinPointer(path, "placeOfProductActivity")
+	a := m.PlaceOfProductActivity
+	for i, s := range a.CountryOfOriginStatements {
+		v.localized(JoinPointer(path, "countryOfOriginStatement", strconv.Itoa(i)), s.Value, s.LanguageCode)
+	}
+	for i, s := range a.ProvenanceStatements {
+		v.localized(JoinPointer(path, "provenanceStatement", strconv.Itoa(i)), s.Value, s.LanguageCode)
+	}
+	for i, c := range a.CountryOfOrigins {
+		v.required(JoinPointer(path, "countryOfOrigin", strconv.Itoa(i), "countryCode"), c.CountryCode)
+	}
+	for i, d := range a.ProductActivityDetails {
+		p := JoinPointer(path, "productActivityDetails", strconv.Itoa(i))
+		v.required(JoinPointer(p, "productActivityTypeCode"), d.ProductActivityTypeCode)
+		for j, s := range d.XStatements {
+			v.localized(JoinPointer(p, "x_statement", strconv.Itoa(j)), s.Statement, s.LanguageCode)
+		}
+	}
+}
+
+// Validate checks the module. Paths in the returned ValidationErrors are relative to the module.
+func (m ProductCharacteristicsModule) Validate() error {
+	v := &validator{}
+	m.validate(v, "")
+	return v.err()
+}
+
+func (m ProductCharacteristicsModule) validate(v *validator, path string) {
+	for i, c := range m.ProductCharacteristics {
+		p := JoinPointer(path, "productCharacteristics", strconv.Itoa(i))
+		v.required(JoinPointer(p, "productCharacteristicCode"), c.ProductCharacteristicCode)
+		if len(c.ProductCharacteristicValueDescriptions) == 0 && len(c.ProductCharacteristicValueString) == 0 {
+			v.add(p, RuleRequired, SeverityWarning, "product characteristic has no value")
+		}
+		for j, descs := range c.ProductCharacteristicValueDescriptions {
+			for k, d := range descs {
+				v.localized(JoinPointer(p, "productCharacteristicValueDescription", strconv.Itoa(j), strconv.Itoa(k)), d.Description, d.LanguageCode)
+			}
+		}
+	}
+}
+
+// Validate checks the module. Paths in the returned ValidationErrors are relative to the module.
+func (m SafetyDataSheetModule) Validate() error {
+	v := &validator{}
+	m.validate(v, "")
+	return v.err()
+}
+
+func (m SafetyDataSheetModule) validate(v *validator, path string) {
+	for i, info := range m.SafetyDataSheetInformations {
+		p := JoinPointer(path, "safetyDataSheetInformation", strconv.Itoa(i))
+		ghs := JoinPointer(p, "gHSDetail")
+		for j, h := range info.GHSDetail.HazardStatements {
+			for k, d := range h.HazardStatementsDescriptions {
+				v.localized(JoinPointer(ghs, "hazardStatement", strconv.Itoa(j), "hazardStatementsDescription", strconv.Itoa(k)), d.Description, d.LanguageCode)
+			}
+		}
+		for j, s := range info.GHSDetail.PrecautionaryStatements {
+			for k, d := range s.PrecautionaryStatementsDescriptions {
+				v.localized(JoinPointer(ghs, "precautionaryStatement", strconv.Itoa(j), "precautionaryStatementsDescription", strconv.Itoa(k)), d.Description, d.LanguageCode)
+			}
+		}
+
+		props := JoinPointer(p, "physicalChemicalPropertyInformation")
+		for j, fp := range info.PhysicalChemicalPropertyInformation.FlashPoints {
+			for k, t := range fp.FlashPointTemperatures {
+				if t.TemperatureMeasurementUnitCode == "" {
+					v.add(JoinPointer(props, "flashPoint", strconv.Itoa(j), "flashPointTemperature", strconv.Itoa(k), "@temperatureMeasurementUnitCode"),
+						RuleMeasurementUnit, SeverityError, "temperature measurement unit code is required")
+				}
+			}
+		}
+		ph := info.PhysicalChemicalPropertyInformation.PHInformation
+		pp := JoinPointer(props, "pHInformation")
+		for _, f := range []struct {
+			key   string
+			value NullFloat64
+		}{
+			{"exactPH", ph.ExactPH},
+			{"minimumPH", ph.MinimumPH},
+			{"maximumPH", ph.MaximumPH},
+		} {
+			if f.value.Valid && (f.value.Float64 < 0 || f.value.Float64 > 14) {
+				v.add(JoinPointer(pp, f.key), RuleRange, SeverityError, "pH %v is not between 0 and 14", f.value.Float64)
+			}
+		}
+		if ph.MinimumPH.Valid && ph.MaximumPH.Valid && ph.MinimumPH.Float64 > ph.MaximumPH.Float64 {
+			v.add(JoinPointer(pp, "minimumPH"), RuleMinMax, SeverityError,
+				"minimum pH %v is greater than maximum pH %v", ph.MinimumPH.Float64, ph.MaximumPH.Float64)
+		}
+	}
+}
+
+// Validate checks the module. Paths in the returned ValidationErrors are relative to the module.
+func (m SalesInformationModule) Validate() error {
+	v := &validator{}
+	m.validate(v, "")
+	return v.err()
+}
+
+func (m SalesInformationModule) validate(v *validator, path string) {
+	path = JoinPointer(path, "salesInformation")
+	s := m.SalesInformation
+	for i, c := range s.PriceComparisonMeasurements {
+		p := JoinPointer(path, "priceComparisonMeasurement", strconv.Itoa(i))
+		v.measurement(p, c.Measurement, c.MeasurementUnitCode)
+		if c.Measurement.IsZero() {
+			v.add(JoinPointer(p, "$"), RuleRange, SeverityError, "price comparison measurement must be positive")
+		}
+	}
+	v.nonNegative(JoinPointer(path, "x_sellingContentIncrement"), float64(s.XSellingContentIncrement))
+	v.nonNegative(JoinPointer(path, "x_sellingContentInitial"), float64(s.XSellingContentInitial))
+	if (s.XSellingContentIncrement != 0 || s.XSellingContentInitial != 0) && s.XSellingUnitOfMeasureCode == "" {
+		v.add(JoinPointer(path, "x_sellingUnitOfMeasureCode"), RuleRequired, SeverityError,
+			"selling unit of measure code is required with selling content")
+	}
+}
+
+// Validate checks the module. Paths in the returned ValidationErrors are relative to the module.
+func (m TradeItemDescriptionModule) Validate() error {
+	v := &validator{}
+	m.validate(v, "")
+	return v.err()
+}
+
+func (m TradeItemDescriptionModule) validate(v *validator, path string) {
+	path = JoinPointer(path, "tradeItemDescriptionInformation")
+	d := m.TradeItemDescriptionInformation
+	for i, t := range d.AdditionalTradeItemDescriptions {
+		v.localized(JoinPointer(path, "additionalTradeItemDescription", strconv.Itoa(i)), t.Description, t.LanguageCode)
+	}
+	for i, t := range d.DescriptionShorts {
+		v.localized(JoinPointer(path, "descriptionShort", strconv.Itoa(i)), t.Description, t.LanguageCode)
+	}
+	for i, t := range d.FunctionalNames {
+		v.localized(JoinPointer(path, "functionalName", strconv.Itoa(i)), t.Name, t.LanguageCode)
+	}
+	if len(d.TradeItemDescriptions) == 0 {
+		v.add(JoinPointer(path, "tradeItemDescription"), RuleRequired, SeverityWarning, "trade item description is missing")
+	}
+	for i, t := range d.TradeItemDescriptions {
+		v.localized(JoinPointer(path, "tradeItemDescription", strconv.Itoa(i)), t.Description, t.LanguageCode)
+	}
+	for i, t := range d.VariantDescriptions {
+		v.localized(JoinPointer(path, "variantDescription", strconv.Itoa(i)), t.Description, t.LanguageCode)
+	}
+	b := JoinPointer(path, "brandNameInformation")
+	for i, t := range d.BrandNameInformation.LanguageSpecificBrandNames {
+		v.localized(JoinPointer(b, "languageSpecificBrandName", strconv.Itoa(i)), t.Name, t.LanguageCode)
+	}
+	for i, t := range d.BrandNameInformation.LanguageSpecificSubbrandNames {
+		v.localized(JoinPointer(b, "languageSpecificSubbrandName", strconv.Itoa(i)), t.Name, t.LanguageCode)
+	}
+}
+
+// Validate checks the module. Paths in the returned ValidationErrors are relative to the module.
+func (m TradeItemLifespanModule) Validate() error {
+	v := &validator{}
+	m.validate(v, "")
+	return v.err()
+}
+
+func (m TradeItemLifespanModule) validate(v *validator, path string) {
+	path = JoinPointer(path, "tradeItemLifespan")
+	l := m.TradeItemLifespan
+	v.nonNegative(JoinPointer(path, "minimumTradeItemLifespanFromTimeOfProduction"), float64(l.MinimumTradeItemLifespanFromTimeOfProduction.Int))
+	v.nonNegative(JoinPointer(path, "openedTradeItemLifespan"), float64(l.OpenedTradeItemLifespan.Int))
+}
+
+// Validate checks the module. Paths in the returned ValidationErrors are relative to the module.
+func (m TradeItemMeasurementsModule) Validate() error {
+	v := &validator{}
+	m.validate(v, "")
+	return v.err()
+}
+
+func (m TradeItemMeasurementsModule) validate(v *validator, path string) {
+	path = JoinPointer(path, "tradeItemMeasurements")
+	t := m.TradeItemMeasurements
+	v.measurement(JoinPointer(path, "depth"), t.Depth.Value, t.Depth.MeasurementUnitCode)
+	v.measurement(JoinPointer(path, "height"), t.Height.Value, t.Height.MeasurementUnitCode)
+	v.measurement(JoinPointer(path, "width"), t.Width.Value, t.Width.MeasurementUnitCode)
+	for i, c := range t.NetContent {
+		p := JoinPointer(path, "netContent", strconv.Itoa(i))
+		v.measurement(p, c.Measurement, c.MeasurementUnitCode)
+		if c.Measurement.IsZero() {
+			v.add(JoinPointer(p, "$"), RuleRange, SeverityError, "net content must be positive")
+		}
+	}
+	w := JoinPointer(path, "tradeItemWeight")
+	weight := t.TradeItemWeight
+	v.measurement(JoinPointer(w, "drainedWeight"), weight.DrainedWeight.Measurement, weight.DrainedWeight.MeasurementUnitCode)
+	v.measurement(JoinPointer(w, "grossWeight"), weight.GrossWeight.Measurement, weight.GrossWeight.MeasurementUnitCode)
+	v.measurement(JoinPointer(w, "netWeight"), weight.NetWeight.Measurement, weight.NetWeight.MeasurementUnitCode)
+	if !weight.GrossWeight.Measurement.IsZero() && weightExceeds(weight.NetWeight.Quantity(), weight.GrossWeight.Quantity()) {
+		v.add(JoinPointer(w, "netWeight"), RuleConsistency, SeverityError, "net weight is greater than gross weight")
+	}
+	if !weight.NetWeight.Measurement.IsZero() && weightExceeds(weight.DrainedWeight.Quantity(), weight.NetWeight.Quantity()) {
+		v.add(JoinPointer(w, "drainedWeight"), RuleConsistency, SeverityError, "drained weight is greater than net weight")
+	}
+}
+
+// weightExceeds reports whether weight a is greater than weight b. Weights in
+// different units are compared in the unit of a. Weights with unknown units or
+// units of different dimensions are not compared.
+func weightExceeds(a, b Quantity) bool {
+	c, err := a.Compare(b)
+	return err == nil && c > 0
+}
+
+// Validate checks the module. Paths in the returned ValidationErrors are relative to the module.
+func (m TradeItemTemperatureInformationModule) Validate() error {
+	v := &validator{}
+	m.validate(v, "")
+	return v.err()
+}
+
+func (m TradeItemTemperatureInformationModule) validate(v *validator, path string) {
+	for i, t := range m.TradeItemTemperatureInformations {
+		p := JoinPointer(path, "tradeItemTemperatureInformation", strconv.Itoa(i))
+		v.required(JoinPointer(p, "temperatureQualifierCode"), t.TemperatureQualifierCode)
+		for _, f := range []struct {
+			key  string
+			temp GDSNTemperature
+		}{
+			{"maximumTemperature", t.MaximumTemperature},
+			{"maximumToleranceTemperature", t.MaximumToleranceTemperature},
+			{"minimumTemperature", t.MinimumTemperature},
+			{"minumumToleranceTemperature", t.MinumumToleranceTemperature},
+		} {
+			if !f.temp.Temperature.IsZero() && f.temp.TemperatureMeasurementUnitCode == "" {
+				v.add(JoinPointer(p, f.key, "@temperatureMeasurementUnitCode"), RuleMeasurementUnit, SeverityError,
+					"temperature measurement unit code is required")
+			}
+		}
+		r, err := t.Range(UnitDegreeCelsius)
+		switch {
+		case err != nil:
+			v.add(p, RuleMeasurementUnit, SeverityError, "%v", err)
+		case r.Inverted():
+			v.add(JoinPointer(p, "minimumTemperature"), RuleMinMax, SeverityError,
+				"minimum temperature is greater than maximum temperature")
+		}
+	}
+}
+
+// Validate checks the module. Paths in the returned ValidationErrors are relative to the module.
+func (m VariableTradeItemInformationModule) Validate() error {
+	v := &validator{}
+	m.validate(v, "")
+	return v.err()
+}
+
+func (m VariableTradeItemInformationModule) validate(v *validator, path string) {
+	path = JoinPointer(path, "variableTradeItemInformation")
+	info := m.VariableTradeItemInformation
+	v.percentage(JoinPointer(path, "variableWeightAllowableDeviationPercentage"), float64(info.VariableWeightAllowableDeviationPercentage.Int))
+	if info.IsTradeItemAVariableUnit.IsFalse() && info.VariableWeightAllowableDeviationPercentage.Valid {
+		v.add(JoinPointer(path, "variableWeightAllowableDeviationPercentage"), RuleConsistency, SeverityWarning,
+			"allowable deviation is given for a fixed unit trade item")
+	}
+}
+
+// Validate checks the module. Paths in the returned ValidationErrors are relative to the module.
+func (m DGCodeListModule) Validate() error {
+	v := &validator{}
+	m.validate(v, "")
+	return v.err()
+}
+
+func (m DGCodeListModule) validate(v *validator, path string) {
+	for i, l := range m.CodeLists {
+		p := JoinPointer(path, "codeList", strconv.Itoa(i))
+		v.required(JoinPointer(p, "codeListName"), l.CodeListName)
+		if l.IsExternalCodeList {
+			v.required(JoinPointer(p, "externalAgencyName"), l.ExternalAgencyName)
+			v.required(JoinPointer(p, "externalCodeListName"), l.ExternalCodeListName)
+		}
+		for j, r := range l.CodeListRecords {
+			v.required(JoinPointer(p, "codeListRecord", strconv.Itoa(j), "code"), r.Code)
+		}
+	}
+}
+
+// Validate checks the module. Paths in the returned ValidationErrors are relative to the module.
+func (m DGMediaModule) Validate() error {
+	v := &validator{}
+	m.validate(v, "")
+	return v.err()
+}
+
+func (m DGMediaModule) validate(v *validator, path string) {
+	seen := map[int]int{}
+	for i, media := range m.Media {
+		p := JoinPointer(path, "media", strconv.Itoa(i))
+		if prev, ok := seen[media.MediaSequence]; ok {
+			v.add(JoinPointer(p, "mediaSequence"), RuleConsistency, SeverityWarning,
+				"media sequence %d is already used at index %d", media.MediaSequence, prev)
+		} else {
+			seen[media.MediaSequence] = i
+		}
+		v.nonNegative(JoinPointer(p, "mediaSequence"), float64(media.MediaSequence))
+		v.required(JoinPointer(p, "mediaStorageKey"), media.MediaStorageKey)
+		v.nonNegative(JoinPointer(p, "mediaDimensionWidth"), float64(media.MediaDimensionWidth))
+		v.nonNegative(JoinPointer(p, "mediaDimensionHeight"), float64(media.MediaDimensionHeight))
+		for j, n := range media.MediaNames {
+			v.localized(JoinPointer(p, "mediaName", strconv.Itoa(j)), n.Name, n.LanguageCode)
+		}
+		for j, d := range media.MediaStateDescriptions {
+			v.localized(JoinPointer(p, "mediaStateDescription", strconv.Itoa(j)), d.Description, d.LanguageCode)
+		}
+		if media.MediaProvider.Gln != "" {
+			if err := GLN(media.MediaProvider.Gln).Validate(); err != nil {
+				v.add(JoinPointer(p, "mediaProvider", "gln"), RuleGLN, SeverityError, "%v", err)
+			}
+		}
+	}
+}
+
+// Validate checks the module. Paths in the returned ValidationErrors are relative to the module.
+func (m DGPresentationModule) Validate() error {
+	v := &validator{}
+	m.validate(v, "")
+	return v.err()
+}
+
+func (m DGPresentationModule) validate(v *validator, path string) {
+	for i, vis := range m.ProductConsumerVisibilities {
+		v.period(JoinPointer(path, "productConsumerVisibility", strconv.Itoa(i)), vis.StartDateTime, vis.EndDateTime, "startDateTime", "endDateTime")
+	}
+	for i, c := range m.PresentationCategories {
+		p := JoinPointer(path, "presentationCategory", strconv.Itoa(i))
+		v.required(JoinPointer(p, "treeName"), c.TreeName)
+		v.required(JoinPointer(p, "extId"), c.ExtID)
+		v.period(JoinPointer(p, "validityPeriod"), c.ValidityPeriods.StartDateTime, c.ValidityPeriods.EndDateTime, "startDateTime", "endDateTime")
+	}
+}
+
+// Validate checks the module. Paths in the returned ValidationErrors are relative to the module.
+func (m DGProductAttributeModule) Validate() error {
+	v := &validator{}
+	m.validate(v, "")
+	return v.err()
+}
+
+func (m DGProductAttributeModule) validate(v *validator, path string) {
+	groups := map[string]int{}
+	for i, g := range m.ProductAttributeGroups {
+		p := JoinPointer(path, "productAttributeGroup", strconv.Itoa(i))
+		v.required(JoinPointer(p, "productAttributeGroupExtId"), g.ProductAttributeGroupExtID)
+		if prev, ok := groups[g.ProductAttributeGroupExtID]; ok && g.ProductAttributeGroupExtID != "" {
+			v.add(JoinPointer(p, "productAttributeGroupExtId"), RuleConsistency, SeverityError,
+				"attribute group %s is already declared at index %d", g.ProductAttributeGroupExtID, prev)
+		} else {
+			groups[g.ProductAttributeGroupExtID] = i
+		}
+		for j, n := range g.ProductAttributeGroupNames {
+			v.localized(JoinPointer(p, "productAttributeGroupName", strconv.Itoa(j)), n.Name, n.LanguageCode)
+		}
+		attrs := map[string]int{}
+		for j, a := range g.ProductAttributes {
+			ap := JoinPointer(p, "productAttribute", strconv.Itoa(j))
+			v.required(JoinPointer(ap, "productAttributeExtId"), a.ProductAttributeExtID)
+			if prev, ok := attrs[a.ProductAttributeExtID]; ok && a.ProductAttributeExtID != "" {
+				v.add(JoinPointer(ap, "productAttributeExtId"), RuleConsistency, SeverityError,
+					"attribute %s is already declared at index %d", a.ProductAttributeExtID, prev)
+			} else {
+				attrs[a.ProductAttributeExtID] = j
+			}
+			v.required(JoinPointer(ap, "productAttributeTypeCode"), a.ProductAttributeTypeCode)
+			values := 0
+			for _, given := range []bool{len(a.ProductAttributeValueStrings) > 0, a.ProductAttributeValueNumeric.Valid, a.ProductAttributeValueBoolean.Valid} {
+				if given {
+					values++
+				}
+			}
+			if values != 1 {
+				v.add(ap, RuleConsistency, SeverityError,
+					"exactly one of string, numeric and boolean value is required, %d given", values)
+			}
+			for k, n := range a.ProductAttributeNames {
+				v.localized(JoinPointer(ap, "productAttributeName", strconv.Itoa(k)), n.Name, n.LanguageCode)
+			}
+			for k, s := range a.ProductAttributeValueStrings {
+				v.localized(JoinPointer(ap, "productAttributeValueString", strconv.Itoa(k)), s.Value, s.LanguageCode)
+			}
+		}
+	}
+}
@@ -0,0 +1,158 @@
+package structs
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+// findings returns the validation findings of err, failing the test when err
+// is not nil or ValidationErrors.
+func findings(t *testing.T, err error) ValidationErrors {
+	t.Helper()
+	if err == nil {
+		return nil
+	}
+	var errs ValidationErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("error %v is not ValidationErrors", err)
+	}
+	return errs
+}
+
+// hasFinding reports whether errs contains a finding of the rule at path.
+func hasFinding(errs ValidationErrors, path, rule string) bool {
+	for _, e := range errs {
+		if e.Path == path && e.Rule == rule {
+			return true
+		}
+	}
+	return false
+}
+
+func TestValidateWeights(t *testing.T) {
+	const (
+		net     = "/tradeItemMeasurements/tradeItemWeight/netWeight"
+		drained = "/tradeItemMeasurements/tradeItemWeight/drainedWeight"
+	)
+	for _, tt := range []struct {
+		name   string
+		weight string
+		paths  []string
+	}{
+		{
+			name:   "same unit",
+			weight: `{"grossWeight": {"$": "500", "@measurementUnitCode": "GRM"}, "netWeight": {"$": "450", "@measurementUnitCode": "GRM"}}`,
+		},
+		{
+			name:   "net greater than gross",
+			weight: `{"grossWeight": {"$": "500", "@measurementUnitCode": "GRM"}, "netWeight": {"$": "550", "@measurementUnitCode": "GRM"}}`,
+			paths:  []string{net},
+		},
+		{
+			name:   "converted units",
+			weight: `{"grossWeight": {"$": "0.5", "@measurementUnitCode": "KGM"}, "netWeight": {"$": "450", "@measurementUnitCode": "GRM"}}`,
+		},
+		{
+			name:   "net greater than gross in other unit",
+			weight: `{"grossWeight": {"$": "0.5", "@measurementUnitCode": "KGM"}, "netWeight": {"$": "501", "@measurementUnitCode": "GRM"}}`,
+			paths:  []string{net},
+		},
+		{
+			name:   "drained greater than net in other unit",
+			weight: `{"drainedWeight": {"$": "16", "@measurementUnitCode": "ONZ"}, "netWeight": {"$": "0.45", "@measurementUnitCode": "KGM"}}`,
+			paths:  []string{drained},
+		},
+		{
+			name:   "drained equal to net in other unit",
+			weight: `{"drainedWeight": {"$": "1", "@measurementUnitCode": "LBR"}, "netWeight": {"$": "453.59237", "@measurementUnitCode": "GRM"}}`,
+		},
+		{
+			name:   "unknown unit",
+			weight: `{"grossWeight": {"$": "1", "@measurementUnitCode": "XYZ"}, "netWeight": {"$": "2", "@measurementUnitCode": "GRM"}}`,
+		},
+		{
+			name:   "missing gross weight",
+			weight: `{"netWeight": {"$": "2", "@measurementUnitCode": "GRM"}}`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var m TradeItemMeasurementsModule
+			doc := `{"tradeItemMeasurements": {"tradeItemWeight": ` + tt.weight + `}}`
+			if err := json.Unmarshal([]byte(doc), &m); err != nil {
+				t.Fatal(err)
+			}
+			errs := findings(t, m.Validate())
+			var got []string
+			for _, e := range errs {
+				if e.Rule == RuleConsistency {
+					got = append(got, e.Path)
+				}
+			}
+			if len(got) != len(tt.paths) {
+				t.Fatalf("consistency findings %v, want %v (all findings: %v)", got, tt.paths, errs)
+			}
+			for i := range got {
+				if got[i] != tt.paths[i] {
+					t.Errorf("finding %d at %s, want %s", i, got[i], tt.paths[i])
+				}
+			}
+		})
+	}
+}
+
+func TestValidateMeasurementUnit(t *testing.T) {
+	var m TradeItemMeasurementsModule
+	doc := `{"tradeItemMeasurements": {"netContent": [{"$": "750"}, {"$": "-1", "@measurementUnitCode": "MLT"}]}}`
+	if err := json.Unmarshal([]byte(doc), &m); err != nil {
+		t.Fatal(err)
+	}
+	errs := findings(t, m.Validate())
+	if !hasFinding(errs, "/tradeItemMeasurements/netContent/0/@measurementUnitCode", RuleMeasurementUnit) {
+		t.Errorf("missing unit code not reported: %v", errs)
+	}
+	if !hasFinding(errs, "/tradeItemMeasurements/netContent/1/$", RuleRange) {
+		t.Errorf("negative net content not reported: %v", errs)
+	}
+}
+
+func TestValidateProduct(t *testing.T) {
+	var p MasterProductData
+	p.Gtin = "4006381333931"
+	p.TradeItem.GTIN = "96385074"
+	p.TradeItem.InformationProviderOfTradeItem.GLN = "4012345000008"
+	p.TradeItem.ManufacturerOfTradeItems = []ManufacturerOfTradeItem{{}, {GLN: "12"}}
+
+	errs := findings(t, p.Validate())
+	for _, want := range []struct {
+		path, rule string
+	}{
+		{"/tradeItem/gtin", RuleGTINMismatch},
+		{"/tradeItem/informationProviderOfTradeItem/gln", RuleGLN},
+		{"/tradeItem/manufacturerOfTradeItem/1/gln", RuleGLN},
+		{"/tradeItem/gdsnTradeItemClassification/gpcCategoryCode", RuleRequired},
+		{"/tradeItem/targetMarket/targetMarketCountryCode", RuleRequired},
+	} {
+		if !hasFinding(errs, want.path, want.rule) {
+			t.Errorf("no %s finding at %s: %v", want.rule, want.path, errs)
+		}
+	}
+	if hasFinding(errs, "/tradeItem/manufacturerOfTradeItem/0/gln", RuleGLN) {
+		t.Error("empty manufacturer GLN reported")
+	}
+	if len(errs.Warnings()) == 0 {
+		t.Error("missing data format version not reported as warning")
+	}
+	for _, e := range errs.Errors() {
+		if e.Severity != SeverityError {
+			t.Errorf("Errors() returned %v", e)
+		}
+	}
+}
+
+func TestValidationErrorString(t *testing.T) {
+	e := ValidationError{Rule: RuleRequired, Severity: SeverityWarning, Message: "value is required"}
+	if got, want := e.Error(), "/: warning: value is required (required)"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,178 @@
+package structs
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+var (
+	// ErrNotVariableMeasure is returned for trade items which are not sold
+	// by a variable measure.
+	ErrNotVariableMeasure = errors.New("not a variable measure trade item")
+	// ErrInvalidOrderQuantity is returned when a requested quantity is not
+	// a valid order quantity of a variable measure trade item.
+	ErrInvalidOrderQuantity = errors.New("invalid order quantity")
+	// ErrTooManyQuantities is returned by VariableMeasure.Upto when there
+	// are more than MaxOrderQuantities valid order quantities.
+	ErrTooManyQuantities = errors.New("too many order quantities")
+)
+
+// MaxOrderQuantities is the largest number of order quantities
+// VariableMeasure.Upto returns.
+const MaxOrderQuantities = 10000
+
+// VariableMeasure describes how a variable measure trade item, for example
+// cheese sold by weight at a deli counter, can be ordered. Valid order
+// quantities are Initial, Initial + Increment, Initial + 2 * Increment and so on.
+type VariableMeasure struct {
+	// Selling unit of measure, for example GRM.
+	UnitCode string
+	// First valid order quantity in UnitCode.
+	Initial Decimal
+	// Step between valid order quantities in UnitCode.
+	Increment Decimal
+	// Percentage the actual measure may differ from the ordered measure.
+	// Invalid when not given.
+	AllowableDeviationPercentage NullFloat64
+}
+
+// VariableMeasure returns the ordering rules of a variable measure trade
+// item. The selling content initial and increment are given in the selling
+// unit of measure. When only one of them is given, it is used for both.
+// ErrNotVariableMeasure is returned when the trade item is not a variable
+// unit, has no known selling unit of measure or has no selling content.
+func (d MasterProductData) VariableMeasure() (VariableMeasure, error) {
+	unit := d.sellingUnit()
+	if unit == "" {
+		return VariableMeasure{}, ErrNotVariableMeasure
+	}
+	ext := d.TradeItem.TradeItemInformation.Extension
+	s := ext.SalesInformationModule.SalesInformation
+	m := VariableMeasure{
+		UnitCode:  unit,
+		Initial:   DecimalFromInt(s.XSellingContentInitial),
+		Increment: DecimalFromInt(s.XSellingContentIncrement),
+	}
+	switch {
+	case m.Initial.Sign() <= 0 && m.Increment.Sign() <= 0:
+		return VariableMeasure{}, fmt.Errorf("no selling content: %w", ErrNotVariableMeasure)
+	case m.Initial.Sign() <= 0:
+		m.Initial = m.Increment
+	case m.Increment.Sign() <= 0:
+		m.Increment = m.Initial
+	}
+	if dev := ext.VariableTradeItemInformationModule.VariableTradeItemInformation.VariableWeightAllowableDeviationPercentage; dev.Valid {
+		m.AllowableDeviationPercentage = ValidFloat64(float64(dev.Int))
+	}
+	return m, nil
+}
+
+// Nth returns the nth valid order quantity. The first one is Nth(0).
+func (m VariableMeasure) Nth(n int) Quantity {
+	v := m.Increment.Rat()
+	v.Mul(v, big.NewRat(int64(n), 1))
+	v.Add(v, m.Initial.Rat())
+	return NewQuantity(DecimalFromRat(v), m.UnitCode)
+}
+
+// Quantities returns the first n valid order quantities.
+func (m VariableMeasure) Quantities(n int) []Quantity {
+	res := make([]Quantity, n)
+	for i := range res {
+		res[i] = m.Nth(i)
+	}
+	return res
+}
+
+// steps returns the exact number of increments from the initial quantity to
+// q, which is in UnitCode.
+func (m VariableMeasure) steps(q Quantity) (*big.Rat, error) {
+	inc := m.Increment.Rat()
+	if inc.Sign() <= 0 {
+		return nil, fmt.Errorf("increment %v: %w", m.Increment, ErrNotVariableMeasure)
+	}
+	v := q.Value.Rat()
+	v.Sub(v, m.Initial.Rat())
+	return v.Quo(v, inc), nil
+}
+
+// Upto returns the valid order quantities which are not greater than max.
+// ErrTooManyQuantities is returned when there are more than
+// MaxOrderQuantities of them.
+func (m VariableMeasure) Upto(max Quantity) ([]Quantity, error) {
+	q, err := max.ConvertTo(m.UnitCode)
+	if err != nil {
+		return nil, err
+	}
+	steps, err := m.steps(q)
+	if err != nil {
+		return nil, err
+	}
+	if steps.Sign() < 0 {
+		return nil, nil
+	}
+	n := new(big.Int).Quo(steps.Num(), steps.Denom())
+	if n.Cmp(big.NewInt(MaxOrderQuantities-1)) > 0 {
+		return nil, fmt.Errorf("%s up to %s: %w", m.Nth(0), max, ErrTooManyQuantities)
+	}
+	return m.Quantities(int(n.Int64()) + 1), nil
+}
+
+// ValidateQuantity checks that q is a valid order quantity. The quantity may
+// be given in any unit of the same dimension as the selling unit.
+func (m VariableMeasure) ValidateQuantity(q Quantity) error {
+	c, err := q.ConvertTo(m.UnitCode)
+	if err != nil {
+		return err
+	}
+	steps, err := m.steps(c)
+	if err != nil {
+		return err
+	}
+	if steps.Sign() < 0 {
+		return fmt.Errorf("%s is less than %s: %w", q, m.Nth(0), ErrInvalidOrderQuantity)
+	}
+	if !steps.IsInt() {
+		return fmt.Errorf("%s is not %s plus a multiple of %s: %w",
+			q, m.Nth(0), NewQuantity(m.Increment, m.UnitCode), ErrInvalidOrderQuantity)
+	}
+	return nil
+}
+
+// PriceRange is the estimated price of a variable measure quantity. Prices are
+// computed exactly and rounded like DecimalFromRat, not to the precision of
+// the currency.
+type PriceRange struct {
+	// Price of the lightest or smallest allowed actual measure.
+	Min Decimal
+	// Price of the ordered measure.
+	Estimate Decimal
+	// Price of the heaviest or largest allowed actual measure.
+	Max Decimal
+}
+
+// PriceRange returns the estimated price of quantity q for the given price
+// per one selling unit of measure. The range is widened by the allowable
+// deviation percentage. Without it, Min and Max equal Estimate.
+func (m VariableMeasure) PriceRange(unitPrice Decimal, q Quantity) (PriceRange, error) {
+	c, err := q.ConvertTo(m.UnitCode)
+	if err != nil {
+		return PriceRange{}, err
+	}
+	p := c.Value.Rat()
+	p.Mul(p, unitPrice.Rat())
+	// deviate returns the price p changed by percent.
+	deviate := func(percent float64) Decimal {
+		r := DecimalFromFloat(percent).Rat()
+		r.Add(r, big.NewRat(100, 1))
+		r.Mul(r, p)
+		return DecimalFromRat(r.Quo(r, big.NewRat(100, 1)))
+	}
+	r := PriceRange{Min: deviate(0), Estimate: deviate(0), Max: deviate(0)}
+	if dev := m.AllowableDeviationPercentage; dev.Valid {
+		r.Min = deviate(-dev.Float64)
+		r.Max = deviate(dev.Float64)
+	}
+	return r, nil
+}
@@ -0,0 +1,100 @@
+package structs
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func variableProduct(t *testing.T, sales string) MasterProductData {
+	t.Helper()
+	var d MasterProductData
+	data := `{"tradeItem": {"tradeItemInformation": {"extensions": {
+		"salesInformationModule": {"salesInformation": ` + sales + `},
+		"variableTradeItemInformationModule": {"variableTradeItemInformation": {
+			"isTradeItemAVariableUnit": true, "variableWeightAllowableDeviationPercentage": 10
+		}}
+	}}}}`
+	if err := json.Unmarshal([]byte(data), &d); err != nil {
+		t.Fatal(err)
+	}
+	return d
+}
+
+func TestVariableMeasure(t *testing.T) {
+	m, err := variableProduct(t, `{"x_sellingUnitOfMeasureCode": "GRM", "x_sellingContentInitial": 150, "x_sellingContentIncrement": 50}`).VariableMeasure()
+	want := VariableMeasure{UnitCode: UnitGram, Initial: DecimalFromInt(150), Increment: DecimalFromInt(50), AllowableDeviationPercentage: ValidFloat64(10)}
+	if err != nil || m != want {
+		t.Fatalf("VariableMeasure() = %+v, %v", m, err)
+	}
+	if m, _ := variableProduct(t, `{"sellingUnitOfMeasure": "KGM", "x_sellingContentIncrement": 1}`).VariableMeasure(); m.Initial != DecimalFromInt(1) || m.UnitCode != UnitKilogram {
+		t.Errorf("VariableMeasure() without initial = %+v", m)
+	}
+	for _, sales := range []string{`{"x_sellingUnitOfMeasureCode": "GRM"}`, `{"x_sellingUnitOfMeasureCode": "XYZ", "x_sellingContentInitial": 1}`} {
+		if _, err := variableProduct(t, sales).VariableMeasure(); !errors.Is(err, ErrNotVariableMeasure) {
+			t.Errorf("%s: VariableMeasure() error = %v", sales, err)
+		}
+	}
+	var fixed MasterProductData
+	if _, err := fixed.VariableMeasure(); !errors.Is(err, ErrNotVariableMeasure) {
+		t.Errorf("fixed measure VariableMeasure() error = %v", err)
+	}
+}
+
+func TestVariableMeasureQuantities(t *testing.T) {
+	m := VariableMeasure{UnitCode: UnitKilogram, Initial: mustDecimal("0.1"), Increment: mustDecimal("0.2")}
+	var got []string
+	for _, q := range m.Quantities(4) {
+		got = append(got, q.String())
+	}
+	if want := []string{"0.1 KGM", "0.3 KGM", "0.5 KGM", "0.7 KGM"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Quantities(4) = %q, want %q", got, want)
+	}
+	if qs, err := m.Upto(quantity("700", UnitGram)); err != nil || len(qs) != 4 {
+		t.Errorf("Upto(700 GRM) = %v, %v", qs, err)
+	}
+	if qs, err := m.Upto(quantity("50", UnitGram)); err != nil || len(qs) != 0 {
+		t.Errorf("Upto(50 GRM) = %v, %v", qs, err)
+	}
+	if _, err := m.Upto(quantity("1", UnitLitre)); !errors.Is(err, ErrIncompatibleUnits) {
+		t.Errorf("Upto(1 LTR) error = %v", err)
+	}
+	if qs, err := m.Upto(quantity("1999.9", UnitKilogram)); err != nil || len(qs) != MaxOrderQuantities || qs[len(qs)-1].String() != "1999.9 KGM" {
+		t.Errorf("Upto(1999.9 KGM) = %d quantities, %v", len(qs), err)
+	}
+	for _, max := range []string{"2000.1", "1e19"} {
+		if qs, err := m.Upto(quantity(max, UnitKilogram)); !errors.Is(err, ErrTooManyQuantities) {
+			t.Errorf("Upto(%s KGM) = %d quantities, %v", max, len(qs), err)
+		}
+	}
+
+	for _, tt := range []struct {
+		q     Quantity
+		valid bool
+	}{
+		{quantity("0.1", UnitKilogram), true},
+		{quantity("900", UnitGram), true},
+		{quantity("0.2", UnitKilogram), false},
+		{quantity("0", UnitKilogram), false},
+	} {
+		if err := m.ValidateQuantity(tt.q); (err == nil) != tt.valid || err != nil && !errors.Is(err, ErrInvalidOrderQuantity) {
+			t.Errorf("ValidateQuantity(%s) = %v", tt.q, err)
+		}
+	}
+	if err := (VariableMeasure{UnitCode: UnitGram, Initial: DecimalFromInt(100)}).ValidateQuantity(quantity("100", UnitGram)); !errors.Is(err, ErrNotVariableMeasure) {
+		t.Errorf("ValidateQuantity without increment error = %v", err)
+	}
+}
+
+func TestVariableMeasurePriceRange(t *testing.T) {
+	m := VariableMeasure{UnitCode: UnitKilogram, Initial: mustDecimal("0.1"), Increment: mustDecimal("0.1"), AllowableDeviationPercentage: ValidFloat64(10)}
+	r, err := m.PriceRange(mustDecimal("19.90"), quantity("300", UnitGram))
+	if err != nil || r.Min.String() != "5.373" || r.Estimate.String() != "5.97" || r.Max.String() != "6.567" {
+		t.Errorf("PriceRange() = %v, %v", r, err)
+	}
+	m.AllowableDeviationPercentage = NullFloat64{}
+	if r, err := m.PriceRange(mustDecimal("10"), quantity("0.25", UnitKilogram)); err != nil || r.Min != r.Estimate || r.Max.String() != "2.5" {
+		t.Errorf("PriceRange() without deviation = %v, %v", r, err)
+	}
+}